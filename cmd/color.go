@@ -0,0 +1,67 @@
+package cmd
+
+import (
+	"fmt"
+	"os"
+
+	"github.com/mandelsoft/spiff/dynaml"
+)
+
+const (
+	COLOR_AUTO   = "auto"
+	COLOR_ALWAYS = "always"
+	COLOR_NEVER  = "never"
+)
+
+const (
+	colorRed   = "31"
+	colorGreen = "32"
+)
+
+var colorMode string
+var noColor bool
+
+// setupColor validates the color options and enables colored
+// parse errors for the dynaml parser if colors are enabled for stderr.
+func setupColor() error {
+	if noColor {
+		colorMode = COLOR_NEVER
+	}
+	switch colorMode {
+	case COLOR_AUTO, COLOR_ALWAYS, COLOR_NEVER:
+	default:
+		return fmt.Errorf("invalid color mode %q (use auto, always or never)", colorMode)
+	}
+	dynaml.Pretty = colorEnabled(os.Stderr) && errorFormat != "json"
+	return nil
+}
+
+// colorEnabled checks whether colored output should be used for the given
+// output file. In auto mode colors are used only for terminals and if the
+// environment variable NO_COLOR is not set.
+func colorEnabled(f *os.File) bool {
+	switch colorMode {
+	case COLOR_ALWAYS:
+		return true
+	case COLOR_NEVER:
+		return false
+	}
+	if os.Getenv("NO_COLOR") != "" {
+		return false
+	}
+	return isTerminal(f)
+}
+
+// isTerminal checks whether the given file is a terminal.
+func isTerminal(f *os.File) bool {
+	info, err := f.Stat()
+	return err == nil && info.Mode()&os.ModeCharDevice != 0
+}
+
+// colored wraps a text with the given ANSI color code, if enabled.
+func colored(enabled bool, code string, text string) string {
+	if !enabled {
+		return text
+	}
+	return "\x1b[" + code + "m" + text + "\x1b[0m"
+}
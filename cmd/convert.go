@@ -36,6 +36,7 @@ func init() {
 	rootCmd.AddCommand(convertCmd)
 
 	convertCmd.Flags().BoolVar(&asJSON, "json", false, "print output in json format")
+	convertCmd.Flags().IntVar(&jsonIndent, "json-indent", 0, "indent json output by the given number of spaces (0 for compact output)")
 	convertCmd.Flags().StringVar(&outputPath, "path", "", "output is taken from given path")
 	convertCmd.Flags().BoolVar(&split, "split", false, "if the output is alist it will be split into separate documents")
 	convertCmd.Flags().StringArrayVar(&selection, "select", []string{}, "filter dedicated output fields")
@@ -98,7 +99,7 @@ func convert(stdin bool, templateFilePath string, json, split bool, subpath stri
 				if list, ok := flowed.Value().([]yaml.Node); ok {
 					for _, d := range list {
 						if json {
-							bytes, err = yaml.ToJSON(d)
+							bytes, err = yaml.ToJSONIndent(d, jsonIndent)
 						} else {
 							bytes, err = candiedyaml.Marshal(d)
 						}
@@ -111,7 +112,7 @@ func convert(stdin bool, templateFilePath string, json, split bool, subpath stri
 				}
 			}
 			if json {
-				bytes, err = yaml.ToJSON(flowed)
+				bytes, err = yaml.ToJSONIndent(flowed, jsonIndent)
 			} else {
 				bytes, err = candiedyaml.Marshal(flowed)
 			}
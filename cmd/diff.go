@@ -4,6 +4,7 @@ import (
 	"errors"
 	"fmt"
 	"log"
+	"os"
 	"path"
 	"strings"
 
@@ -83,6 +84,7 @@ func diff(aFilePath, bFilePath string, separator string) {
 		fmt.Println("no differences!")
 		return
 	}
+	color := colorEnabled(os.Stdout)
 	for no := range aYAMLs {
 		if len(ddiffs[no]) == 0 {
 			if len(aYAMLs) > 1 {
@@ -103,7 +105,7 @@ func diff(aFilePath, bFilePath string, separator string) {
 						panic(err)
 					}
 
-					fmt.Printf("  %s has:\n    \x1b[31m%s\x1b[0m\n", aFilePath, strings.Replace(string(ayaml), "\n", "\n    ", -1))
+					fmt.Printf("  %s has:\n    %s\n", aFilePath, colored(color, colorRed, strings.Replace(string(ayaml), "\n", "\n    ", -1)))
 				}
 
 				if diff.B != nil {
@@ -112,7 +114,7 @@ func diff(aFilePath, bFilePath string, separator string) {
 						panic(err)
 					}
 
-					fmt.Printf("  %s has:\n    \x1b[32m%s\x1b[0m\n", bFilePath, strings.Replace(string(byaml), "\n", "\n    ", -1))
+					fmt.Printf("  %s has:\n    %s\n", bFilePath, colored(color, colorGreen, strings.Replace(string(byaml), "\n", "\n    ", -1)))
 				}
 
 				fmt.Printf(separator)
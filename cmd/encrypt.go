@@ -7,6 +7,7 @@ import (
 	"log"
 	"os"
 	"path"
+	"strings"
 
 	"github.com/spf13/cobra"
 
@@ -57,7 +58,7 @@ func encrypt(decrypt bool, args []string) {
 	}
 
 	key := features.EncryptionKey()
-	method := passwd.TRIPPLEDES
+	method := ""
 	v := ""
 	if len(args) > 1 {
 		v = args[1]
@@ -71,6 +72,7 @@ func encrypt(decrypt bool, args []string) {
 			key = v
 		}
 	case 3:
+		key = v
 		method = args[2]
 	}
 
@@ -78,17 +80,17 @@ func encrypt(decrypt bool, args []string) {
 		log.Fatalln("invalid empty encyption key")
 	}
 
-	e := passwd.GetEncoding(method)
-	if e == nil {
-		log.Fatalf("invalid encyption method %q", method)
-	}
-
 	if key == "" {
 		log.Fatalf("invalid empty encyption key")
 	}
 
 	if decrypt {
-		result, err := e.Decode(string(file), key)
+		text := strings.TrimSpace(string(file))
+		e, err := passwd.DetectEncoding(text, method)
+		if err != nil {
+			log.Fatalln(err)
+		}
+		result, err := e.Decode(text, key)
 		if err != nil {
 			log.Fatalln(fmt.Sprintf("error decoding data [%s]:", path.Clean(filePath)), err)
 		}
@@ -98,6 +100,13 @@ func encrypt(decrypt bool, args []string) {
 		if err != nil {
 			log.Fatalln(err)
 		}
+		if method == "" {
+			method = passwd.TRIPPLEDES
+		}
+		e := passwd.GetEncoding(method)
+		if e == nil {
+			log.Fatalf("invalid encyption method %q", method)
+		}
 		result, err := e.Encode(string(file), key)
 		if err != nil {
 			log.Fatalln(err)
@@ -0,0 +1,175 @@
+package cmd
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"log"
+	"os"
+	"regexp"
+	"strconv"
+	"strings"
+
+	"github.com/mandelsoft/spiff/dynaml"
+	"github.com/mandelsoft/spiff/flow"
+	"github.com/mandelsoft/spiff/yaml"
+)
+
+const (
+	STAGE_ARGUMENTS = "arguments"
+	STAGE_READ      = "read"
+	STAGE_PARSE     = "parse"
+	STAGE_CHECK     = "check"
+	STAGE_EVALUATE  = "evaluate"
+	STAGE_OUTPUT    = "output"
+)
+
+var errorFormat string
+
+// errorReport is the machine readable description of an error
+// used for the error format json.
+// For dynaml syntax errors line and column describe the position
+// in the expression, for yaml syntax errors and evaluation errors
+// the position in the file.
+type errorReport struct {
+	Stage      string   `json:"stage"`
+	File       string   `json:"file,omitempty"`
+	Path       string   `json:"path,omitempty"`
+	Expression string   `json:"expression,omitempty"`
+	Line       int      `json:"line,omitempty"`
+	Column     int      `json:"column,omitempty"`
+	ExprLine   int      `json:"expr_line,omitempty"`
+	ExprColumn int      `json:"expr_column,omitempty"`
+	Message    string   `json:"message"`
+	Chain      []string `json:"chain,omitempty"`
+}
+
+var yamlPosition = regexp.MustCompile(`at line (\d+), column (\d+)`)
+
+func checkErrorFormat() {
+	switch errorFormat {
+	case "text", "json":
+	default:
+		log.Fatalf("invalid error format %q (use text or json)\n", errorFormat)
+	}
+}
+
+// fail reports an error according to the selected error format and exits.
+// The text arguments are used for the regular text format.
+func fail(stage, file string, err error, text ...interface{}) {
+	progressLine.Clear()
+	if errorFormat == "json" {
+		reportErrors(newErrorReports(stage, file, err, text...)...)
+	}
+	if len(text) > 0 && colorEnabled(os.Stderr) {
+		if s, ok := text[0].(string); ok {
+			text = append([]interface{}{colored(true, colorRed, s)}, text[1:]...)
+		}
+	}
+	log.Fatalln(text...)
+}
+
+func reportErrors(reports ...errorReport) {
+	enc := json.NewEncoder(os.Stderr)
+	enc.SetEscapeHTML(false)
+	for _, r := range reports {
+		enc.Encode(r)
+	}
+	os.Exit(1)
+}
+
+func newErrorReports(stage, file string, err error, text ...interface{}) []errorReport {
+	var unresolved dynaml.UnresolvedNodes
+	if errors.As(err, &unresolved) {
+		return unresolvedReports(stage, unresolved)
+	}
+	switch e := err.(type) {
+	case *flow.SyntaxError:
+		r := errorReport{
+			Stage:      stage,
+			File:       e.File,
+			Path:       flow.PathString(e.Path),
+			Expression: "(( " + e.Expression + " ))",
+			Message:    e.Err.Error(),
+		}
+		if e.Node != nil {
+			if pos := e.Node.Position(); pos.IsValid() {
+				r.Line, r.Column = pos.Line, pos.Column
+			}
+		}
+		if perr, ok := e.Err.(*dynaml.ParseError); ok {
+			r.ExprLine, r.ExprColumn = perr.Line, perr.Symbol
+		}
+		return []errorReport{r}
+	}
+
+	r := errorReport{Stage: stage, File: file}
+	if err != nil {
+		r.Message = err.Error()
+		if perr, ok := err.(*dynaml.ParseError); ok {
+			r.ExprLine, r.ExprColumn = perr.Line, perr.Symbol
+		} else if m := yamlPosition.FindStringSubmatch(r.Message); m != nil {
+			r.Line, _ = strconv.Atoi(m[1])
+			r.Column, _ = strconv.Atoi(m[2])
+		}
+	} else {
+		r.Message = strings.TrimSuffix(fmt.Sprintln(text...), "\n")
+	}
+	return []errorReport{r}
+}
+
+func unresolvedReports(stage string, e dynaml.UnresolvedNodes) []errorReport {
+	var reports []errorReport
+	for _, n := range e.Nodes {
+		r := unresolvedNodeReport(stage, n)
+		r.Chain = e.ReferenceChain(n)
+		reports = append(reports, r)
+	}
+	return reports
+}
+
+func unresolvedNodeReport(stage string, n dynaml.UnresolvedNode) errorReport {
+	r := errorReport{
+		Stage: stage,
+		File:  n.SourceName(),
+		Path:  flow.PathString(n.Context),
+	}
+	if pos := n.Position(); pos.IsValid() {
+		r.Line, r.Column = pos.Line, pos.Column
+	}
+	switch v := n.Value().(type) {
+	case dynaml.Expression:
+		r.Expression = fmt.Sprintf("(( %s ))", v)
+	case string:
+		r.Expression = v
+		if sub := yaml.EmbeddedDynaml(n, false); sub != nil {
+			if _, err := dynaml.Parse(*sub, nil, nil); err != nil {
+				if perr, ok := err.(*dynaml.ParseError); ok {
+					r.ExprLine, r.ExprColumn = perr.Line, perr.Symbol
+				}
+			}
+		}
+	}
+	issue := n.Issue()
+	msgs := []string{}
+	if issue.Issue != "" {
+		msgs = append(msgs, issue.Issue)
+	}
+	msgs = append(msgs, nestedMessages(issue)...)
+	if len(msgs) == 0 {
+		msgs = append(msgs, "unresolved")
+	}
+	r.Message = strings.Join(msgs, ": ")
+	return r
+}
+
+func nestedMessages(issue yaml.Issue) []string {
+	var msgs []string
+	for _, sub := range issue.Nested {
+		if sub.Issue != "" {
+			msgs = append(msgs, sub.Issue)
+		}
+		msgs = append(msgs, nestedMessages(sub)...)
+	}
+	return msgs
+}
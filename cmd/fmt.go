@@ -0,0 +1,133 @@
+package cmd
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"io/ioutil"
+	"log"
+	"os"
+	"path"
+
+	"github.com/spf13/cobra"
+
+	"github.com/mandelsoft/spiff/flow"
+	"github.com/mandelsoft/spiff/legacy/candiedyaml"
+	"github.com/mandelsoft/spiff/yaml"
+)
+
+var fmtWrite bool
+var fmtCheck bool
+
+// fmtCmd represents the fmt command
+var fmtCmd = &cobra.Command{
+	Use:   "fmt",
+	Short: "Format templates",
+	Long: `The given template files are re-emitted in a canonical yaml layout.
+Dynaml expressions are not evaluated, but rendered with canonical spacing.`,
+	Args: func(cmd *cobra.Command, args []string) error {
+		if len(args) < 1 {
+			return errors.New("requires at least one arg")
+		}
+		return nil
+	},
+	Run: func(cmd *cobra.Command, args []string) {
+		if fmtWrite && fmtCheck {
+			log.Fatalln("options --write and --check are exclusive")
+		}
+		unformatted := false
+		for _, file := range args {
+			if !format(file) {
+				unformatted = true
+			}
+		}
+		if unformatted {
+			os.Exit(1)
+		}
+	},
+}
+
+func init() {
+	rootCmd.AddCommand(fmtCmd)
+
+	fmtCmd.Flags().BoolVarP(&fmtWrite, "write", "w", false, "write the result to the source file instead of stdout")
+	fmtCmd.Flags().BoolVar(&fmtCheck, "check", false, "only list files that are not formatted")
+}
+
+// format formats a single file according to the selected options.
+// It returns false, if the check option is given and the file
+// is not formatted.
+func format(file string) bool {
+	var data []byte
+	var err error
+
+	if file == "-" {
+		data, err = ioutil.ReadAll(os.Stdin)
+	} else {
+		data, err = ReadFile(file)
+	}
+	if err != nil {
+		log.Fatalln(fmt.Sprintf("error reading template [%s]:", path.Clean(file)), err)
+	}
+
+	formatted, complete, err := formatDocuments(file, data)
+	if err != nil {
+		log.Fatalln(fmt.Sprintf("error formatting template [%s]:", path.Clean(file)), err)
+	}
+
+	switch {
+	case fmtCheck:
+		if !bytes.Equal(data, formatted) {
+			fmt.Println(file)
+			return false
+		}
+	case fmtWrite && file != "-":
+		if !bytes.Equal(data, formatted) {
+			if !complete {
+				log.Fatalln(fmt.Sprintf("cannot write template [%s]: comments not attached to map keys or list entries would be lost", path.Clean(file)))
+			}
+			info, err := os.Stat(file)
+			if err != nil {
+				log.Fatalln(fmt.Sprintf("error writing template [%s]:", path.Clean(file)), err)
+			}
+			err = ioutil.WriteFile(file, formatted, info.Mode())
+			if err != nil {
+				log.Fatalln(fmt.Sprintf("error writing template [%s]:", path.Clean(file)), err)
+			}
+		}
+	default:
+		os.Stdout.Write(formatted)
+	}
+	return true
+}
+
+// formatDocuments formats all documents of a source. Comments of map keys
+// and list entries are preserved, all other comments are dropped. The additional result
+// reports whether all comments could be preserved.
+func formatDocuments(file string, data []byte) ([]byte, bool, error) {
+	docs, err := yaml.ParseMulti(file, data)
+	if err != nil {
+		return nil, false, err
+	}
+	comments, err := yaml.ExtractComments(data)
+	if err != nil {
+		return nil, false, err
+	}
+	count, err := yaml.CountComments(data)
+	if err != nil {
+		return nil, false, err
+	}
+	preserved := 0
+	result, err := yaml.MarshalMultiWith(docs, func(i int, doc yaml.Node) ([]byte, error) {
+		out, err := candiedyaml.Marshal(flow.Format(doc))
+		if err != nil || i >= len(comments) {
+			return out, err
+		}
+		preserved += comments[i].Count()
+		return yaml.InsertComments(out, comments[i])
+	})
+	if err != nil {
+		return nil, false, err
+	}
+	return result, preserved >= count, nil
+}
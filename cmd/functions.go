@@ -0,0 +1,48 @@
+package cmd
+
+import (
+	"encoding/json"
+	"fmt"
+	"log"
+	"os"
+
+	"github.com/spf13/cobra"
+
+	"github.com/mandelsoft/spiff/dynaml"
+)
+
+// listFunctionsCmd represents the list-functions command
+var listFunctionsCmd = &cobra.Command{
+	Use:   "list-functions",
+	Short: "List available dynaml functions",
+	Long:  `List all available dynaml functions with their arity and a short description.`,
+	Args:  cobra.NoArgs,
+	Run: func(cmd *cobra.Command, args []string) {
+		listFunctions(asJSON)
+	},
+}
+
+func init() {
+	rootCmd.AddCommand(listFunctionsCmd)
+
+	listFunctionsCmd.Flags().BoolVar(&asJSON, "json", false, "print output in json format")
+}
+
+func listFunctions(asJSON bool) {
+	infos := dynaml.ListFunctions(nil)
+	if asJSON {
+		enc := json.NewEncoder(os.Stdout)
+		enc.SetIndent("", "  ")
+		if err := enc.Encode(infos); err != nil {
+			log.Fatalln("error marshalling function list:", err)
+		}
+		return
+	}
+	for _, i := range infos {
+		desc := i.Description
+		if i.Deprecated != "" {
+			desc += fmt.Sprintf(" (deprecated, use %s)", i.Deprecated)
+		}
+		fmt.Printf("%-20s %-5s %s\n", i.Name, i.Arity, desc)
+	}
+}
@@ -0,0 +1,79 @@
+package cmd
+
+import (
+	"errors"
+	"fmt"
+	"io/ioutil"
+	"log"
+	"os"
+	"path"
+
+	"github.com/spf13/cobra"
+
+	"github.com/mandelsoft/spiff/flow"
+	"github.com/mandelsoft/spiff/yaml"
+)
+
+var graphFormat string
+
+// graphCmd represents the graph command
+var graphCmd = &cobra.Command{
+	Use:   "graph",
+	Short: "Show the dependency graph of a template",
+	Long: `Output the dependency graph of the dynaml expressions of a template merged
+with the given stubs in Graphviz DOT or Mermaid format. Dependency cycles
+are colored red.`,
+	Args: func(cmd *cobra.Command, args []string) error {
+		if len(args) < 1 {
+			return errors.New("requires at least one arg")
+		}
+		return nil
+	},
+	Run: func(cmd *cobra.Command, args []string) {
+		graph(args[0], args[1:])
+	},
+}
+
+func init() {
+	rootCmd.AddCommand(graphCmd)
+
+	graphCmd.Flags().StringVar(&graphFormat, "format", "dot", "output format (dot or mermaid)")
+}
+
+func graph(templateFilePath string, stubFilePaths []string) {
+	if graphFormat != "dot" && graphFormat != "mermaid" {
+		log.Fatalln(fmt.Sprintf("invalid graph format %q (use dot or mermaid)", graphFormat))
+	}
+
+	template := readGraphDocument(templateFilePath, "template")
+	stubs := []yaml.Node{}
+	for _, stubFilePath := range stubFilePaths {
+		stubs = append(stubs, readGraphDocument(stubFilePath, "stub"))
+	}
+
+	g := flow.DependencyGraph(template, stubs...)
+	if graphFormat == "mermaid" {
+		g.WriteMermaid(os.Stdout)
+	} else {
+		g.WriteDot(os.Stdout)
+	}
+}
+
+func readGraphDocument(file string, desc string) yaml.Node {
+	var data []byte
+	var err error
+
+	if file == "-" {
+		data, err = ioutil.ReadAll(os.Stdin)
+	} else {
+		data, err = ReadFile(file)
+	}
+	if err != nil {
+		log.Fatalln(fmt.Sprintf("error reading %s [%s]:", desc, path.Clean(file)), err)
+	}
+	doc, err := yaml.Parse(file, data)
+	if err != nil {
+		log.Fatalln(fmt.Sprintf("error parsing %s [%s]:", desc, path.Clean(file)), err)
+	}
+	return doc
+}
@@ -4,16 +4,19 @@ import (
 	"errors"
 	"fmt"
 	"io/ioutil"
-	"log"
 	"os"
 	"path"
+	"regexp"
+	"sort"
 	"strconv"
 	"strings"
+	"time"
 
 	"github.com/spf13/cobra"
 
 	"github.com/mandelsoft/spiff/debug"
 	"github.com/mandelsoft/spiff/dynaml"
+	"github.com/mandelsoft/spiff/dynaml/passwd"
 	"github.com/mandelsoft/spiff/features"
 	"github.com/mandelsoft/spiff/flow"
 	"github.com/mandelsoft/spiff/legacy/candiedyaml"
@@ -21,17 +24,46 @@ import (
 )
 
 var asJSON bool
+var jsonIndent int
 var outputPath string
 var selection []string
 var tagdefs []string
-var expr string
+var exprs []string
 var split bool
 var interpolation bool
+var exprDelimiters string
 var featureFlags []string
 var processingOptions flow.Options
 var state string
 var bindings string
 var values []string
+var randomSeed int64
+var seedFile string
+var seeded bool
+var includeDirs []string
+var checkOnly bool
+var trace bool
+var traceFile string
+var tracePaths []string
+var explainPath string
+var profile bool
+var profileFile string
+var streamMode bool
+var stubOrder string
+var preserveComments bool
+var flatOutput bool
+var flatSeparator string
+var flatEncode bool
+var envOutput bool
+var envSeparator string
+var useAnchors bool
+var multilineStyle string
+var quoteStyle string
+var stateFormat string
+var encryptionMethod string
+var listUnresolved bool
+var expandPaths bool
+var envPrefix string
 
 // mergeCmd represents the merge command
 var mergeCmd = &cobra.Command{
@@ -48,7 +80,23 @@ var mergeCmd = &cobra.Command{
 	Run: func(cmd *cobra.Command, args []string) {
 		vals, err := createValuesFromArgs(values)
 		if err != nil {
-			log.Fatalf("%s\n", err)
+			fail(STAGE_ARGUMENTS, "", err, err)
+		}
+		checkErrorFormat()
+		seeded = cmd.Flags().Changed("seed")
+		if seedFile != "" {
+			if seeded {
+				fail(STAGE_ARGUMENTS, "", nil, "--seed and --seed-from-file cannot be combined")
+			}
+			data, err := ReadFile(seedFile)
+			if err != nil {
+				fail(STAGE_READ, seedFile, err, fmt.Sprintf("error reading seed file [%s]:", path.Clean(seedFile)), err)
+			}
+			randomSeed, err = flow.ParseRandomSeed(data)
+			if err != nil {
+				fail(STAGE_READ, seedFile, err, fmt.Sprintf("invalid seed file [%s]:", path.Clean(seedFile)), err)
+			}
+			seeded = true
 		}
 		merge(false, args[0], processingOptions, asJSON, split, outputPath, selection, state, bindings, vals, nil, args[1:])
 	},
@@ -61,20 +109,135 @@ func init() {
 	rootCmd.AddCommand(mergeCmd)
 
 	mergeCmd.Flags().BoolVar(&interpolation, "interpolation", interpolation, "enable interpolation alpha feature")
+	mergeCmd.Flags().StringVar(&exprDelimiters, "expr-delimiters", "", "delimiters of expressions embedded in strings (for example \"<% %>\"), implies --interpolation")
 	mergeCmd.Flags().BoolVar(&asJSON, "json", false, "print output in json format")
+	mergeCmd.Flags().IntVar(&jsonIndent, "json-indent", 0, "indent json output by the given number of spaces (0 for compact output)")
 	mergeCmd.Flags().BoolVar(&debug.DebugFlag, "debug", false, "Print state info")
 	mergeCmd.Flags().BoolVar(&processingOptions.Partial, "partial", false, "Allow partial evaluation only")
+	mergeCmd.Flags().BoolVar(&listUnresolved, "list-unresolved", false, "print the paths of all unresolved nodes to stderr (as yaml or json list)")
 	mergeCmd.Flags().StringVar(&outputPath, "path", "", "output is taken from given path")
 	mergeCmd.Flags().BoolVar(&split, "split", false, "if the output is a list it will be split into separate documents")
 	mergeCmd.Flags().BoolVar(&processingOptions.PreserveEscapes, "preserve-escapes", false, "preserve escaping for escaped expressions and merges")
 	mergeCmd.Flags().BoolVar(&processingOptions.PreserveTemporary, "preserve-temporary", false, "preserve temporary fields")
+	mergeCmd.Flags().IntVar(&processingOptions.MaxDepth, "max-depth", flow.DefaultMaxDepth, "maximum nesting depth of lambda calls and template instantiations")
 	mergeCmd.Flags().StringVar(&state, "state", "", "select state file to maintain")
+	mergeCmd.Flags().StringVar(&stateFormat, "state-format", "", "format of the state file (yaml or json), default is derived from the file name")
 	mergeCmd.Flags().StringVar(&bindings, "bindings", "", "yaml file with additional bindings to use")
 	mergeCmd.Flags().StringArrayVarP(&values, "define", "D", nil, "key/value bindings")
+	mergeCmd.Flags().StringVar(&envPrefix, "env-prefix", "", "use environment variables with the given prefix as default for bindings")
 	mergeCmd.Flags().StringArrayVar(&selection, "select", []string{}, "filter dedicated output fields")
-	mergeCmd.Flags().StringArrayVar(&tagdefs, "tag", []string{}, "tag files (tag:path)")
+	mergeCmd.Flags().StringArrayVar(&tagdefs, "tag", []string{}, "tag files (tag:path or tag:url)")
 	mergeCmd.Flags().StringArrayVar(&featureFlags, "features", []string{}, "set feature flags")
-	mergeCmd.Flags().StringVar(&expr, "evaluate", "", "evaluation expression")
+	mergeCmd.Flags().StringArrayVar(&exprs, "evaluate", nil, "evaluation expression (optionally named by name=expression, may be repeated)")
+	mergeCmd.Flags().StringVar(&encryptionMethod, "encryption-method", "", "default encryption method for the encrypt function (3DES, AES-GCM or CHACHA20-POLY1305)")
+	mergeCmd.Flags().Int64Var(&randomSeed, "seed", 0, "seed for reproducible random values")
+	mergeCmd.Flags().StringVar(&seedFile, "seed-from-file", "", "read the seed for reproducible random values from the given file")
+	mergeCmd.Flags().BoolVar(&expandPaths, "expand-paths", false, "expand environment variables in template, stub, bindings, state and tag file paths")
+	mergeCmd.Flags().StringVar(&errorFormat, "error-format", "text", "error output format (text or json)")
+	mergeCmd.Flags().BoolVar(&checkOnly, "check", false, "only check the syntax of all documents without processing")
+	mergeCmd.Flags().StringArrayVar(&includeDirs, "include-dir", []string{}, "search path for include function")
+	mergeCmd.Flags().BoolVar(&trace, "trace", false, "trace the evaluation of dynaml expressions")
+	mergeCmd.Flags().StringVar(&traceFile, "trace-file", "", "write the evaluation trace to the given file instead of stderr")
+	mergeCmd.Flags().StringArrayVar(&tracePaths, "trace-path", []string{}, "restrict the evaluation trace to the given path prefixes")
+	mergeCmd.Flags().StringVar(&explainPath, "explain", "", "explain how the field with the given path was computed")
+	mergeCmd.Flags().StringVar(&stubOrder, "stub-order", "", "order of the stub files (name, mtime or comma separated list of files), default is the command line order")
+	mergeCmd.Flags().BoolVar(&streamMode, "stream", false, "use the first document of the template input as template and the other documents as stubs")
+	mergeCmd.Flags().BoolVar(&processingOptions.CarryStream, "carry-stream-state", false, "keep stream tags defined by stubs for subsequent stubs and the template documents")
+	mergeCmd.Flags().BoolVar(&flatOutput, "flat", false, "print output as flat list of path=value lines")
+	mergeCmd.Flags().StringVar(&flatSeparator, "flat-separator", ".", "separator for the path elements of the flat output")
+	mergeCmd.Flags().BoolVar(&flatEncode, "flat-json", false, "encode non-scalar values of the flat output in json instead of failing")
+	mergeCmd.Flags().BoolVar(&envOutput, "env", false, "print output as environment file with KEY=value lines")
+	mergeCmd.Flags().StringVar(&envSeparator, "env-separator", "", "flatten nested maps for the env output using the given key separator")
+	mergeCmd.Flags().BoolVar(&useAnchors, "use-anchors", false, "emit repeated identical maps and lists as yaml anchors and aliases")
+	mergeCmd.Flags().StringVar(&multilineStyle, "multiline-style", "literal", "style of multi-line strings not taken from the input (literal or folded)")
+	mergeCmd.Flags().StringVar(&quoteStyle, "quote-style", "auto", "quoting of string values (auto, none, double or single)")
+	mergeCmd.Flags().BoolVar(&preserveComments, "preserve-comments", false, "preserve comments of map keys from template and stubs")
+	mergeCmd.Flags().BoolVar(&progress, "progress", false, "print the processing progress to stderr (only for terminals)")
+	mergeCmd.Flags().BoolVar(&profile, "profile", false, "print evaluation times per function and top-level path")
+	mergeCmd.Flags().StringVar(&profileFile, "profile-file", "", "write the evaluation profile to the given file instead of stderr")
+}
+
+type evaluation struct {
+	name   string
+	named  bool
+	source string
+	expr   dynaml.Expression
+}
+
+var evaluationName = regexp.MustCompile(`^([a-zA-Z_][a-zA-Z0-9_-]*)=([^=].*)$`)
+
+// parseEvaluations parses the expressions given by the --evaluate option.
+// Expressions may be named by a name=expression prefix, unnamed
+// expressions are named by their source.
+func parseEvaluations(exprs []string) ([]evaluation, error) {
+	var result []evaluation
+	names := map[string]bool{}
+	for _, src := range exprs {
+		e := evaluation{name: src, source: src}
+		if m := evaluationName.FindStringSubmatch(src); m != nil {
+			e.name, e.named, e.source = m[1], true, m[2]
+		}
+		if names[e.name] {
+			return nil, fmt.Errorf("duplicate evaluation name %q", e.name)
+		}
+		names[e.name] = true
+		expr, err := dynaml.Parse(e.source, []string{}, []string{})
+		if err != nil {
+			return nil, fmt.Errorf("invalid expression %q: %s", e.source, err)
+		}
+		e.expr = expr
+		result = append(result, e)
+	}
+	return result, nil
+}
+
+// printUnresolved prints the sorted paths of all nodes of a document
+// still containing unresolved expressions or errors to stderr.
+func printUnresolved(node yaml.Node, json bool) {
+	paths := []string{}
+	found := map[string]bool{}
+	for _, n := range dynaml.FindUnresolvedNodes(node) {
+		p := strings.Join(n.Context, ".")
+		if !found[p] {
+			found[p] = true
+			paths = append(paths, p)
+		}
+	}
+	sort.Strings(paths)
+
+	list := make([]yaml.Node, len(paths))
+	for i, p := range paths {
+		list[i] = yaml.NewNode(p, "")
+	}
+	var data []byte
+	var err error
+	if json {
+		data, err = yaml.ToJSON(yaml.NewNode(list, ""))
+		data = append(data, '\n')
+	} else {
+		data, err = candiedyaml.Marshal(yaml.NewNode(list, ""))
+	}
+	if err != nil {
+		fail(STAGE_OUTPUT, "", err, "cannot print unresolved nodes:", err)
+	}
+	os.Stderr.Write(data)
+}
+
+// createTracer creates the tracer requested by the trace options. If the
+// trace is written to a file, this file is returned, also, and must be
+// closed by the caller.
+func createTracer() (dynaml.Tracer, *os.File) {
+	if !trace && traceFile == "" && len(tracePaths) == 0 {
+		return nil, nil
+	}
+	if traceFile == "" {
+		return dynaml.NewTracer(os.Stderr, tracePaths...), nil
+	}
+	f, err := os.Create(traceFile)
+	if err != nil {
+		fail(STAGE_ARGUMENTS, traceFile, err, fmt.Sprintf("error creating trace file [%s]:", path.Clean(traceFile)), err)
+	}
+	return dynaml.NewTracer(f, tracePaths...), f
 }
 
 func createValuesFromArgs(values []string) (map[string]string, error) {
@@ -95,6 +258,83 @@ func createValuesFromArgs(values []string) (map[string]string, error) {
 	return result, nil
 }
 
+// orderStubs determines the order the given stub files are layered in.
+// Later stubs take precedence over earlier ones. The order may be
+// "name", "mtime" or a comma separated list of files. Files not
+// mentioned in such a list are appended in command line order.
+func orderStubs(paths []string, order string) ([]string, error) {
+	result := append(paths[:0:0], paths...)
+	switch order {
+	case "":
+	case "name":
+		sort.SliceStable(result, func(i, j int) bool { return result[i] < result[j] })
+	case "mtime":
+		times := map[string]time.Time{}
+		for _, p := range result {
+			if p == "-" {
+				continue
+			}
+			info, err := os.Stat(p)
+			if err != nil {
+				return nil, err
+			}
+			times[p] = info.ModTime()
+		}
+		sort.SliceStable(result, func(i, j int) bool { return times[result[i]].Before(times[result[j]]) })
+	default:
+		ordered := []string{}
+		used := map[int]bool{}
+		for _, name := range strings.Split(order, ",") {
+			name = strings.TrimSpace(name)
+			found := false
+			for i, p := range result {
+				if !used[i] && (p == name || path.Base(p) == name) {
+					ordered = append(ordered, p)
+					used[i] = true
+					found = true
+					break
+				}
+			}
+			if !found {
+				return nil, fmt.Errorf("stub %q not found", name)
+			}
+		}
+		for i, p := range result {
+			if !used[i] {
+				ordered = append(ordered, p)
+			}
+		}
+		result = ordered
+	}
+	return result, nil
+}
+
+// documentComments selects the comments for a processed document from
+// the comments of the template document and the stubs. Every node gets
+// the comments of the document it originates from. For nodes without
+// such comments the template comments take precedence, followed by the
+// stubs in reverse order.
+func documentComments(node yaml.Node, template yaml.CommentSource, stubs []yaml.CommentSource) yaml.Comments {
+	sources := []yaml.CommentSource{template}
+	for i := len(stubs) - 1; i >= 0; i-- {
+		sources = append(sources, stubs[i])
+	}
+	return yaml.SelectComments(node, sources)
+}
+
+// flatten renders a document as list of path=value lines.
+func flatten(node yaml.Node) ([]byte, error) {
+	entries, err := yaml.Flatten(node, flatSeparator, flatEncode)
+	if err != nil {
+		return nil, err
+	}
+	buf := &strings.Builder{}
+	for _, e := range entries {
+		fmt.Fprintln(buf, e)
+	}
+	return []byte(buf.String()), nil
+}
+
 func fileExists(filename string) bool {
 	info, err := os.Stat(filename)
 	if os.IsNotExist(err) {
@@ -108,11 +348,11 @@ func readYAML(filename string, desc string, required bool) yaml.Node {
 		if fileExists(filename) {
 			data, err := ioutil.ReadFile(filename)
 			if required && err != nil {
-				log.Fatalln(fmt.Sprintf("error reading %s [%s]:", desc, path.Clean(filename)), err)
+				fail(STAGE_READ, filename, err, fmt.Sprintf("error reading %s [%s]:", desc, path.Clean(filename)), err)
 			}
 			doc, err := yaml.Parse(filename, data)
 			if err != nil {
-				log.Fatalln(fmt.Sprintf("error parsing %s [%s]:", desc, path.Clean(filename)), err)
+				fail(STAGE_PARSE, filename, err, fmt.Sprintf("error parsing %s [%s]:", desc, path.Clean(filename)), err)
 			}
 			return doc
 		}
@@ -120,11 +360,79 @@ func readYAML(filename string, desc string, required bool) yaml.Node {
 	return nil
 }
 
+// expandPath expands environment variables in a file path argument,
+// if enabled by option --expand-paths. Undefined variables are
+// replaced by the empty string.
+func expandPath(p string) string {
+	if !expandPaths || p == "-" {
+		return p
+	}
+	return os.ExpandEnv(p)
+}
+
 func merge(stdin bool, templateFilePath string, opts flow.Options, json, split bool,
 	subpath string, selection []string, stateFilePath, bindingFilePath string, values map[string]string, stubs []yaml.Node, stubFilePaths []string) {
 	var templateFile []byte
 	var err error
 
+	if flatOutput && json {
+		fail(STAGE_ARGUMENTS, "", nil, "flat output cannot be combined with json output")
+	}
+	if envOutput && (json || flatOutput) {
+		fail(STAGE_ARGUMENTS, "", nil, "env output cannot be combined with json or flat output")
+	}
+	if progress && isTerminal(os.Stderr) {
+		progressLine = &progressPrinter{w: os.Stderr}
+		opts.Progress = progressLine.Report
+	}
+	templateFilePath = expandPath(templateFilePath)
+	stateFilePath = expandPath(stateFilePath)
+	bindingFilePath = expandPath(bindingFilePath)
+	if expandPaths {
+		expanded := make([]string, len(stubFilePaths))
+		for i, p := range stubFilePaths {
+			expanded[i] = expandPath(p)
+		}
+		stubFilePaths = expanded
+	}
+	marshalOptions := yaml.MarshalOptions{Anchors: useAnchors}
+	switch multilineStyle {
+	case "literal":
+		marshalOptions.Multiline = candiedyaml.LiteralStyle
+	case "folded":
+		marshalOptions.Multiline = candiedyaml.FoldedStyle
+	default:
+		fail(STAGE_ARGUMENTS, "", nil, fmt.Sprintf("invalid multi-line style %q (use literal or folded)", multilineStyle))
+	}
+	switch quoteStyle {
+	case "auto", "none":
+		marshalOptions.Quote = candiedyaml.AutoQuote
+	case "double":
+		marshalOptions.Quote = candiedyaml.DoubleQuote
+	case "single":
+		marshalOptions.Quote = candiedyaml.SingleQuote
+	default:
+		fail(STAGE_ARGUMENTS, "", nil, fmt.Sprintf("invalid quote style %q (use auto, none, double or single)", quoteStyle))
+	}
+	switch stateFormat {
+	case "", "yaml", "json":
+	default:
+		fail(STAGE_ARGUMENTS, "", nil, fmt.Sprintf("invalid state format %q (use yaml or json)", stateFormat))
+	}
+	if jsonIndent < 0 {
+		fail(STAGE_ARGUMENTS, "", nil, fmt.Sprintf("invalid json indent %d", jsonIndent))
+	}
+	if encryptionMethod != "" && passwd.GetEncoding(encryptionMethod) == nil {
+		fail(STAGE_ARGUMENTS, "", nil, fmt.Sprintf("invalid encryption method %q", encryptionMethod))
+	}
+	delimiters := yaml.DefaultDelimiters
+	if exprDelimiters != "" {
+		delimiters, err = yaml.ParseDelimiters(exprDelimiters)
+		if err != nil {
+			fail(STAGE_ARGUMENTS, "", err, err.Error())
+		}
+	}
+
 	if templateFilePath == "-" {
 		templateFile, err = ioutil.ReadAll(os.Stdin)
 		stdin = true
@@ -133,18 +441,42 @@ func merge(stdin bool, templateFilePath string, opts flow.Options, json, split b
 	}
 
 	if err != nil {
-		log.Fatalln(fmt.Sprintf("error reading template [%s]:", path.Clean(templateFilePath)), err)
+		fail(STAGE_READ, templateFilePath, err, fmt.Sprintf("error reading template [%s]:", path.Clean(templateFilePath)), err)
 	}
 
-	templateYAMLs, err := yaml.ParseMulti(templateFilePath, templateFile)
+	templateYAMLs, err := yaml.ParseMultiWithPositions(templateFilePath, templateFile)
 	if err != nil {
-		log.Fatalln(fmt.Sprintf("error parsing template [%s]:", path.Clean(templateFilePath)), err)
+		fail(STAGE_PARSE, templateFilePath, err, fmt.Sprintf("error parsing template [%s]:", path.Clean(templateFilePath)), err)
+	}
+
+	var templateComments []yaml.Comments
+	var stubComments []yaml.CommentSource
+	if preserveComments {
+		templateComments, err = yaml.ExtractComments(templateFile)
+		if err != nil {
+			fail(STAGE_READ, templateFilePath, err, fmt.Sprintf("error reading comments of template [%s]:", path.Clean(templateFilePath)), err)
+		}
+	}
+
+	var streamStubs []yaml.Node
+	if streamMode {
+		if len(templateYAMLs) == 0 {
+			fail(STAGE_ARGUMENTS, templateFilePath, nil, fmt.Sprintf("no template document found in stream [%s]", path.Clean(templateFilePath)))
+		}
+		streamStubs = templateYAMLs[1:]
+		templateYAMLs = templateYAMLs[:1]
+		if len(templateComments) > 1 {
+			for _, c := range templateComments[1:] {
+				stubComments = append(stubComments, yaml.CommentSource{Name: templateFilePath, Comments: c})
+			}
+			templateComments = templateComments[:1]
+		}
 	}
 
 	var stateYAML yaml.Node
 	if stateFilePath != "" {
 		if len(templateYAMLs) > 1 {
-			log.Fatalln(fmt.Sprintf("state handling not supported gor multi documents [%s]:", path.Clean(templateFilePath)), err)
+			fail(STAGE_ARGUMENTS, templateFilePath, nil, fmt.Sprintf("state handling not supported gor multi documents [%s]:", path.Clean(templateFilePath)), err)
 		}
 		stateYAML = readYAML(stateFilePath, "state file", false)
 	}
@@ -156,7 +488,7 @@ func merge(stdin bool, templateFilePath string, opts flow.Options, json, split b
 		}
 		m, ok := bindingYAML.Value().(map[string]yaml.Node)
 		if !ok {
-			log.Fatalf(fmt.Sprintf("binding %q must be a map\n", bindingFilePath))
+			fail(STAGE_ARGUMENTS, bindingFilePath, nil, fmt.Sprintf("binding %q must be a map", bindingFilePath))
 		}
 		for k, v := range values {
 			i, err := strconv.ParseInt(v, 10, 64)
@@ -166,7 +498,7 @@ func merge(stdin bool, templateFilePath string, opts flow.Options, json, split b
 				err = addValue(m, k, yaml.NewNode(v, "<values>"))
 			}
 			if err != nil {
-				log.Fatalln(fmt.Sprintf("error in value definitions (-D): %s", err))
+				fail(STAGE_ARGUMENTS, "", nil, fmt.Sprintf("error in value definitions (-D): %s", err))
 			}
 		}
 
@@ -177,22 +509,22 @@ func merge(stdin bool, templateFilePath string, opts flow.Options, json, split b
 	for _, tagDef := range tagdefs {
 		i := strings.Index(tagDef, ":")
 		if i <= 0 {
-			log.Fatalln(fmt.Sprintf("tag file must be preceeded by a tag (<tag>:<path>)"))
+			fail(STAGE_ARGUMENTS, "", nil, fmt.Sprintf("tag file must be preceeded by a tag (<tag>:<path>)"))
 		}
 		tagName := tagDef[:i]
 		err := dynaml.CheckTagName(tagName)
 		if err != nil {
-			log.Fatalln(fmt.Sprintf("invalid tag name [%s]:", path.Clean(tagName)), err)
+			fail(STAGE_ARGUMENTS, "", err, fmt.Sprintf("invalid tag name [%s]:", path.Clean(tagName)), err)
 		}
-		tagFilePath := tagDef[i+1:]
+		tagFilePath := expandPath(tagDef[i+1:])
 		tagFile, err := ReadFile(tagFilePath)
 		if err != nil {
-			log.Fatalln(fmt.Sprintf("error reading tag file [%s]:", path.Clean(tagFilePath)), err)
+			fail(STAGE_READ, tagFilePath, err, fmt.Sprintf("error reading tag file [%s]:", DisplayPath(tagFilePath)), err)
 		}
 
-		tagYAML, err := yaml.Parse(tagFilePath, tagFile)
+		tagYAML, err := yaml.ParseWithPositions(tagFilePath, tagFile)
 		if err != nil {
-			log.Fatalln(fmt.Sprintf("error parsing tag file [%s]:", path.Clean(tagFilePath)), err)
+			fail(STAGE_PARSE, tagFilePath, err, fmt.Sprintf("error parsing tag file [%s]:", DisplayPath(tagFilePath)), err)
 		}
 
 		tags = append(tags, dynaml.NewTag(tagName, tagYAML, nil, dynaml.TAG_SCOPE_GLOBAL))
@@ -201,14 +533,19 @@ func merge(stdin bool, templateFilePath string, opts flow.Options, json, split b
 	if stubs == nil {
 		stubs = []yaml.Node{}
 	}
-	stubs = append(stubs[:0:0], stubs...)
+	stubs = append(append(stubs[:0:0], streamStubs...), stubs...)
+
+	stubFilePaths, err = orderStubs(stubFilePaths, stubOrder)
+	if err != nil {
+		fail(STAGE_ARGUMENTS, "", err, "invalid stub order:", err)
+	}
 
 	for _, stubFilePath := range stubFilePaths {
 		var stubFile []byte
 		var err error
 		if stubFilePath == "-" {
 			if stdin {
-				log.Fatalln(fmt.Sprintf("stdin cannot be used twice"))
+				fail(STAGE_ARGUMENTS, "", nil, fmt.Sprintf("stdin cannot be used twice"))
 			}
 			stubFile, err = ioutil.ReadAll(os.Stdin)
 			stdin = true
@@ -216,12 +553,19 @@ func merge(stdin bool, templateFilePath string, opts flow.Options, json, split b
 			stubFile, err = ReadFile(stubFilePath)
 		}
 		if err != nil {
-			log.Fatalln(fmt.Sprintf("error reading stub [%s]:", path.Clean(stubFilePath)), err)
+			fail(STAGE_READ, stubFilePath, err, fmt.Sprintf("error reading stub [%s]:", path.Clean(stubFilePath)), err)
 		}
 
-		stubYAML, err := yaml.Parse(stubFilePath, stubFile)
+		stubYAML, err := yaml.ParseWithPositions(stubFilePath, stubFile)
 		if err != nil {
-			log.Fatalln(fmt.Sprintf("error parsing stub [%s]:", path.Clean(stubFilePath)), err)
+			fail(STAGE_PARSE, stubFilePath, err, fmt.Sprintf("error parsing stub [%s]:", path.Clean(stubFilePath)), err)
+		}
+		if preserveComments {
+			comments, err := yaml.ExtractComments(stubFile)
+			if err != nil {
+				fail(STAGE_READ, stubFilePath, err, fmt.Sprintf("error reading comments of stub [%s]:", path.Clean(stubFilePath)), err)
+			}
+			stubComments = append(stubComments, yaml.CommentSource{Name: stubFilePath, Comments: comments[0]})
 		}
 
 		stubs = append(stubs, stubYAML)
@@ -241,49 +585,113 @@ func merge(stdin bool, templateFilePath string, opts flow.Options, json, split b
 	for _, list := range featureFlags {
 		for _, f := range strings.Split(list, ",") {
 			if err := features.Set(strings.TrimSpace(f), true); err != nil {
-				log.Fatalln(err.Error())
+				fail(STAGE_ARGUMENTS, "", err, err.Error())
 			}
 		}
 	}
-	if interpolation {
+	if interpolation || exprDelimiters != "" {
 		features.SetInterpolation(true)
 	}
-	if bindingYAML != nil || features.Size() > 0 || len(tags) > 0 || len(templateYAMLs) > 1 {
-		defstate := flow.NewDefaultState().SetTags(tags...).SetFeatures(features)
-		binding = flow.NewEnvironment(
-			nil, "context", defstate)
-		if bindingYAML != nil {
-			values, ok := bindingYAML.Value().(map[string]yaml.Node)
-			if !ok {
-				log.Fatalln("bindings must be given as map")
+
+	if checkOnly {
+		var errs []error
+		for _, doc := range templateYAMLs {
+			errs = append(errs, flow.CheckSyntax(doc, features.InterpolationEnabled(), delimiters)...)
+		}
+		for _, doc := range stubs {
+			errs = append(errs, flow.CheckSyntax(doc, features.InterpolationEnabled(), delimiters)...)
+		}
+		if len(errs) > 0 && errorFormat == "json" {
+			var reports []errorReport
+			for _, err := range errs {
+				reports = append(reports, newErrorReports(STAGE_CHECK, "", err)...)
 			}
-			binding = binding.WithLocalScope(values)
+			reportErrors(reports...)
+		}
+		for _, err := range errs {
+			fmt.Fprintf(os.Stderr, "%s\n", err)
 		}
-		features = binding.GetFeatures()
+		if len(errs) > 0 {
+			os.Exit(1)
+		}
+		return
 	}
 
-	prepared, err := flow.PrepareStubs(binding, processingOptions.Partial, stubs...)
-	if !processingOptions.Partial && err != nil {
-		log.Fatalln("error generating manifest:", err, legend)
+	evaluations, err := parseEvaluations(exprs)
+	if err != nil {
+		fail(STAGE_PARSE, "<expr>", err, err.Error())
 	}
 
-	result := [][]byte{}
+	tracer, traceOut := createTracer()
+	if traceOut != nil {
+		defer traceOut.Close()
+	}
+	var profiler *dynaml.Profiler
+	if profile || profileFile != "" {
+		profiler = dynaml.NewProfiler()
+	}
+	defstate := flow.NewDefaultState().SetTags(tags...).SetFeatures(features).SetMaxDepth(opts.MaxDepth).SetEncryptionMethod(encryptionMethod)
+	defstate.SetInterpolationDelimiters(delimiters)
+	defstate.SetNetworkAccess(allowNetwork).SetHTTPLimits(httpTimeout, 0)
+	if seeded {
+		defstate.SetRandomSeed(randomSeed)
+	}
+	if len(includeDirs) > 0 {
+		defstate.SetIncludeDirs(includeDirs...)
+	}
+	if tracer != nil {
+		defstate.SetTracer(tracer)
+	}
+	if profiler != nil {
+		defstate.SetProfiler(profiler)
+	}
+	binding = flow.NewEnvironment(
+		nil, "context", defstate)
+	if envPrefix != "" {
+		bindingYAML = addEnvironmentBindings(defstate, bindingYAML)
+	}
+	if bindingYAML != nil {
+		values, ok := bindingYAML.Value().(map[string]yaml.Node)
+		if !ok {
+			fail(STAGE_ARGUMENTS, bindingFilePath, nil, "bindings must be given as map")
+		}
+		binding = binding.WithLocalScope(values)
+	}
+	features = binding.GetFeatures()
+
+	prepared, err := flow.PrepareStubsWithOptions(binding, opts, stubs...)
+	if !opts.Partial && err != nil {
+		fail(STAGE_EVALUATE, "", err, "error generating manifest:", err, legend)
+	}
+
+	result := []outputDocument{}
 	count := 0
 	for no, templateYAML := range templateYAMLs {
 		doc := ""
 		if len(templateYAMLs) > 1 {
 			doc = fmt.Sprintf(" (document %d)", no+1)
 		}
-		var bytes []byte
+		output := outputDocument{doc: doc}
 		if templateYAML.Value() != nil {
 			count++
 			flowed, err := flow.Apply(binding, templateYAML, prepared, opts)
 			if !opts.Partial && err != nil {
-				log.Fatalln(fmt.Sprintf("error generating manifest%s:", doc), err, legend)
+				fail(STAGE_EVALUATE, templateFilePath, err, fmt.Sprintf("error generating manifest%s:", doc), err, legend)
+			}
+			if listUnresolved {
+				printUnresolved(flowed, json)
 			}
 			if err != nil {
 				flowed = dynaml.ResetUnresolvedNodes(flowed)
 			}
+			if explainPath != "" {
+				e, err := flow.Explain(flowed, templateYAML, stubs, dynaml.PathComponents(explainPath, false))
+				if err != nil {
+					fmt.Fprintf(os.Stderr, "cannot explain %q%s: %s\n", explainPath, doc, err)
+				} else {
+					fmt.Fprint(os.Stderr, e)
+				}
+			}
 			if !opts.PreserveTemporary && flowed.Temporary() {
 				continue
 			}
@@ -291,19 +699,21 @@ func merge(stdin bool, templateFilePath string, opts flow.Options, json, split b
 				comps := dynaml.PathComponents(subpath, false)
 				node, ok := yaml.FindR(true, flowed, features, comps...)
 				if !ok {
-					log.Fatalln(fmt.Sprintf("path %q not found%s", subpath, doc))
+					fail(STAGE_OUTPUT, templateFilePath, nil, fmt.Sprintf("path %q not found%s", subpath, doc))
 				}
 				flowed = node
 			}
 			if stateFilePath != "" {
 				state := flow.Cleanup(flowed, flow.DiscardNonState)
 				json := json
-				if strings.HasSuffix(stateFilePath, ".yaml") || strings.HasSuffix(stateFilePath, ".yml") {
+				var bytes []byte
+				switch {
+				case stateFormat != "":
+					json = stateFormat == "json"
+				case strings.HasSuffix(stateFilePath, ".yaml") || strings.HasSuffix(stateFilePath, ".yml"):
 					json = false
-				} else {
-					if strings.HasSuffix(stateFilePath, ".json") {
-						json = true
-					}
+				case strings.HasSuffix(stateFilePath, ".json"):
+					json = true
 				}
 				if json {
 					bytes, err = yaml.ToJSON(state)
@@ -322,24 +732,27 @@ func merge(stdin bool, templateFilePath string, opts flow.Options, json, split b
 					if old {
 						os.Rename(stateFilePath+".bak", stateFilePath)
 					}
-					log.Fatalln(fmt.Sprintf("cannot write state file %q", stateFilePath))
+					fail(STAGE_OUTPUT, stateFilePath, err, fmt.Sprintf("cannot write state file %q", stateFilePath))
 				}
 			}
 
-			if len(expr) > 0 {
-				e, err := dynaml.Parse(expr, []string{}, []string{})
-				if err != nil {
-					log.Fatalln(fmt.Sprintf("invalid expression %q: %s", expr, err))
-				}
+			if len(evaluations) > 0 {
 				if m, ok := flowed.Value().(map[string]yaml.Node); ok {
 					binding := flow.NewNestedEnvironment(nil, "context", binding).WithLocalScope(m)
-					v, err := flow.Cascade(binding, yaml.NewNode(e, "<expr>"), flow.Options{})
-					if err != nil {
-						log.Fatalln(fmt.Sprintf("expression %q failed: %s", expr, err))
+					results := map[string]yaml.Node{}
+					for _, e := range evaluations {
+						v, err := flow.Cascade(binding, yaml.NewNode(e.expr, "<expr>"), flow.Options{})
+						if err != nil {
+							fail(STAGE_EVALUATE, "<expr>", err, fmt.Sprintf("expression %q failed: %s", e.source, err))
+						}
+						results[e.name] = v
+						flowed = v
+					}
+					if len(evaluations) > 1 || evaluations[0].named {
+						flowed = yaml.NewNode(results, "<expr>")
 					}
-					flowed = v
 				} else {
-					log.Fatalln("no map document")
+					fail(STAGE_EVALUATE, templateFilePath, nil, "no map document")
 				}
 			}
 
@@ -349,7 +762,7 @@ func merge(stdin bool, templateFilePath string, opts flow.Options, json, split b
 					comps := dynaml.PathComponents(p, false)
 					node, ok := yaml.FindR(true, flowed, features, comps...)
 					if !ok {
-						log.Fatalln(fmt.Sprintf("path %q not found%s", subpath, doc))
+						fail(STAGE_OUTPUT, templateFilePath, nil, fmt.Sprintf("path %q not found%s", subpath, doc))
 					}
 					new[comps[len(comps)-1]] = node
 
@@ -360,42 +773,121 @@ func merge(stdin bool, templateFilePath string, opts flow.Options, json, split b
 			if split {
 				if list, ok := flowed.Value().([]yaml.Node); ok {
 					for _, d := range list {
-						if json {
-							bytes, err = yaml.ToJSON(d)
-						} else {
-							bytes, err = candiedyaml.Marshal(d)
-						}
-						if err != nil {
-							log.Fatalln(fmt.Sprintf("error marshalling manifest%s:", doc), err)
-						}
-						result = append(result, bytes)
+						result = append(result, outputDocument{node: d, doc: doc})
 					}
 					continue
 				}
 			}
-			if json {
-				bytes, err = yaml.ToJSON(flowed)
-			} else {
-				bytes, err = candiedyaml.Marshal(flowed)
-			}
-			if err != nil {
-				log.Fatalln(fmt.Sprintf("error marshalling manifest%s:", doc), err)
+			output.node = flowed
+			if preserveComments && !json && !flatOutput && !envOutput && no < len(templateComments) && subpath == "" && len(evaluations) == 0 && len(selection) == 0 {
+				template := yaml.CommentSource{Name: templateFilePath, Comments: templateComments[no]}
+				output.comments = documentComments(flowed, template, stubComments)
 			}
 		}
-		result = append(result, bytes)
+		result = append(result, output)
 	}
 
-	for _, bytes := range result {
-		if !json && (len(result) > 1 || len(bytes) == 0) {
-			fmt.Println("---")
+	var data []byte
+	if json {
+		nodes := []yaml.Node{}
+		for _, r := range result {
+			if r.node != nil {
+				nodes = append(nodes, r.node)
+			}
 		}
-		if bytes != nil {
-			fmt.Print(string(bytes))
-			if json {
-				fmt.Println()
+		data, err = yaml.ToJSONStream(nodes, jsonIndent)
+	} else {
+		nodes := make([]yaml.Node, len(result))
+		for i, r := range result {
+			nodes[i] = r.node
+		}
+		data, err = yaml.MarshalMultiWith(nodes, func(i int, n yaml.Node) ([]byte, error) {
+			if n == nil {
+				return nil, nil
 			}
+			data, err := marshalDocument(n, marshalOptions, result[i].comments)
+			if err != nil {
+				fail(STAGE_OUTPUT, templateFilePath, err, fmt.Sprintf("error marshalling manifest%s:", result[i].doc), err)
+			}
+			return data, nil
+		})
+	}
+	if err != nil {
+		fail(STAGE_OUTPUT, templateFilePath, err, "error marshalling manifest:", err)
+	}
+	fmt.Print(string(data))
+	printWarnings(defstate)
+	if profiler != nil {
+		writeProfile(profiler)
+	}
+}
+
+// outputDocument is a processed document to be written to the output.
+type outputDocument struct {
+	node     yaml.Node
+	doc      string        // document info used for error messages
+	comments yaml.Comments // comments to preserve, if any
+}
+
+// marshalDocument marshals a single document according to the selected
+// (non-json) output format.
+func marshalDocument(node yaml.Node, opts yaml.MarshalOptions, comments yaml.Comments) ([]byte, error) {
+	if envOutput {
+		return yaml.ToEnv(node, envSeparator)
+	}
+	if flatOutput {
+		return flatten(node)
+	}
+	data, err := yaml.MarshalWithOptions(node, opts)
+	if err != nil || comments == nil {
+		return data, err
+	}
+	return yaml.InsertComments(data, comments)
+}
+
+// printWarnings reports the non-fatal issues detected by the processing,
+// for example the usage of deprecated functions.
+func printWarnings(state *flow.State) {
+	for _, w := range state.Warnings() {
+		fmt.Fprintf(os.Stderr, "warning: %s\n", w)
+	}
+	state.ResetWarnings()
+}
+
+func writeProfile(profiler *dynaml.Profiler) {
+	if profileFile == "" {
+		profiler.Write(os.Stderr)
+		return
+	}
+	f, err := os.Create(profileFile)
+	if err != nil {
+		fail(STAGE_OUTPUT, profileFile, err, fmt.Sprintf("error creating profile file [%s]:", path.Clean(profileFile)), err)
+	}
+	defer f.Close()
+	profiler.Write(f)
+}
+
+// addEnvironmentBindings adds the binding values taken from the environment
+// variables with the prefix given by option --env-prefix for all names not
+// already defined by the bindings file or the value definitions (-D).
+func addEnvironmentBindings(state *flow.State, bindings yaml.Node) yaml.Node {
+	env, err := state.EnvironmentBindings(envPrefix)
+	if err != nil {
+		fail(STAGE_ARGUMENTS, "", err, "cannot use environment bindings:", err)
+	}
+	if bindings == nil {
+		return yaml.NewNode(env, "<env>")
+	}
+	m, ok := bindings.Value().(map[string]yaml.Node)
+	if !ok {
+		return bindings
+	}
+	for k, v := range env {
+		if _, ok := m[k]; !ok {
+			m[k] = v
 		}
 	}
+	return bindings
 }
 
 func addValue(m map[string]yaml.Node, name string, value yaml.Node) error {
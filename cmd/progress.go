@@ -0,0 +1,45 @@
+package cmd
+
+import (
+	"fmt"
+	"io"
+
+	"github.com/mandelsoft/spiff/flow"
+)
+
+var progress bool
+
+// progressPrinter prints the processing progress as a single line
+// updated for every reported step.
+type progressPrinter struct {
+	w      io.Writer
+	active bool
+}
+
+// progressLine is the active progress printer, if enabled by option --progress.
+var progressLine *progressPrinter
+
+func (p *progressPrinter) Report(pr flow.Progress) {
+	var msg string
+	switch {
+	case pr.Iteration == 0 && pr.Stub == 0:
+		p.Clear()
+		return
+	case pr.Iteration == 0:
+		msg = fmt.Sprintf("prepared %d/%d stubs", pr.Stub, pr.Stubs)
+	case pr.Stub > 0:
+		msg = fmt.Sprintf("stub %d/%d: iteration %d, resolved %d nodes (%d unresolved)", pr.Stub, pr.Stubs, pr.Iteration, pr.Resolved, pr.Unresolved)
+	default:
+		msg = fmt.Sprintf("template: iteration %d, resolved %d nodes (%d unresolved)", pr.Iteration, pr.Resolved, pr.Unresolved)
+	}
+	fmt.Fprintf(p.w, "\r\x1b[K%s", msg)
+	p.active = true
+}
+
+// Clear removes the progress line.
+func (p *progressPrinter) Clear() {
+	if p != nil && p.active {
+		fmt.Fprint(p.w, "\r\x1b[K")
+		p.active = false
+	}
+}
@@ -0,0 +1,297 @@
+package cmd
+
+import (
+	"bufio"
+	"fmt"
+	"io"
+	"os"
+	"path"
+	"sort"
+	"strconv"
+	"strings"
+
+	"github.com/spf13/cobra"
+
+	"github.com/mandelsoft/spiff/dynaml"
+	"github.com/mandelsoft/spiff/spiffing"
+	"github.com/mandelsoft/spiff/yaml"
+)
+
+const replHelp = `Read dynaml expressions line by line and print their values.
+The expressions are evaluated in a context given by the fields of the
+processed stub files and the bindings. Expressions not complete at the
+end of a line (open brackets or strings, or a trailing backslash) are
+continued on the next line.
+
+Lines starting with a colon are commands:
+  :load <file>        process a stub file and add its fields to the context
+  :set <key> <value>  add a binding (a yaml value or dynaml expression)
+  :values             list the names of all bindings
+  :help               print this help
+  :quit               leave the shell`
+
+// replCmd represents the repl command
+var replCmd = &cobra.Command{
+	Use:   "repl [<stub file>...]",
+	Short: "Interactively evaluate dynaml expressions",
+	Long:  replHelp,
+	Run: func(cmd *cobra.Command, args []string) {
+		r := newRepl(os.Stdout, os.Stderr)
+		if err := r.init(bindings, values, args); err != nil {
+			fail(STAGE_ARGUMENTS, "", err, err.Error())
+		}
+		r.run(os.Stdin, isTerminal(os.Stdin))
+	},
+}
+
+func init() {
+	rootCmd.AddCommand(replCmd)
+
+	replCmd.Flags().StringVar(&bindings, "bindings", "", "yaml file with additional bindings to use")
+	replCmd.Flags().StringArrayVarP(&values, "define", "D", nil, "key/value bindings")
+}
+
+// repl is an interactive evaluation shell for dynaml expressions
+// based on a spiff context.
+type repl struct {
+	ctx    spiffing.Spiff
+	names  map[string]bool
+	out    io.Writer
+	errout io.Writer
+}
+
+func newRepl(out, errout io.Writer) *repl {
+	return &repl{
+		ctx:    spiffing.New(),
+		names:  map[string]bool{},
+		out:    out,
+		errout: errout,
+	}
+}
+
+// init sets up the context with the bindings file, the -D values
+// and the given stub files.
+func (r *repl) init(bindingFilePath string, defs []string, stubFilePaths []string) error {
+	if bindingFilePath != "" {
+		node, err := r.read(bindingFilePath)
+		if err != nil {
+			return err
+		}
+		m, ok := node.Value().(map[string]yaml.Node)
+		if !ok {
+			return fmt.Errorf("binding %q must be a map", bindingFilePath)
+		}
+		for k, v := range m {
+			r.set(k, v)
+		}
+	}
+	vals, err := createValuesFromArgs(defs)
+	if err != nil {
+		return err
+	}
+	for k, v := range vals {
+		if i, err := strconv.ParseInt(v, 10, 64); err == nil {
+			r.set(k, yaml.NewNode(i, "<values>"))
+		} else {
+			r.set(k, yaml.NewNode(v, "<values>"))
+		}
+	}
+	for _, p := range stubFilePaths {
+		if err := r.load(p); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// run reads and executes the input line by line until the end
+// of the input or a quit command. The prompt is only shown
+// for interactive input.
+func (r *repl) run(in io.Reader, interactive bool) {
+	scanner := bufio.NewScanner(in)
+	prompt := func(p string) {
+		if interactive {
+			fmt.Fprint(r.out, p)
+		}
+	}
+
+	src := ""
+	prompt("> ")
+	for scanner.Scan() {
+		line := scanner.Text()
+		if src == "" && strings.HasPrefix(strings.TrimSpace(line), ":") {
+			if !r.command(strings.TrimSpace(line)) {
+				return
+			}
+			prompt("> ")
+			continue
+		}
+		if strings.HasSuffix(line, "\\") {
+			src += strings.TrimSuffix(line, "\\") + "\n"
+			prompt("... ")
+			continue
+		}
+		src += line
+		if line != "" && incomplete(src) {
+			src += "\n"
+			prompt("... ")
+			continue
+		}
+		if strings.TrimSpace(src) != "" {
+			r.evaluate(src)
+		}
+		src = ""
+		prompt("> ")
+	}
+	if strings.TrimSpace(src) != "" {
+		r.evaluate(src)
+	}
+	if err := scanner.Err(); err != nil {
+		r.error(err)
+	}
+}
+
+// command executes a shell command. It returns false
+// if the shell should be left.
+func (r *repl) command(line string) bool {
+	fields := strings.Fields(line)
+	switch fields[0] {
+	case ":quit", ":q", ":exit":
+		return false
+	case ":help", ":h":
+		fmt.Fprintln(r.out, replHelp)
+	case ":load", ":l":
+		if len(fields) != 2 {
+			r.error(fmt.Errorf("file name required for :load"))
+			break
+		}
+		if err := r.load(fields[1]); err != nil {
+			r.error(err)
+		}
+	case ":set", ":s":
+		if len(fields) < 3 {
+			r.error(fmt.Errorf("key and value required for :set"))
+			break
+		}
+		value := strings.TrimSpace(strings.TrimPrefix(strings.TrimSpace(line), fields[0]))
+		value = strings.TrimSpace(strings.TrimPrefix(value, fields[1]))
+		node, err := r.ctx.Unmarshal("<set "+fields[1]+">", []byte(value))
+		if err == nil {
+			node, err = r.ctx.Cascade(node, nil)
+		}
+		if err != nil {
+			r.error(err)
+			break
+		}
+		r.set(fields[1], node)
+	case ":values", ":v":
+		names := make([]string, 0, len(r.names))
+		for n := range r.names {
+			names = append(names, n)
+		}
+		sort.Strings(names)
+		for _, n := range names {
+			fmt.Fprintln(r.out, n)
+		}
+	default:
+		r.error(fmt.Errorf("unknown command %q (use :help)", fields[0]))
+	}
+	return true
+}
+
+// evaluate evaluates a dynaml expression, which may optionally be
+// given in brackets, and prints its value.
+func (r *repl) evaluate(src string) {
+	src = strings.TrimSpace(src)
+	if strings.HasPrefix(src, "((") && strings.HasSuffix(src, "))") {
+		src = strings.TrimSpace(src[2 : len(src)-2])
+	}
+	expr, err := dynaml.Parse(src, []string{}, []string{})
+	if err != nil {
+		r.error(err)
+		return
+	}
+	result, err := r.ctx.Cascade(yaml.NewNode(expr, "<repl>"), nil)
+	if err != nil {
+		r.error(err)
+		return
+	}
+	data, err := r.ctx.Marshal(result)
+	if err != nil {
+		r.error(err)
+		return
+	}
+	r.out.Write(data)
+}
+
+// load processes a stub file in the actual context and adds
+// its top level fields to the context.
+func (r *repl) load(filePath string) error {
+	node, err := r.read(filePath)
+	if err != nil {
+		return err
+	}
+	result, err := r.ctx.Cascade(node, nil)
+	if err != nil {
+		return fmt.Errorf("error processing stub [%s]: %s", path.Clean(filePath), err)
+	}
+	m, ok := result.Value().(map[string]yaml.Node)
+	if !ok {
+		return fmt.Errorf("stub %q must be a map", filePath)
+	}
+	for k, v := range m {
+		r.set(k, v)
+	}
+	return nil
+}
+
+func (r *repl) read(filePath string) (yaml.Node, error) {
+	data, err := ReadFile(expandPath(filePath))
+	if err != nil {
+		return nil, fmt.Errorf("error reading file [%s]: %s", path.Clean(filePath), err)
+	}
+	node, err := r.ctx.Unmarshal(filePath, data)
+	if err != nil {
+		return nil, fmt.Errorf("error parsing file [%s]: %s", path.Clean(filePath), err)
+	}
+	return node, nil
+}
+
+func (r *repl) set(name string, value yaml.Node) {
+	r.ctx = r.ctx.SetValue(name, value)
+	r.names[name] = true
+}
+
+func (r *repl) error(err error) {
+	fmt.Fprintf(r.errout, "%s\n", colored(colorEnabled(os.Stderr), colorRed, "error: "+err.Error()))
+}
+
+// incomplete checks whether an expression has unclosed
+// brackets or strings.
+func incomplete(src string) bool {
+	depth := 0
+	quoted := false
+	escaped := false
+	for _, c := range src {
+		if quoted {
+			switch {
+			case escaped:
+				escaped = false
+			case c == '\\':
+				escaped = true
+			case c == '"':
+				quoted = false
+			}
+			continue
+		}
+		switch c {
+		case '"':
+			quoted = true
+		case '(', '[', '{':
+			depth++
+		case ')', ']', '}':
+			depth--
+		}
+	}
+	return quoted || depth > 0
+}
@@ -3,23 +3,34 @@ package cmd
 import (
 	"fmt"
 	"io/ioutil"
-	"net/http"
 	"os"
+	"path"
 	"strings"
+	"time"
 
 	"github.com/spf13/cobra"
 	"github.com/spf13/viper"
 
+	"github.com/mandelsoft/spiff/dynaml"
 	"github.com/mandelsoft/spiff/flow"
 )
 
 var cfgFile string
+var offline bool
+var allowNetwork bool
+var httpTimeout time.Duration
 
 // rootCmd represents the base command when called without any subcommands
 var rootCmd = &cobra.Command{
 	Use:     "spiff",
 	Short:   "YAML in-domain templating processor",
 	Version: flow.VERSION,
+	PersistentPreRunE: func(cmd *cobra.Command, args []string) error {
+		if allowNetwork && offline {
+			return fmt.Errorf("--allow-network and --offline cannot be combined")
+		}
+		return setupColor()
+	},
 }
 
 // Execute adds all child commands to the root command and sets flags appropriately.
@@ -33,6 +44,11 @@ func Execute() {
 
 func init() {
 	cobra.OnInitialize(initConfig)
+	rootCmd.PersistentFlags().BoolVar(&allowNetwork, "allow-network", false, "allow network access for input documents given as http(s) URL and dynaml functions like http_get")
+	rootCmd.PersistentFlags().BoolVar(&offline, "offline", false, "deny network access (default, cannot be combined with --allow-network)")
+	rootCmd.PersistentFlags().StringVar(&colorMode, "color", COLOR_AUTO, "colored output (auto, always or never), auto uses colors only for terminals")
+	rootCmd.PersistentFlags().BoolVar(&noColor, "no-color", false, "disable colored output (same as --color never)")
+	rootCmd.PersistentFlags().DurationVar(&httpTimeout, "http-timeout", 30*time.Second, "timeout for getting input documents given as http(s) URL and for the http_get functions")
 }
 
 // initConfig reads in config file and ENV variables if set.
@@ -40,20 +56,32 @@ func initConfig() {
 	viper.AutomaticEnv() // read in environment variables that match
 }
 
+// IsURL checks whether a file name denotes an http(s) URL.
+func IsURL(file string) bool {
+	return strings.HasPrefix(file, "http:") || strings.HasPrefix(file, "https:")
+}
+
+// DisplayPath returns the name of a file or URL used for messages.
+func DisplayPath(file string) string {
+	if IsURL(file) {
+		return file
+	}
+	return path.Clean(file)
+}
+
+// networkState provides the network capability (MODE_NETWORK) and the
+// http limits configured by the options --allow-network and --http-timeout.
+func networkState() *flow.State {
+	return flow.NewDefaultState().SetNetworkAccess(allowNetwork).SetHTTPLimits(httpTimeout, 0)
+}
+
 func ReadFile(file string) ([]byte, error) {
-	if strings.HasPrefix(file, "http:") || strings.HasPrefix(file, "https:") {
-		response, err := http.Get(file)
-		if err != nil {
-			return nil, fmt.Errorf("error getting [%s]: %s", file, err)
-		} else {
-			if response.StatusCode != http.StatusOK {
-				defer response.Body.Close()
-				msg, _ := ioutil.ReadAll(response.Body)
-				return nil, fmt.Errorf("[status %d]: %s", response.StatusCode, msg)
-			}
-			return ioutil.ReadAll(response.Body)
+	if IsURL(file) {
+		state := networkState()
+		if !state.OnlineAllowed() {
+			return nil, fmt.Errorf("network access disabled (see --allow-network), cannot get [%s]", file)
 		}
-	} else {
-		return ioutil.ReadFile(file)
+		return dynaml.HTTPGet(file, state)
 	}
+	return ioutil.ReadFile(file)
 }
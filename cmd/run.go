@@ -40,6 +40,7 @@ func init() {
 	processCmd.Flags().BoolVar(&processingOptions.Partial, "partial", false, "Allow partial evaluation only")
 	processCmd.Flags().StringVar(&outputPath, "path", "", "output is taken from given path")
 	processCmd.Flags().StringVar(&state, "state", "", "select state file to maintain")
+	processCmd.Flags().StringVar(&stateFormat, "state-format", "", "format of the state file (yaml or json), default is derived from the file name")
 	processCmd.Flags().StringArrayVar(&selection, "select", []string{}, "filter dedicated output fields")
 	processCmd.Flags().BoolVar(&processingOptions.PreserveEscapes, "preserve-escapes", false, "preserve escaping for escaped expressions and merges")
 	processCmd.Flags().BoolVar(&processingOptions.PreserveTemporary, "preserve-temporary", false, "preserve temporary fields")
@@ -58,7 +59,7 @@ func run(documentFilePath, templateFilePath string, opts flow.Options, json, spl
 		documentFile, err = ReadFile(documentFilePath)
 	}
 
-	documentYAML, err := yaml.Parse(documentFilePath, documentFile)
+	documentYAML, err := yaml.ParseWithPositions(documentFilePath, documentFile)
 	if err != nil {
 		log.Fatalln(fmt.Sprintf("error parsing template [%s]:", path.Clean(documentFilePath)), err)
 	}
@@ -0,0 +1,36 @@
+package dynaml
+
+import (
+	"strings"
+)
+
+func init() {
+	RegisterFunction("starts_with", func_starts_with)
+	RegisterFunction("ends_with", func_ends_with)
+}
+
+func func_starts_with(arguments []interface{}, binding Binding) (interface{}, EvaluationInfo, bool) {
+	return _checkaffix("starts_with", strings.HasPrefix, arguments, binding)
+}
+
+func func_ends_with(arguments []interface{}, binding Binding) (interface{}, EvaluationInfo, bool) {
+	return _checkaffix("ends_with", strings.HasSuffix, arguments, binding)
+}
+
+func _checkaffix(name string, check func(string, string) bool, arguments []interface{}, binding Binding) (interface{}, EvaluationInfo, bool) {
+	info := DefaultInfo()
+
+	if len(arguments) != 2 {
+		return info.Error("%s takes exactly two arguments", name)
+	}
+
+	str, ok := arguments[0].(string)
+	if !ok {
+		return info.Error("first argument of %s must be a string", name)
+	}
+	affix, ok := arguments[1].(string)
+	if !ok {
+		return info.Error("second argument of %s must be a string", name)
+	}
+	return check(str, affix), info, true
+}
@@ -0,0 +1,42 @@
+package dynaml
+
+import (
+	"github.com/mandelsoft/spiff/yaml"
+)
+
+func func_apply(arguments []interface{}, binding Binding) (bool, interface{}, EvaluationInfo, bool) {
+	info := DefaultInfo()
+
+	if len(arguments) < 1 || len(arguments) > 3 {
+		info.SetError("apply requires one to three arguments")
+		return true, nil, info, false
+	}
+	lambda, ok := arguments[0].(LambdaValue)
+	if !ok {
+		info.SetError("apply: first argument must be a lambda value, but found %s", ExpressionType(arguments[0]))
+		return true, nil, info, false
+	}
+
+	var args []interface{}
+	if len(arguments) > 1 && arguments[1] != nil {
+		list, ok := arguments[1].([]yaml.Node)
+		if !ok {
+			info.SetError("apply: second argument must be a list of arguments, but found %s", ExpressionType(arguments[1]))
+			return true, nil, info, false
+		}
+		for _, a := range list {
+			args = append(args, a.Value())
+		}
+	}
+
+	var named map[string]yaml.Node
+	if len(arguments) > 2 && arguments[2] != nil {
+		named, ok = arguments[2].(map[string]yaml.Node)
+		if !ok {
+			info.SetError("apply: third argument must be a map of named arguments, but found %s", ExpressionType(arguments[2]))
+			return true, nil, info, false
+		}
+	}
+
+	return lambda.Evaluate(false, false, false, named, args, binding, false)
+}
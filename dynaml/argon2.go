@@ -0,0 +1,123 @@
+package dynaml
+
+import (
+	"crypto/rand"
+	"crypto/subtle"
+	"encoding/base64"
+	"fmt"
+	"strings"
+
+	"golang.org/x/crypto/argon2"
+)
+
+const (
+	argon2Time    = 1
+	argon2Memory  = 64 * 1024
+	argon2Threads = 4
+	argon2KeyLen  = 32
+	argon2SaltLen = 16
+
+	argon2MinSaltLen = 8
+
+	// limits for parameters taken from hashes to check
+	argon2MaxMemory  = 1024 * 1024
+	argon2MaxTime    = 16
+	argon2MaxThreads = 255
+	argon2MinKeyLen  = 4
+	argon2MaxKeyLen  = 1024
+)
+
+func init() {
+	RegisterFunction("argon2", func_argon2)
+	RegisterFunction("argon2_check", func_argon2_check)
+}
+
+func func_argon2(arguments []interface{}, binding Binding) (interface{}, EvaluationInfo, bool) {
+	info := DefaultInfo()
+
+	if len(arguments) < 1 || len(arguments) > 2 {
+		return info.Error("argon2 takes one or two arguments")
+	}
+
+	passwd, ok := arguments[0].(string)
+	if !ok {
+		return info.Error("first argument for argon2 must be a string")
+	}
+
+	var salt []byte
+	if len(arguments) > 1 {
+		s, ok := arguments[1].(string)
+		if !ok {
+			return info.Error("second argument for argon2 must be a string")
+		}
+		if len(s) < argon2MinSaltLen {
+			return info.Error("salt for argon2 must have at least %d bytes", argon2MinSaltLen)
+		}
+		salt = []byte(s)
+	} else {
+		salt = make([]byte, argon2SaltLen)
+		if _, err := rand.Read(salt); err != nil {
+			return info.Error("argon2 error: %s", err)
+		}
+	}
+
+	key := argon2.IDKey([]byte(passwd), salt, argon2Time, argon2Memory, argon2Threads, argon2KeyLen)
+	return fmt.Sprintf("$argon2id$v=%d$m=%d,t=%d,p=%d$%s$%s", argon2.Version,
+		argon2Memory, argon2Time, argon2Threads,
+		base64.RawStdEncoding.EncodeToString(salt),
+		base64.RawStdEncoding.EncodeToString(key)), info, true
+}
+
+func func_argon2_check(arguments []interface{}, binding Binding) (interface{}, EvaluationInfo, bool) {
+	info := DefaultInfo()
+
+	if len(arguments) != 2 {
+		return info.Error("argon2_check takes two arguments")
+	}
+
+	passwd, ok := arguments[0].(string)
+	if !ok {
+		return info.Error("first argument for argon2_check must be a string")
+	}
+
+	hash, ok := arguments[1].(string)
+	if !ok {
+		return info.Error("second argument for argon2_check must be a string")
+	}
+
+	fields := strings.Split(hash, "$")
+	if len(fields) != 6 || fields[0] != "" || fields[1] != "argon2id" {
+		return info.Error("invalid argon2id hash")
+	}
+	var version int
+	if _, err := fmt.Sscanf(fields[2], "v=%d", &version); err != nil || version != argon2.Version {
+		return info.Error("unsupported argon2 version %q", fields[2])
+	}
+	var memory, time, threads uint32
+	if _, err := fmt.Sscanf(fields[3], "m=%d,t=%d,p=%d", &memory, &time, &threads); err != nil {
+		return info.Error("invalid argon2 parameters %q", fields[3])
+	}
+	if memory < 1 || memory > argon2MaxMemory {
+		return info.Error("argon2 memory must be in range [1,%d]", argon2MaxMemory)
+	}
+	if time < 1 || time > argon2MaxTime {
+		return info.Error("argon2 time must be in range [1,%d]", argon2MaxTime)
+	}
+	if threads < 1 || threads > argon2MaxThreads {
+		return info.Error("argon2 parallelism must be in range [1,%d]", argon2MaxThreads)
+	}
+	salt, err := base64.RawStdEncoding.DecodeString(fields[4])
+	if err != nil {
+		return info.Error("invalid argon2 salt: %s", err)
+	}
+	key, err := base64.RawStdEncoding.DecodeString(fields[5])
+	if err != nil {
+		return info.Error("invalid argon2 key: %s", err)
+	}
+	if len(key) < argon2MinKeyLen || len(key) > argon2MaxKeyLen {
+		return info.Error("argon2 key length must be in range [%d,%d]", argon2MinKeyLen, argon2MaxKeyLen)
+	}
+
+	other := argon2.IDKey([]byte(passwd), salt, time, memory, uint8(threads), uint32(len(key)))
+	return subtle.ConstantTimeCompare(key, other) == 1, info, true
+}
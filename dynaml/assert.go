@@ -0,0 +1,80 @@
+package dynaml
+
+import (
+	"strings"
+)
+
+func init() {
+	RegisterFunction("assert", func_assert)
+	RegisterFunction("assert_type", func_assert_type)
+}
+
+var assertTypes = map[string][]string{
+	"string":   {"string"},
+	"int":      {"int"},
+	"float":    {"float"},
+	"number":   {"int", "float"},
+	"bool":     {"bool"},
+	"list":     {"list"},
+	"map":      {"map"},
+	"nil":      {"nil"},
+	"lambda":   {"lambda"},
+	"template": {"template"},
+}
+
+func func_assert(arguments []interface{}, binding Binding) (interface{}, EvaluationInfo, bool) {
+	info := DefaultInfo()
+
+	if len(arguments) < 2 || len(arguments) > 3 {
+		return info.Error("assert requires two or three arguments (condition, message and optional value)")
+	}
+	cond, ok := arguments[0].(bool)
+	if !ok {
+		return info.Error("assert: condition must be a bool, but found %s", ExpressionType(arguments[0]))
+	}
+	msg, ok := arguments[1].(string)
+	if !ok {
+		return info.Error("assert: message must be a string, but found %s", ExpressionType(arguments[1]))
+	}
+	if !cond {
+		return info.Error("assertion failed: %s", msg)
+	}
+	if len(arguments) == 3 {
+		return arguments[2], info, true
+	}
+	return nil, info, true
+}
+
+func func_assert_type(arguments []interface{}, binding Binding) (interface{}, EvaluationInfo, bool) {
+	info := DefaultInfo()
+
+	if len(arguments) < 2 || len(arguments) > 3 {
+		return info.Error("assert_type requires two or three arguments (value, type and optional message)")
+	}
+	name, ok := arguments[1].(string)
+	if !ok {
+		return info.Error("assert_type: type must be a string, but found %s", ExpressionType(arguments[1]))
+	}
+	types, ok := assertTypes[name]
+	if !ok {
+		return info.Error("assert_type: unknown type %q", name)
+	}
+	msg := ""
+	if len(arguments) == 3 {
+		msg, ok = arguments[2].(string)
+		if !ok {
+			return info.Error("assert_type: message must be a string, but found %s", ExpressionType(arguments[2]))
+		}
+	}
+
+	tn := ExpressionType(arguments[0])
+	for _, t := range types {
+		if t == tn {
+			return arguments[0], info, true
+		}
+	}
+	if msg == "" {
+		return info.Error("assertion failed: %s expected, but found %s", strings.Join(types, " or "), tn)
+	}
+	return info.Error("assertion failed: %s (%s expected, but found %s)", msg, strings.Join(types, " or "), tn)
+}
@@ -2,10 +2,17 @@ package dynaml
 
 import (
 	"encoding/base64"
+	"encoding/json"
 	"strconv"
 	"strings"
+
+	"github.com/mandelsoft/spiff/yaml"
 )
 
+func init() {
+	RegisterFunction("decode_and_parse", func_decode_and_parse)
+}
+
 func func_base64(arguments []interface{}, binding Binding) (interface{}, EvaluationInfo, bool) {
 	var err error
 
@@ -58,6 +65,51 @@ func func_base64_decode(arguments []interface{}, binding Binding) (interface{},
 	return string(result), info, true
 }
 
+func func_decode_and_parse(arguments []interface{}, binding Binding) (interface{}, EvaluationInfo, bool) {
+	info := DefaultInfo()
+
+	if len(arguments) < 1 || len(arguments) > 2 {
+		return info.Error("decode_and_parse takes one or two arguments")
+	}
+
+	str, ok := arguments[0].(string)
+	if !ok {
+		return info.Error("first argument for decode_and_parse must be a string")
+	}
+
+	format := "yaml"
+	if len(arguments) > 1 {
+		format, ok = arguments[1].(string)
+		if !ok {
+			return info.Error("second argument for decode_and_parse must be a string")
+		}
+	}
+
+	data, err := base64.StdEncoding.DecodeString(str)
+	if err != nil {
+		return info.Error("decode_and_parse: cannot decode base64: %s", err)
+	}
+
+	switch format {
+	case "raw":
+		return string(data), info, true
+	case "json":
+		if !json.Valid(data) {
+			return info.Error("decode_and_parse: cannot parse json: invalid json document")
+		}
+	case "yaml":
+	default:
+		return info.Error("decode_and_parse: invalid format %q (use yaml, json or raw)", format)
+	}
+
+	node, err := yaml.Parse(strings.Join(binding.Path(), "."), data)
+	if err != nil {
+		return info.Error("decode_and_parse: cannot parse %s: %s", format, err)
+	}
+	info.Raw = true
+	return node.Value(), info, true
+}
+
 func Base64Encode(data []byte, max int) string {
 	str := base64.StdEncoding.EncodeToString(data)
 	if max > 0 {
@@ -23,6 +23,9 @@ func func_bcrypt(arguments []interface{}, binding Binding) (interface{}, Evaluat
 		if !ok {
 			return info.Error("second argument for bcrypt must be an integer")
 		}
+		if c < int64(bcrypt.MinCost) || c > int64(bcrypt.MaxCost) {
+			return info.Error("cost for bcrypt must be in range [%d,%d]", bcrypt.MinCost, bcrypt.MaxCost)
+		}
 		cost = int(c)
 	}
 	result, err := bcrypt.GenerateFromPassword([]byte(str), cost)
@@ -14,20 +14,38 @@ type Function func(arguments []interface{}, binding Binding) (interface{}, Evalu
 type Functions interface {
 	RegisterFunction(name string, f Function)
 	LookupFunction(name string) Function
+	// DeprecateFunction marks a function as deprecated in favor
+	// of the given replacement.
+	DeprecateFunction(name, replacement string)
+	// LookupDeprecation returns the replacement of a deprecated function.
+	LookupDeprecation(name string) (string, bool)
 }
 
 type functionRegistry struct {
-	functions map[string]Function
+	functions  map[string]Function
+	deprecated map[string]string
 }
 
 func NewFunctions() Functions {
-	return &functionRegistry{map[string]Function{}}
+	return &functionRegistry{map[string]Function{}, map[string]string{}}
 }
 
 func (r *functionRegistry) RegisterFunction(name string, f Function) {
 	r.functions[name] = f
 }
 
+func (r *functionRegistry) DeprecateFunction(name, replacement string) {
+	r.deprecated[name] = replacement
+}
+
+func (r *functionRegistry) LookupDeprecation(name string) (string, bool) {
+	replacement, ok := r.deprecated[name]
+	if ok || r == function_registry {
+		return replacement, ok
+	}
+	return function_registry.LookupDeprecation(name)
+}
+
 func (r *functionRegistry) LookupFunction(name string) Function {
 	f := r.functions[name]
 	if f != nil || r == function_registry {
@@ -40,6 +58,12 @@ func RegisterFunction(name string, f Function) {
 	function_registry.RegisterFunction(name, f)
 }
 
+// DeprecateFunction globally marks a function as deprecated in favor
+// of the given replacement. Its usage is reported as warning.
+func DeprecateFunction(name, replacement string) {
+	function_registry.DeprecateFunction(name, replacement)
+}
+
 var function_registry = NewFunctions()
 
 type NameArgument struct {
@@ -85,12 +109,20 @@ func (e CallExpr) Evaluate(binding Binding, locally bool) (interface{}, Evaluati
 
 	cleaned := false
 
+	if funcName != "" {
+		defer profileFunction(binding, funcName)()
+	} else {
+		defer profileFunction(binding, "<lambda>")()
+	}
+
 	var f func(binding Binding) (interface{}, EvaluationInfo, bool)
 	switch funcName {
 	case "defined":
 		f = e.defined
 	case "require":
 		f = e.require
+	case "require_all":
+		f = e.require_all
 	case "valid":
 		f = e.valid
 	case "stub":
@@ -99,6 +131,14 @@ func (e CallExpr) Evaluate(binding Binding, locally bool) (interface{}, Evaluati
 		f = e.catch
 	case "sync":
 		f = e.sync
+	case "coalesce":
+		f = e.coalesce
+	case "switch":
+		f = e.switchcase
+	case "cond":
+		f = e.cond
+	case "with":
+		f = e.with
 	}
 
 	if f != nil {
@@ -165,7 +205,9 @@ func (e CallExpr) Evaluate(binding Binding, locally bool) (interface{}, Evaluati
 		}
 
 	case "join":
-		result, sub, ok = func_join(values, binding)
+		resolved, result, sub, ok = func_join(values, binding)
+	case "join_with":
+		resolved, result, sub, ok = func_join_with(values, binding)
 
 	case "split":
 		result, sub, ok = func_split(values, binding)
@@ -175,8 +217,8 @@ func (e CallExpr) Evaluate(binding Binding, locally bool) (interface{}, Evaluati
 	case "trim":
 		result, sub, ok = func_trim(values, binding)
 
-	case "length":
-		result, sub, ok = func_length(values, binding)
+	case "length", "size":
+		result, sub, ok = func_length(funcName, values, binding)
 
 	case "uniq":
 		result, sub, ok = func_uniq(values, binding)
@@ -203,6 +245,42 @@ func (e CallExpr) Evaluate(binding Binding, locally bool) (interface{}, Evaluati
 	case "sort":
 		result, sub, ok = func_sort(values, binding)
 
+	case "apply":
+		resolved, result, sub, ok = func_apply(values, binding)
+
+	case "pipeline":
+		resolved, result, sub, ok = func_pipeline(values, binding)
+	case "compose":
+		result, sub, ok = func_compose(values, binding)
+	case "partition":
+		resolved, result, sub, ok = func_partition(values, binding)
+	case "count":
+		resolved, result, sub, ok = func_count(values, binding)
+	case "count_by":
+		resolved, result, sub, ok = func_count_by(values, binding)
+	case "find":
+		resolved, result, sub, ok = func_find(values, binding)
+	case "find_index":
+		resolved, result, sub, ok = func_find_index(values, binding)
+	case "any":
+		resolved, result, sub, ok = func_any(values, binding)
+	case "all":
+		resolved, result, sub, ok = func_all(values, binding)
+	case "none":
+		resolved, result, sub, ok = func_none(values, binding)
+	case "map_values":
+		resolved, result, sub, ok = func_map_values(values, binding)
+	case "map_keys":
+		resolved, result, sub, ok = func_map_keys(values, binding)
+	case "mapentries":
+		resolved, result, sub, ok = func_mapentries(values, binding)
+	case "filter_map":
+		resolved, result, sub, ok = func_filter_map(values, binding)
+	case "filter_keys":
+		resolved, result, sub, ok = func_filter_keys(values, binding)
+	case "filter_values":
+		resolved, result, sub, ok = func_filter_values(values, binding)
+
 	case "exec":
 		result, sub, ok = func_exec(true, values, binding)
 		cleaned = true
@@ -216,6 +294,11 @@ func (e CallExpr) Evaluate(binding Binding, locally bool) (interface{}, Evaluati
 		result, sub, ok = func_pipe(false, values, binding)
 		cleaned = true
 
+	case "http_get":
+		result, sub, ok = func_http_get(false, values, binding)
+	case "http_get_json":
+		result, sub, ok = func_http_get(true, values, binding)
+
 	case "eval":
 		result, sub, ok = func_eval(values, binding, locally)
 
@@ -238,6 +321,12 @@ func (e CallExpr) Evaluate(binding Binding, locally bool) (interface{}, Evaluati
 		result, sub, ok = func_lookup(false, values, binding)
 	case "lookup_dir":
 		result, sub, ok = func_lookup(true, values, binding)
+	case "include":
+		result, sub, ok = func_include(values, binding)
+		cleaned = true
+	case "lookup_read":
+		result, sub, ok = func_lookup_read(true, values, binding)
+		cleaned = true
 	case "list_files":
 		result, sub, ok = func_listFiles(false, values, binding)
 	case "list_dirs":
@@ -308,6 +397,12 @@ func (e CallExpr) Evaluate(binding Binding, locally bool) (interface{}, Evaluati
 		result, sub, ok = func_lower(values, binding)
 	case "upper":
 		result, sub, ok = func_upper(values, binding)
+	case "swapcase":
+		result, sub, ok = func_swapcase(values, binding)
+	case "escape_expr":
+		result, sub, ok = func_escape_expr(values, binding)
+	case "unescape_expr":
+		result, sub, ok = func_unescape_expr(values, binding)
 
 	case "keys":
 		result, sub, ok = func_keys(values, binding)
@@ -320,7 +415,7 @@ func (e CallExpr) Evaluate(binding Binding, locally bool) (interface{}, Evaluati
 	case "check":
 		resolved, result, sub, ok = func_check(values, binding)
 
-	case "type":
+	case "type", "type_of":
 		if info.Undefined {
 			info.Undefined = false
 			return "undef", info, ok
@@ -339,6 +434,13 @@ func (e CallExpr) Evaluate(binding Binding, locally bool) (interface{}, Evaluati
 	if cleaned {
 		info.Cleanup()
 	}
+	if funcName != "" && binding != nil {
+		if s := binding.GetState(); s != nil {
+			if _, deprecated := s.GetRegistry().LookupDeprecation(funcName); deprecated {
+				sub.Deprecated = append(sub.Deprecated, funcName)
+			}
+		}
+	}
 	if ok && (!resolved || IsExpression(result)) {
 		return e, sub.Join(info), true
 	}
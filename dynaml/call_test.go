@@ -1,9 +1,12 @@
 package dynaml
 
 import (
+	"bytes"
+
 	. "github.com/onsi/ginkgo"
 	. "github.com/onsi/gomega"
 
+	"github.com/mandelsoft/spiff/debug"
 	"github.com/mandelsoft/spiff/yaml"
 )
 
@@ -335,4 +338,33 @@ var _ = Describe("calls", func() {
 			})
 		})
 	})
+
+	Describe("inspect", func() {
+		var buf *bytes.Buffer
+
+		BeforeEach(func() {
+			buf = &bytes.Buffer{}
+			inspectWriter = buf
+		})
+
+		AfterEach(func() {
+			debug.DebugFlag = false
+		})
+
+		It("prints the value in debug mode", func() {
+			debug.DebugFlag = true
+			value := map[string]yaml.Node{"alice": NewNode(int64(25), nil)}
+			result, _, ok := func_inspect([]interface{}{value, "label"}, FakeBinding{path: []string{"foo", "bar"}})
+			Expect(ok).To(BeTrue())
+			Expect(result).To(Equal(value))
+			Expect(buf.String()).To(Equal("label [foo.bar]: {\"alice\":25}\n"))
+		})
+
+		It("is an identity without debug mode", func() {
+			result, _, ok := func_inspect([]interface{}{"value", "label"}, FakeBinding{})
+			Expect(ok).To(BeTrue())
+			Expect(result).To(Equal("value"))
+			Expect(buf.String()).To(Equal(""))
+		})
+	})
 })
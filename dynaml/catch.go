@@ -52,6 +52,7 @@ func (e CatchExpr) Evaluate(binding Binding, locally bool) (interface{}, Evaluat
 	}
 	// no lambda -> returning deprecated  map
 	if lambda == nil {
+		info.Warn("catch without lambda is deprecated, use catch(expr, lambda)")
 		result := map[string]yaml.Node{}
 		if !ok {
 			debug.Debug("catch arg failed\n")
@@ -0,0 +1,24 @@
+package dynaml
+
+func (e CallExpr) coalesce(binding Binding) (interface{}, EvaluationInfo, bool) {
+	info := DefaultInfo()
+	if len(e.Arguments) == 0 {
+		return info.Error("at least one argument required for 'coalesce'")
+	}
+
+	pushed := make([]Expression, len(e.Arguments))
+	copy(pushed, e.Arguments)
+	for i := range pushed {
+		resolved := true
+		val, infoe, ok := ResolveExpressionOrPushEvaluation(&pushed[i], &resolved, nil, binding, false)
+		if !resolved {
+			// wait for the actual candidate, subsequent ones must not be evaluated
+			return e, info, true
+		}
+		if !ok || infoe.Undefined || val == nil {
+			continue
+		}
+		return val, infoe, true
+	}
+	return nil, info, true
+}
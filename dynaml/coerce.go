@@ -0,0 +1,52 @@
+package dynaml
+
+import (
+	"github.com/mandelsoft/spiff/yaml"
+)
+
+type coercion func(value interface{}) (interface{}, error)
+
+func init() {
+	registerCoercion("asint", "asints", func(v interface{}) (interface{}, error) { return convertToInteger(v) })
+	registerCoercion("asfloat", "asfloats", func(v interface{}) (interface{}, error) { return convertToFloat(v) })
+	registerCoercion("asstring", "asstrings", func(v interface{}) (interface{}, error) { return convertToString(v) })
+}
+
+// registerCoercion registers a scalar and a list flavor of a type coercion.
+func registerCoercion(scalar, list string, c coercion) {
+	RegisterFunction(scalar, func(arguments []interface{}, binding Binding) (interface{}, EvaluationInfo, bool) {
+		info := DefaultInfo()
+		if len(arguments) != 1 {
+			return info.Error("%s requires one argument", scalar)
+		}
+		result, err := c(arguments[0])
+		if err != nil {
+			return info.Error("%s: %s", scalar, err)
+		}
+		return result, info, true
+	})
+
+	RegisterFunction(list, func(arguments []interface{}, binding Binding) (interface{}, EvaluationInfo, bool) {
+		info := DefaultInfo()
+		if len(arguments) != 1 {
+			return info.Error("%s requires one argument", list)
+		}
+		elems, ok := arguments[0].([]yaml.Node)
+		if !ok {
+			return info.Error("%s requires a list argument", list)
+		}
+		result := make([]yaml.Node, len(elems))
+		for i, e := range elems {
+			var v interface{}
+			if e != nil {
+				v = e.Value()
+			}
+			r, err := c(v)
+			if err != nil {
+				return info.Error("%s: entry %d: %s", list, i, err)
+			}
+			result[i] = NewNode(r, binding)
+		}
+		return result, info, true
+	})
+}
@@ -41,6 +41,12 @@ func (e ComparisonExpr) Evaluate(binding Binding, locally bool) (interface{}, Ev
 	case "!=":
 		result, infor, ok = compareEquals(a, b)
 		result = !result
+	case "in":
+		var msg string
+		result, ok, msg = isMember(a, b)
+		if !ok {
+			return infor.Error("operator in: %s", msg)
+		}
 	case "<=", "<", ">", ">=":
 		switch va := a.(type) {
 		case int64:
@@ -33,6 +33,24 @@ var _ = Describe("comparison operators", func() {
 		compareIt("!=", []bool{true, false, true})
 	})
 
+	Context("in", func() {
+		It("checks list elements", func() {
+			list := ListExpr{[]Expression{StringExpr{"a"}, IntegerExpr{1}}}
+			Expect(ComparisonExpr{StringExpr{"a"}, "in", list}).To(EvaluateAs(true, FakeBinding{}))
+			Expect(ComparisonExpr{IntegerExpr{1}, "in", list}).To(EvaluateAs(true, FakeBinding{}))
+			Expect(ComparisonExpr{StringExpr{"b"}, "in", list}).To(EvaluateAs(false, FakeBinding{}))
+		})
+
+		It("checks sub strings", func() {
+			Expect(ComparisonExpr{StringExpr{"ell"}, "in", StringExpr{"hello"}}).To(EvaluateAs(true, FakeBinding{}))
+			Expect(ComparisonExpr{StringExpr{"elo"}, "in", StringExpr{"hello"}}).To(EvaluateAs(false, FakeBinding{}))
+		})
+
+		It("fails for non-container", func() {
+			Expect(ComparisonExpr{IntegerExpr{1}, "in", IntegerExpr{2}}).To(FailToEvaluate(FakeBinding{}))
+		})
+	})
+
 	Context("when one side fails", func() {
 		It("fails for left side failing", func() {
 			expr := ComparisonExpr{
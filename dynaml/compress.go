@@ -0,0 +1,104 @@
+package dynaml
+
+import (
+	"bytes"
+	"compress/flate"
+	"compress/gzip"
+	"encoding/base64"
+	"io"
+	"io/ioutil"
+)
+
+func init() {
+	RegisterFunction("gzip", func_gzip)
+	RegisterFunction("gunzip", func_gunzip)
+	RegisterFunction("deflate", func_deflate)
+	RegisterFunction("inflate", func_inflate)
+}
+
+func func_gzip(arguments []interface{}, binding Binding) (interface{}, EvaluationInfo, bool) {
+	return _compress("gzip", func(w io.Writer, level int) (io.WriteCloser, error) {
+		return gzip.NewWriterLevel(w, level)
+	}, arguments, binding)
+}
+
+func func_deflate(arguments []interface{}, binding Binding) (interface{}, EvaluationInfo, bool) {
+	return _compress("deflate", func(w io.Writer, level int) (io.WriteCloser, error) {
+		return flate.NewWriter(w, level)
+	}, arguments, binding)
+}
+
+func func_gunzip(arguments []interface{}, binding Binding) (interface{}, EvaluationInfo, bool) {
+	return _uncompress("gunzip", func(r io.Reader) (io.Reader, error) {
+		return gzip.NewReader(r)
+	}, arguments, binding)
+}
+
+func func_inflate(arguments []interface{}, binding Binding) (interface{}, EvaluationInfo, bool) {
+	return _uncompress("inflate", func(r io.Reader) (io.Reader, error) {
+		return flate.NewReader(r), nil
+	}, arguments, binding)
+}
+
+func _compress(name string, writer func(io.Writer, int) (io.WriteCloser, error), arguments []interface{}, binding Binding) (interface{}, EvaluationInfo, bool) {
+	info := DefaultInfo()
+
+	if len(arguments) < 1 || len(arguments) > 2 {
+		return info.Error("%s takes one or two arguments", name)
+	}
+
+	data, ok := arguments[0].(string)
+	if !ok {
+		return info.Error("first argument for %s must be a string", name)
+	}
+
+	level := int64(flate.DefaultCompression)
+	if len(arguments) > 1 {
+		level, ok = arguments[1].(int64)
+		if !ok {
+			return info.Error("second argument for %s must be an integer", name)
+		}
+	}
+
+	var buf bytes.Buffer
+	w, err := writer(&buf, int(level))
+	if err != nil {
+		return info.Error("%s: %s", name, err)
+	}
+	_, err = w.Write([]byte(data))
+	if err == nil {
+		err = w.Close()
+	}
+	if err != nil {
+		return info.Error("%s: %s", name, err)
+	}
+	return Base64Encode(buf.Bytes(), -1), info, true
+}
+
+func _uncompress(name string, reader func(io.Reader) (io.Reader, error), arguments []interface{}, binding Binding) (interface{}, EvaluationInfo, bool) {
+	info := DefaultInfo()
+
+	if len(arguments) != 1 {
+		return info.Error("%s takes exactly one argument", name)
+	}
+
+	str, ok := arguments[0].(string)
+	if !ok {
+		return info.Error("argument for %s must be a base64 encoded string", name)
+	}
+
+	data, err := base64.StdEncoding.DecodeString(str)
+	if err != nil {
+		return info.Error("%s: cannot decode base64: %s", name, err)
+	}
+
+	r, err := reader(bytes.NewReader(data))
+	if err != nil {
+		return info.Error("%s: %s", name, err)
+	}
+	result, err := ioutil.ReadAll(r)
+	if err != nil {
+		return info.Error("%s: %s", name, err)
+	}
+	return string(result), info, true
+}
@@ -0,0 +1,58 @@
+package dynaml
+
+import (
+	"github.com/mandelsoft/spiff/yaml"
+)
+
+func init() {
+	RegisterFunction("concat", func_concat)
+}
+
+func func_concat(arguments []interface{}, binding Binding) (interface{}, EvaluationInfo, bool) {
+	var result interface{}
+	info := DefaultInfo()
+
+	for i, arg := range arguments {
+		if arg == nil {
+			continue
+		}
+		switch v := arg.(type) {
+		case string:
+			switch r := result.(type) {
+			case nil:
+				result = v
+			case string:
+				result = r + v
+			default:
+				return info.Error("concat: argument %d: type 'string' cannot be concatenated with '%s'", i+1, ExpressionType(result))
+			}
+		case []yaml.Node:
+			switch r := result.(type) {
+			case nil:
+				result = append([]yaml.Node{}, v...)
+			case []yaml.Node:
+				result = append(r, v...)
+			default:
+				return info.Error("concat: argument %d: type 'list' cannot be concatenated with '%s'", i+1, ExpressionType(result))
+			}
+		case map[string]yaml.Node:
+			switch r := result.(type) {
+			case nil:
+				m := map[string]yaml.Node{}
+				for k, e := range v {
+					m[k] = e
+				}
+				result = m
+			case map[string]yaml.Node:
+				for k, e := range v {
+					r[k] = e
+				}
+			default:
+				return info.Error("concat: argument %d: type 'map' cannot be concatenated with '%s'", i+1, ExpressionType(result))
+			}
+		default:
+			return info.Error("concat: argument %d: type '%s' not supported, only strings, lists or maps can be concatenated", i+1, ExpressionType(arg))
+		}
+	}
+	return result, info, true
+}
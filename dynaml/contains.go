@@ -14,45 +14,50 @@ func func_contains(arguments []interface{}, binding Binding) (interface{}, Evalu
 		return info.Error("function contains takes exactly two arguments")
 	}
 
-	switch val := arguments[0].(type) {
+	result, ok, msg := isMember(arguments[1], arguments[0])
+	if !ok {
+		return info.Error("function contains: %s", msg)
+	}
+	return result, info, true
+}
+
+// isMember checks whether elem is an element of a list, a key of a map or
+// a sub string of a string. If the container or element types are not
+// suitable, false and a description of the problem is returned.
+func isMember(elem, container interface{}) (bool, bool, string) {
+	switch val := container.(type) {
 	case map[string]yaml.Node:
-		elem := arguments[1]
-		if elem == nil {
-			return false, info, true
-		}
 		key, ok := elem.(string)
 		if !ok {
-			return false, info, true
+			return false, true, ""
 		}
 		_, ok = val[key]
-		return ok, info, true
+		return ok, true, ""
 
 	case []yaml.Node:
-		if arguments[1] == nil {
-			return false, info, true
+		if elem == nil {
+			return false, true, ""
 		}
-
-		elem := arguments[1]
-
 		for _, v := range val {
 			r, _, _ := compareEquals(v.Value(), elem)
 			if r {
-				return true, info, true
+				return true, true, ""
 			}
 		}
+		return false, true, ""
+
 	case string:
-		switch elem := arguments[1].(type) {
+		switch elem := elem.(type) {
 		case string:
-			return strings.Contains(val, elem), info, true
+			return strings.Contains(val, elem), true, ""
 		case int64:
-			return strings.Contains(val, strconv.FormatInt(elem, 10)), info, true
+			return strings.Contains(val, strconv.FormatInt(elem, 10)), true, ""
 		case bool:
-			return strings.Contains(val, strconv.FormatBool(elem)), info, true
+			return strings.Contains(val, strconv.FormatBool(elem)), true, ""
 		default:
-			return info.Error("invalid type for check string")
+			return false, false, "invalid element type for string"
 		}
 	default:
-		return info.Error("list or string expected for argument one of function contains")
+		return false, false, "list, map or string expected"
 	}
-	return false, info, true
 }
@@ -24,8 +24,6 @@ func func_string(arguments []interface{}, binding Binding) (interface{}, Evaluat
 		}
 	}
 	switch v := arguments[0].(type) {
-	case string:
-		return v, info, true
 	case int64:
 		base := 10
 		if len(arguments) == 2 {
@@ -39,15 +37,12 @@ func func_string(arguments []interface{}, binding Binding) (interface{}, Evaluat
 			}
 		}
 		return strconv.FormatInt(v, base), info, true
-	case float64:
-		return fmt.Sprintf("%g", v), info, true
-	case bool:
-		if v {
-			return "true", info, true
-		}
-		return "false", info, true
 	default:
-		return info.Error("cannot convert %T to string", v)
+		str, err := convertToString(v)
+		if err != nil {
+			return info.Error("%s", err)
+		}
+		return str, info, true
 	}
 }
 
@@ -56,50 +51,84 @@ func func_integer(arguments []interface{}, binding Binding) (interface{}, Evalua
 	if len(arguments) != 1 {
 		return info.Error("integer requires one argument")
 	}
-	switch v := arguments[0].(type) {
+	i, err := convertToInteger(arguments[0])
+	if err != nil {
+		return info.Error("%s", err)
+	}
+	return i, info, true
+}
+
+func func_float(arguments []interface{}, binding Binding) (interface{}, EvaluationInfo, bool) {
+	info := DefaultInfo()
+	if len(arguments) != 1 {
+		return info.Error("float requires one argument")
+	}
+	f, err := convertToFloat(arguments[0])
+	if err != nil {
+		return info.Error("%s", err)
+	}
+	return f, info, true
+}
+
+func convertToString(value interface{}) (string, error) {
+	switch v := value.(type) {
+	case string:
+		return v, nil
+	case int64:
+		return strconv.FormatInt(v, 10), nil
+	case float64:
+		return fmt.Sprintf("%g", v), nil
+	case bool:
+		if v {
+			return "true", nil
+		}
+		return "false", nil
+	default:
+		return "", fmt.Errorf("cannot convert %s to string", ExpressionType(v))
+	}
+}
+
+func convertToInteger(value interface{}) (int64, error) {
+	switch v := value.(type) {
 	case string:
 		i, err := strconv.ParseInt(v, 10, 64)
 		if err != nil {
-			return info.Error("%q is no integer value: %s", err)
+			return 0, fmt.Errorf("%q is no integer value: %s", v, err)
 		}
-		return i, info, true
+		return i, nil
 	case int64:
-		return v, info, true
+		return v, nil
 	case float64:
-		return int64(v), info, true
+		return int64(v), nil
 	case bool:
 		if v {
-			return 1, info, true
+			return 1, nil
 		}
-		return 0, info, true
+		return 0, nil
 	default:
-		return info.Error("cannot convert %T to integer", v)
+		return 0, fmt.Errorf("cannot convert %s to integer", ExpressionType(v))
 	}
 }
 
-func func_float(arguments []interface{}, binding Binding) (interface{}, EvaluationInfo, bool) {
-	info := DefaultInfo()
-	if len(arguments) != 1 {
-		return info.Error("float requires one argument")
-	}
-	switch v := arguments[0].(type) {
+func convertToFloat(value interface{}) (float64, error) {
+	switch v := value.(type) {
 	case string:
 		f, err := strconv.ParseFloat(v, 64)
 		if err != nil {
-			return info.Error("%q is no float value: %s", err)
+			return 0, fmt.Errorf("%q is no float value: %s", v, err)
 		}
-		return f, info, true
+		return f, nil
 	case int64:
-		return float64(v), info, true
+		return float64(v), nil
 	case float64:
-		return v, info, true
+		return v, nil
 	case bool:
 		if v {
-			return float64(1), info, true
+			return 1, nil
 		}
-		return float64(0), info, true
+		return 0, nil
 	default:
-		return info.Error("cannot convert %T to float", v)
+		return 0, fmt.Errorf("cannot convert %s to float", ExpressionType(v))
 	}
 }
 
@@ -118,7 +147,7 @@ func func_bool(arguments []interface{}, binding Binding) (interface{}, Evaluatio
 		}
 		i, err := strconv.ParseBool(v)
 		if err != nil {
-			return info.Error("%q is no bool value: %s", err)
+			return info.Error("%q is no bool value: %s", v, err)
 		}
 		return i, info, true
 	case int64:
@@ -0,0 +1,91 @@
+package dynaml
+
+import (
+	"fmt"
+
+	"github.com/mandelsoft/spiff/yaml"
+)
+
+func func_count(arguments []interface{}, binding Binding) (bool, interface{}, EvaluationInfo, bool) {
+	info := DefaultInfo()
+
+	if len(arguments) != 2 {
+		info.SetError("count requires two arguments (list and predicate or value)")
+		return true, nil, info, false
+	}
+	list, ok := arguments[0].([]yaml.Node)
+	if !ok && arguments[0] != nil {
+		info.SetError("count: first argument must be a list, but found %s", ExpressionType(arguments[0]))
+		return true, nil, info, false
+	}
+
+	count := int64(0)
+	lambda, ok := arguments[1].(LambdaValue)
+	for i, e := range list {
+		if !ok {
+			if eq, _, _ := compareEquals(e.Value(), arguments[1]); eq {
+				count++
+			}
+			continue
+		}
+		resolved, v, sub, ok := lambda.Evaluate(false, false, false, nil, []interface{}{e.Value()}, binding, false)
+		if !ok {
+			return true, nil, sub, false
+		}
+		if !resolved {
+			return false, nil, sub, true
+		}
+		b, ok := v.(bool)
+		if !ok {
+			info.SetError("count: predicate for entry %d must return a bool, but found %s", i, ExpressionType(v))
+			return true, nil, info, false
+		}
+		if b {
+			count++
+		}
+	}
+	return true, count, info, true
+}
+
+func func_count_by(arguments []interface{}, binding Binding) (bool, interface{}, EvaluationInfo, bool) {
+	info := DefaultInfo()
+
+	if len(arguments) != 2 {
+		info.SetError("count_by requires two arguments (list and lambda)")
+		return true, nil, info, false
+	}
+	list, ok := arguments[0].([]yaml.Node)
+	if !ok && arguments[0] != nil {
+		info.SetError("count_by: first argument must be a list, but found %s", ExpressionType(arguments[0]))
+		return true, nil, info, false
+	}
+	lambda, ok := arguments[1].(LambdaValue)
+	if !ok {
+		info.SetError("count_by: second argument must be a lambda value, but found %s", ExpressionType(arguments[1]))
+		return true, nil, info, false
+	}
+
+	counts := map[string]int64{}
+	for i, e := range list {
+		resolved, v, sub, ok := lambda.Evaluate(false, false, false, nil, []interface{}{e.Value()}, binding, false)
+		if !ok {
+			return true, nil, sub, false
+		}
+		if !resolved {
+			return false, nil, sub, true
+		}
+		switch v.(type) {
+		case string, int64, bool:
+			counts[fmt.Sprintf("%v", v)]++
+		default:
+			info.SetError("count_by: key for entry %d must be a string, int or bool, but found %s", i, ExpressionType(v))
+			return true, nil, info, false
+		}
+	}
+
+	result := map[string]yaml.Node{}
+	for k, c := range counts {
+		result[k] = NewNode(c, binding)
+	}
+	return true, result, info, true
+}
@@ -0,0 +1,105 @@
+package dynaml
+
+import (
+	"github.com/mandelsoft/spiff/yaml"
+)
+
+func init() {
+	RegisterFunction("default_deep", func_default_deep)
+}
+
+func func_default_deep(arguments []interface{}, binding Binding) (interface{}, EvaluationInfo, bool) {
+	info := DefaultInfo()
+
+	if len(arguments) < 2 || len(arguments) > 3 {
+		return info.Error("default_deep requires two or three arguments (value, defaults and optional list key field)")
+	}
+
+	key := ""
+	if len(arguments) == 3 {
+		k, ok := arguments[2].(string)
+		if !ok {
+			return info.Error("default_deep: key field must be a string, but found %s", ExpressionType(arguments[2]))
+		}
+		key = k
+	}
+
+	if arguments[0] == nil {
+		return arguments[1], info, true
+	}
+	return defaultDeepValue(arguments[0], arguments[1], key), info, true
+}
+
+// defaultDeepValue fills the fields missing or nil in value by the ones
+// found in defaults. Maps are defaulted recursively, lists are kept as they
+// are, or, if a key field is given, their map entries are defaulted by the
+// default entries with the same key value.
+func defaultDeepValue(value, defaults interface{}, key string) interface{} {
+	switch v := value.(type) {
+	case map[string]yaml.Node:
+		if d, ok := defaults.(map[string]yaml.Node); ok {
+			return defaultDeepMap(v, d, key)
+		}
+	case []yaml.Node:
+		if d, ok := defaults.([]yaml.Node); ok && key != "" {
+			return defaultDeepList(v, d, key)
+		}
+	}
+	return value
+}
+
+func defaultDeepMap(value, defaults map[string]yaml.Node, key string) map[string]yaml.Node {
+	result := make(map[string]yaml.Node, len(value)+len(defaults))
+	for k, v := range value {
+		result[k] = v
+	}
+	for k, d := range defaults {
+		if v := result[k]; v == nil || v.Value() == nil {
+			result[k] = d
+		} else if d != nil {
+			result[k] = yaml.SubstituteNode(defaultDeepValue(v.Value(), d.Value(), key), v)
+		}
+	}
+	return result
+}
+
+func defaultDeepList(value, defaults []yaml.Node, key string) []yaml.Node {
+	result := make([]yaml.Node, 0, len(value)+len(defaults))
+	used := make([]bool, len(value))
+	for _, d := range defaults {
+		dk := listEntryKey(d, key)
+		if dk != nil {
+			found := false
+			for i, v := range value {
+				if vk := listEntryKey(v, key); !used[i] && vk != nil {
+					if eq, _, _ := compareEquals(vk.Value(), dk.Value()); eq {
+						result = append(result, yaml.SubstituteNode(defaultDeepValue(v.Value(), d.Value(), key), v))
+						used[i] = true
+						found = true
+						break
+					}
+				}
+			}
+			if found {
+				continue
+			}
+		}
+		result = append(result, d)
+	}
+	for i, v := range value {
+		if !used[i] {
+			result = append(result, v)
+		}
+	}
+	return result
+}
+
+func listEntryKey(n yaml.Node, key string) yaml.Node {
+	if n == nil {
+		return nil
+	}
+	if m, ok := n.Value().(map[string]yaml.Node); ok {
+		return m[key]
+	}
+	return nil
+}
@@ -0,0 +1,138 @@
+package dynaml
+
+import (
+	"reflect"
+	"strings"
+)
+
+var expressionType = reflect.TypeOf((*Expression)(nil)).Elem()
+
+// DynamicDependency is used as last path element of a dependency
+// whose concrete path is only known at evaluation time.
+const DynamicDependency = "*"
+
+// Dependencies returns the paths of all document fields an expression
+// reads, in the order of their occurrence. Every path is reported only
+// once. Function names and references to lambda parameters or to names
+// bound by a scope are omitted. For dynamic references (like `a.[b]`)
+// the reference path of the root is reported with an additional
+// DynamicDependency element, the index expression is analysed like any
+// other expression.
+// Tagged references and references relative to the result of another
+// expression are not reported.
+func Dependencies(e Expression) [][]string {
+	d := &dependencies{found: map[string]bool{}, refs: [][]string{}}
+	d.collect(reflect.ValueOf(e), map[string]bool{})
+	return d.refs
+}
+
+type dependencies struct {
+	found map[string]bool
+	refs  [][]string
+}
+
+func (d *dependencies) add(path []string) {
+	key := strings.Join(path, ".")
+	if !d.found[key] {
+		d.found[key] = true
+		d.refs = append(d.refs, path)
+	}
+}
+
+func dependencyPath(e ReferenceExpr, bound map[string]bool) []string {
+	if e.Tag != "" || len(e.Path) == 0 || bound[e.Path[0]] {
+		return nil
+	}
+	return e.Path
+}
+
+func (d *dependencies) collect(v reflect.Value, bound map[string]bool) {
+	switch v.Kind() {
+	case reflect.Interface, reflect.Ptr:
+		if !v.IsNil() {
+			d.collect(v.Elem(), bound)
+		}
+	case reflect.Slice, reflect.Array:
+		for i := 0; i < v.Len(); i++ {
+			d.collect(v.Index(i), bound)
+		}
+	case reflect.Struct:
+		if !v.Type().Implements(expressionType) {
+			return
+		}
+		switch e := v.Interface().(type) {
+		case ValueExpr:
+			return
+		case ReferenceExpr:
+			if path := dependencyPath(e, bound); path != nil {
+				d.add(path)
+			}
+			return
+		case CallExpr:
+			if r, ok := e.Function.(ReferenceExpr); !ok || r.Tag != "" || len(r.Path) != 1 {
+				d.collect(reflect.ValueOf(e.Function), bound)
+			}
+			d.collect(reflect.ValueOf(e.Arguments), bound)
+			return
+		case QualifiedExpr:
+			d.collect(reflect.ValueOf(e.Expression), bound)
+			return
+		case MarkerExpr:
+			d.collect(reflect.ValueOf(e.expr), bound)
+			return
+		case MarkerExpressionExpr:
+			d.collect(reflect.ValueOf(e.expr), bound)
+			return
+		case PipeExpr:
+			d.collect(reflect.ValueOf(e.A), bound)
+			if c, ok := e.B.(CallExpr); ok {
+				d.collect(reflect.ValueOf(c), bound)
+			} else {
+				d.collect(reflect.ValueOf(CallExpr{Function: e.B}), bound)
+			}
+			return
+		case DynamicExpr:
+			if r, ok := e.Root.(ReferenceExpr); ok {
+				if path := dependencyPath(r, bound); path != nil {
+					d.add(append(path[:len(path):len(path)], DynamicDependency))
+				}
+			} else {
+				d.collect(reflect.ValueOf(e.Root), bound)
+			}
+			d.collect(reflect.ValueOf(e.Index), bound)
+			return
+		case LambdaExpr:
+			inner := copyBound(bound)
+			for _, p := range e.Parameters {
+				d.collect(reflect.ValueOf(p.Default), bound)
+				inner[p.Name] = true
+			}
+			d.collect(reflect.ValueOf(e.E), inner)
+			return
+		case ScopeExpr:
+			inner := copyBound(bound)
+			for _, a := range e.Assignments {
+				d.collect(reflect.ValueOf(a.Key), bound)
+				d.collect(reflect.ValueOf(a.Value), bound)
+				if s, ok := a.Key.(StringExpr); ok {
+					inner[s.Value] = true
+				}
+			}
+			d.collect(reflect.ValueOf(e.E), inner)
+			return
+		}
+		for i := 0; i < v.NumField(); i++ {
+			if v.Type().Field(i).IsExported() {
+				d.collect(v.Field(i), bound)
+			}
+		}
+	}
+}
+
+func copyBound(bound map[string]bool) map[string]bool {
+	result := map[string]bool{}
+	for k, v := range bound {
+		result[k] = v
+	}
+	return result
+}
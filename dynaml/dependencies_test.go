@@ -0,0 +1,59 @@
+package dynaml
+
+import (
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("dependencies", func() {
+	dependencies := func(source string) [][]string {
+		expr, err := Parse(source, []string{"test"}, []string{"test"})
+		Expect(err).NotTo(HaveOccurred())
+		return Dependencies(expr)
+	}
+
+	It("reports nested references once", func() {
+		Expect(dependencies(`a.b + c || join(",", a.b, [d])`)).To(Equal([][]string{
+			{"a", "b"}, {"c"}, {"d"},
+		}))
+	})
+
+	It("omits lambda parameters", func() {
+		Expect(dependencies(`map[list|x|->x + offset]`)).To(Equal([][]string{
+			{"list"}, {"offset"},
+		}))
+		Expect(dependencies(`|a,b=dflt|->a + b.c + d`)).To(Equal([][]string{
+			{"dflt"}, {"d"},
+		}))
+	})
+
+	It("omits names bound by scopes", func() {
+		Expect(dependencies(`($a=base) a.x + b`)).To(Equal([][]string{
+			{"base"}, {"b"},
+		}))
+	})
+
+	It("reports references of marker expressions", func() {
+		Expect(dependencies(`&cached(a + b)`)).To(Equal([][]string{
+			{"a"}, {"b"},
+		}))
+	})
+
+	It("omits function names of pipes", func() {
+		Expect(dependencies(`list |> sort |> .f(x)`)).To(Equal([][]string{
+			{"list"}, {"", "f"}, {"x"},
+		}))
+	})
+
+	It("reports dynamic references", func() {
+		Expect(dependencies(`a.[b].c`)).To(Equal([][]string{
+			{"a", DynamicDependency}, {"b"},
+		}))
+	})
+
+	It("ignores references relative to other expressions", func() {
+		Expect(dependencies(`(a || b).c`)).To(Equal([][]string{
+			{"a"}, {"b"},
+		}))
+	})
+})
@@ -0,0 +1,96 @@
+package dynaml
+
+import (
+	"crypto/sha256"
+	"crypto/sha512"
+	"encoding/hex"
+	"hash"
+
+	"golang.org/x/crypto/pbkdf2"
+	"golang.org/x/crypto/scrypt"
+)
+
+const (
+	deriveKeyScryptCost       = 32768
+	deriveKeyScryptBlockSize  = 8
+	deriveKeyScryptParallel   = 1
+	deriveKeyPBKDF2Iterations = 600000
+	deriveKeyLen              = 32
+
+	deriveKeyMinSaltLen = 8
+	deriveKeyMinLen     = 16
+	deriveKeyMaxLen     = 1024
+)
+
+func init() {
+	RegisterFunction("derive_key", func_derive_key)
+}
+
+func func_derive_key(arguments []interface{}, binding Binding) (interface{}, EvaluationInfo, bool) {
+	info := DefaultInfo()
+
+	if len(arguments) < 2 || len(arguments) > 5 {
+		return info.Error("derive_key takes two to five arguments")
+	}
+
+	passphrase, ok := arguments[0].(string)
+	if !ok {
+		return info.Error("first argument for derive_key must be a string")
+	}
+	salt, ok := arguments[1].(string)
+	if !ok {
+		return info.Error("second argument for derive_key must be a string")
+	}
+	if len(salt) < deriveKeyMinSaltLen {
+		return info.Error("salt for derive_key must have at least %d bytes", deriveKeyMinSaltLen)
+	}
+
+	method := "scrypt"
+	if len(arguments) > 2 {
+		method, ok = arguments[2].(string)
+		if !ok {
+			return info.Error("method for derive_key must be a string")
+		}
+	}
+
+	var cost int64
+	switch method {
+	case "scrypt":
+		cost = deriveKeyScryptCost
+	case "pbkdf2", "pbkdf2-sha512":
+		cost = deriveKeyPBKDF2Iterations
+	default:
+		return info.Error("invalid derive_key method %q (use scrypt, pbkdf2 or pbkdf2-sha512)", method)
+	}
+	if len(arguments) > 3 {
+		cost, ok = arguments[3].(int64)
+		if !ok || cost < 1 {
+			return info.Error("cost for derive_key must be a positive integer")
+		}
+	}
+
+	length := int64(deriveKeyLen)
+	if len(arguments) > 4 {
+		length, ok = arguments[4].(int64)
+		if !ok || length < deriveKeyMinLen || length > deriveKeyMaxLen {
+			return info.Error("key length for derive_key must be an integer between %d and %d", deriveKeyMinLen, deriveKeyMaxLen)
+		}
+	}
+
+	var key []byte
+	switch method {
+	case "scrypt":
+		var err error
+		key, err = scrypt.Key([]byte(passphrase), []byte(salt), int(cost), deriveKeyScryptBlockSize, deriveKeyScryptParallel, int(length))
+		if err != nil {
+			return info.Error("derive_key: %s", err)
+		}
+	default:
+		var h func() hash.Hash = sha256.New
+		if method == "pbkdf2-sha512" {
+			h = sha512.New
+		}
+		key = pbkdf2.Key([]byte(passphrase), []byte(salt), int(cost), int(length), h)
+	}
+	return hex.EncodeToString(key), info, true
+}
@@ -82,9 +82,10 @@ func (e DynamicExpr) Evaluate(binding Binding, locally bool) (interface{}, Evalu
 		return info.Error("index or field name required for reference qualifier")
 	}
 
+	node := NewNode(root, nil)
 	t, info, ok := NewReferenceExpr(qual...).find(func(end int, path []string) (yaml.Node, bool) {
-		return yaml.Find(NewNode(root, nil), binding.GetFeatures(), path[:end+1]...)
-	}, binding, true)
+		return yaml.Find(node, binding.GetFeatures(), path[:end+1]...)
+	}, node, binding, true)
 
 	if !ok {
 		return nil, info, false
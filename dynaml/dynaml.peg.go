@@ -28,6 +28,8 @@ const (
 	ruleLevel7
 	ruleOr
 	ruleOrOp
+	rulePiped
+	rulePipe
 	ruleLevel6
 	ruleConditional
 	ruleLevel5
@@ -70,7 +72,10 @@ const (
 	ruleStartRange
 	ruleRangeOp
 	ruleNumber
+	ruleDigits
+	rulePrefixedInteger
 	ruleString
+	ruleRawString
 	ruleBoolean
 	ruleNil
 	ruleUndefined
@@ -114,6 +119,7 @@ const (
 	ruleTagComponent
 	ruleFollowUpRef
 	rulePathComponent
+	ruleSafeNavigation
 	ruleKey
 	ruleIndex
 	ruleIP
@@ -144,6 +150,8 @@ var rul3s = [...]string{
 	"Level7",
 	"Or",
 	"OrOp",
+	"Piped",
+	"Pipe",
 	"Level6",
 	"Conditional",
 	"Level5",
@@ -186,7 +194,10 @@ var rul3s = [...]string{
 	"StartRange",
 	"RangeOp",
 	"Number",
+	"Digits",
+	"PrefixedInteger",
 	"String",
+	"RawString",
 	"Boolean",
 	"Nil",
 	"Undefined",
@@ -230,6 +241,7 @@ var rul3s = [...]string{
 	"TagComponent",
 	"FollowUpRef",
 	"PathComponent",
+	"SafeNavigation",
 	"Key",
 	"Index",
 	"IP",
@@ -556,7 +568,7 @@ func (t *tokens32) Expand(index int) tokenTree {
 type DynamlGrammar struct {
 	Buffer string
 	buffer []rune
-	rules  [109]func() bool
+	rules  [115]func() bool
 	Parse  func(rule ...int) error
 	Reset  func()
 	Pretty bool
@@ -867,7 +879,7 @@ func (p *DynamlGrammar) Init() {
 			position, tokenIndex, depth = position14, tokenIndex14, depth14
 			return false
 		},
-		/* 4 Marker <- <('&' (('t' 'e' 'm' 'p' 'l' 'a' 't' 'e') / ('t' 'e' 'm' 'p' 'o' 'r' 'a' 'r' 'y') / ('l' 'o' 'c' 'a' 'l') / ('i' 'n' 'j' 'e' 'c' 't') / ('s' 't' 'a' 't' 'e') / ('d' 'e' 'f' 'a' 'u' 'l' 't') / ('d' 'y' 'n' 'a' 'm' 'i' 'c') / TagMarker))> */
+		/* 4 Marker <- <('&' (('t' 'e' 'm' 'p' 'l' 'a' 't' 'e') / ('t' 'e' 'm' 'p' 'o' 'r' 'a' 'r' 'y') / ('l' 'o' 'c' 'a' 'l') / ('i' 'n' 'j' 'e' 'c' 't') / ('s' 't' 'a' 't' 'e') / ('d' 'e' 'f' 'a' 'u' 'l' 't') / ('d' 'y' 'n' 'a' 'm' 'i' 'c') / ('c' 'a' 'c' 'h' 'e' 'd') / ('o' 'n' 'c' 'e') / TagMarker))> */
 		func() bool {
 			position16, tokenIndex16, depth16 := position, tokenIndex, depth
 			{
@@ -1087,6 +1099,52 @@ func (p *DynamlGrammar) Init() {
 					position++
 					goto l18
 				l25:
+					position, tokenIndex, depth = position18, tokenIndex18, depth18
+					if buffer[position] != rune('c') {
+						goto l26
+					}
+					position++
+					if buffer[position] != rune('a') {
+						goto l26
+					}
+					position++
+					if buffer[position] != rune('c') {
+						goto l26
+					}
+					position++
+					if buffer[position] != rune('h') {
+						goto l26
+					}
+					position++
+					if buffer[position] != rune('e') {
+						goto l26
+					}
+					position++
+					if buffer[position] != rune('d') {
+						goto l26
+					}
+					position++
+					goto l18
+				l26:
+					position, tokenIndex, depth = position18, tokenIndex18, depth18
+					if buffer[position] != rune('o') {
+						goto l27
+					}
+					position++
+					if buffer[position] != rune('n') {
+						goto l27
+					}
+					position++
+					if buffer[position] != rune('c') {
+						goto l27
+					}
+					position++
+					if buffer[position] != rune('e') {
+						goto l27
+					}
+					position++
+					goto l18
+				l27:
 					position, tokenIndex, depth = position18, tokenIndex18, depth18
 					if !_rules[ruleTagMarker]() {
 						goto l16
@@ -1103,4053 +1161,4681 @@ func (p *DynamlGrammar) Init() {
 		},
 		/* 5 TagMarker <- <('t' 'a' 'g' ':' '*'? Tag)> */
 		func() bool {
-			position26, tokenIndex26, depth26 := position, tokenIndex, depth
+			position28, tokenIndex28, depth28 := position, tokenIndex, depth
 			{
-				position27 := position
+				position29 := position
 				depth++
 				if buffer[position] != rune('t') {
-					goto l26
+					goto l28
 				}
 				position++
 				if buffer[position] != rune('a') {
-					goto l26
+					goto l28
 				}
 				position++
 				if buffer[position] != rune('g') {
-					goto l26
+					goto l28
 				}
 				position++
 				if buffer[position] != rune(':') {
-					goto l26
+					goto l28
 				}
 				position++
 				{
-					position28, tokenIndex28, depth28 := position, tokenIndex, depth
+					position30, tokenIndex30, depth30 := position, tokenIndex, depth
 					if buffer[position] != rune('*') {
-						goto l28
+						goto l30
 					}
 					position++
-					goto l29
-				l28:
-					position, tokenIndex, depth = position28, tokenIndex28, depth28
+					goto l31
+				l30:
+					position, tokenIndex, depth = position30, tokenIndex30, depth30
 				}
-			l29:
+			l31:
 				if !_rules[ruleTag]() {
-					goto l26
+					goto l28
 				}
 				depth--
-				add(ruleTagMarker, position27)
+				add(ruleTagMarker, position29)
 			}
 			return true
-		l26:
-			position, tokenIndex, depth = position26, tokenIndex26, depth26
+		l28:
+			position, tokenIndex, depth = position28, tokenIndex28, depth28
 			return false
 		},
 		/* 6 MarkerExpression <- <Grouped> */
 		func() bool {
-			position30, tokenIndex30, depth30 := position, tokenIndex, depth
+			position32, tokenIndex32, depth32 := position, tokenIndex, depth
 			{
-				position31 := position
+				position33 := position
 				depth++
 				if !_rules[ruleGrouped]() {
-					goto l30
+					goto l32
 				}
 				depth--
-				add(ruleMarkerExpression, position31)
+				add(ruleMarkerExpression, position33)
 			}
 			return true
-		l30:
-			position, tokenIndex, depth = position30, tokenIndex30, depth30
+		l32:
+			position, tokenIndex, depth = position32, tokenIndex32, depth32
 			return false
 		},
 		/* 7 Expression <- <((Scoped / LambdaExpr / Level7) ws)> */
 		func() bool {
-			position32, tokenIndex32, depth32 := position, tokenIndex, depth
+			position34, tokenIndex34, depth34 := position, tokenIndex, depth
 			{
-				position33 := position
+				position35 := position
 				depth++
 				{
-					position34, tokenIndex34, depth34 := position, tokenIndex, depth
+					position36, tokenIndex36, depth36 := position, tokenIndex, depth
 					if !_rules[ruleScoped]() {
-						goto l35
+						goto l37
 					}
-					goto l34
-				l35:
-					position, tokenIndex, depth = position34, tokenIndex34, depth34
+					goto l36
+				l37:
+					position, tokenIndex, depth = position36, tokenIndex36, depth36
 					if !_rules[ruleLambdaExpr]() {
-						goto l36
+						goto l38
 					}
-					goto l34
-				l36:
-					position, tokenIndex, depth = position34, tokenIndex34, depth34
+					goto l36
+				l38:
+					position, tokenIndex, depth = position36, tokenIndex36, depth36
 					if !_rules[ruleLevel7]() {
-						goto l32
+						goto l34
 					}
 				}
-			l34:
+			l36:
 				if !_rules[rulews]() {
-					goto l32
+					goto l34
 				}
 				depth--
-				add(ruleExpression, position33)
+				add(ruleExpression, position35)
 			}
 			return true
-		l32:
-			position, tokenIndex, depth = position32, tokenIndex32, depth32
+		l34:
+			position, tokenIndex, depth = position34, tokenIndex34, depth34
 			return false
 		},
 		/* 8 Scoped <- <(ws Scope ws Expression)> */
 		func() bool {
-			position37, tokenIndex37, depth37 := position, tokenIndex, depth
+			position39, tokenIndex39, depth39 := position, tokenIndex, depth
 			{
-				position38 := position
+				position40 := position
 				depth++
 				if !_rules[rulews]() {
-					goto l37
+					goto l39
 				}
 				if !_rules[ruleScope]() {
-					goto l37
+					goto l39
 				}
 				if !_rules[rulews]() {
-					goto l37
+					goto l39
 				}
 				if !_rules[ruleExpression]() {
-					goto l37
+					goto l39
 				}
 				depth--
-				add(ruleScoped, position38)
+				add(ruleScoped, position40)
 			}
 			return true
-		l37:
-			position, tokenIndex, depth = position37, tokenIndex37, depth37
+		l39:
+			position, tokenIndex, depth = position39, tokenIndex39, depth39
 			return false
 		},
 		/* 9 Scope <- <(CreateScope ws Assignments? ')')> */
 		func() bool {
-			position39, tokenIndex39, depth39 := position, tokenIndex, depth
+			position41, tokenIndex41, depth41 := position, tokenIndex, depth
 			{
-				position40 := position
+				position42 := position
 				depth++
 				if !_rules[ruleCreateScope]() {
-					goto l39
+					goto l41
 				}
 				if !_rules[rulews]() {
-					goto l39
+					goto l41
 				}
 				{
-					position41, tokenIndex41, depth41 := position, tokenIndex, depth
+					position43, tokenIndex43, depth43 := position, tokenIndex, depth
 					if !_rules[ruleAssignments]() {
-						goto l41
+						goto l43
 					}
-					goto l42
-				l41:
-					position, tokenIndex, depth = position41, tokenIndex41, depth41
+					goto l44
+				l43:
+					position, tokenIndex, depth = position43, tokenIndex43, depth43
 				}
-			l42:
+			l44:
 				if buffer[position] != rune(')') {
-					goto l39
+					goto l41
 				}
 				position++
 				depth--
-				add(ruleScope, position40)
+				add(ruleScope, position42)
 			}
 			return true
-		l39:
-			position, tokenIndex, depth = position39, tokenIndex39, depth39
+		l41:
+			position, tokenIndex, depth = position41, tokenIndex41, depth41
 			return false
 		},
 		/* 10 CreateScope <- <'('> */
 		func() bool {
-			position43, tokenIndex43, depth43 := position, tokenIndex, depth
+			position45, tokenIndex45, depth45 := position, tokenIndex, depth
 			{
-				position44 := position
+				position46 := position
 				depth++
 				if buffer[position] != rune('(') {
-					goto l43
+					goto l45
 				}
 				position++
 				depth--
-				add(ruleCreateScope, position44)
+				add(ruleCreateScope, position46)
 			}
 			return true
-		l43:
-			position, tokenIndex, depth = position43, tokenIndex43, depth43
+		l45:
+			position, tokenIndex, depth = position45, tokenIndex45, depth45
 			return false
 		},
-		/* 11 Level7 <- <(ws Level6 (req_ws Or)*)> */
+		/* 11 Level7 <- <(ws Piped (req_ws Or)*)> */
 		func() bool {
-			position45, tokenIndex45, depth45 := position, tokenIndex, depth
+			position47, tokenIndex47, depth47 := position, tokenIndex, depth
 			{
-				position46 := position
+				position48 := position
 				depth++
 				if !_rules[rulews]() {
-					goto l45
+					goto l47
 				}
-				if !_rules[ruleLevel6]() {
-					goto l45
+				if !_rules[rulePiped]() {
+					goto l47
 				}
-			l47:
+			l49:
 				{
-					position48, tokenIndex48, depth48 := position, tokenIndex, depth
+					position50, tokenIndex50, depth50 := position, tokenIndex, depth
 					if !_rules[rulereq_ws]() {
-						goto l48
+						goto l50
 					}
 					if !_rules[ruleOr]() {
-						goto l48
+						goto l50
 					}
-					goto l47
-				l48:
-					position, tokenIndex, depth = position48, tokenIndex48, depth48
+					goto l49
+				l50:
+					position, tokenIndex, depth = position50, tokenIndex50, depth50
 				}
 				depth--
-				add(ruleLevel7, position46)
+				add(ruleLevel7, position48)
 			}
 			return true
-		l45:
-			position, tokenIndex, depth = position45, tokenIndex45, depth45
+		l47:
+			position, tokenIndex, depth = position47, tokenIndex47, depth47
 			return false
 		},
-		/* 12 Or <- <(OrOp req_ws Level6)> */
+		/* 12 Or <- <(OrOp req_ws Piped)> */
 		func() bool {
-			position49, tokenIndex49, depth49 := position, tokenIndex, depth
+			position51, tokenIndex51, depth51 := position, tokenIndex, depth
 			{
-				position50 := position
+				position52 := position
 				depth++
 				if !_rules[ruleOrOp]() {
-					goto l49
+					goto l51
 				}
 				if !_rules[rulereq_ws]() {
-					goto l49
+					goto l51
 				}
-				if !_rules[ruleLevel6]() {
-					goto l49
+				if !_rules[rulePiped]() {
+					goto l51
 				}
 				depth--
-				add(ruleOr, position50)
+				add(ruleOr, position52)
 			}
 			return true
-		l49:
-			position, tokenIndex, depth = position49, tokenIndex49, depth49
+		l51:
+			position, tokenIndex, depth = position51, tokenIndex51, depth51
 			return false
 		},
 		/* 13 OrOp <- <(('|' '|') / ('/' '/'))> */
 		func() bool {
-			position51, tokenIndex51, depth51 := position, tokenIndex, depth
+			position53, tokenIndex53, depth53 := position, tokenIndex, depth
 			{
-				position52 := position
+				position54 := position
 				depth++
 				{
-					position53, tokenIndex53, depth53 := position, tokenIndex, depth
+					position55, tokenIndex55, depth55 := position, tokenIndex, depth
 					if buffer[position] != rune('|') {
-						goto l54
+						goto l56
 					}
 					position++
 					if buffer[position] != rune('|') {
-						goto l54
+						goto l56
 					}
 					position++
-					goto l53
-				l54:
-					position, tokenIndex, depth = position53, tokenIndex53, depth53
+					goto l55
+				l56:
+					position, tokenIndex, depth = position55, tokenIndex55, depth55
 					if buffer[position] != rune('/') {
-						goto l51
+						goto l53
 					}
 					position++
 					if buffer[position] != rune('/') {
-						goto l51
+						goto l53
 					}
 					position++
 				}
-			l53:
+			l55:
 				depth--
-				add(ruleOrOp, position52)
+				add(ruleOrOp, position54)
 			}
 			return true
-		l51:
-			position, tokenIndex, depth = position51, tokenIndex51, depth51
+		l53:
+			position, tokenIndex, depth = position53, tokenIndex53, depth53
 			return false
 		},
-		/* 14 Level6 <- <(Conditional / Level5)> */
+		/* 14 Piped <- <(Level6 (req_ws Pipe)*)> */
 		func() bool {
-			position55, tokenIndex55, depth55 := position, tokenIndex, depth
+			position57, tokenIndex57, depth57 := position, tokenIndex, depth
 			{
-				position56 := position
+				position58 := position
 				depth++
+				if !_rules[ruleLevel6]() {
+					goto l57
+				}
+			l59:
 				{
-					position57, tokenIndex57, depth57 := position, tokenIndex, depth
-					if !_rules[ruleConditional]() {
-						goto l58
+					position60, tokenIndex60, depth60 := position, tokenIndex, depth
+					if !_rules[rulereq_ws]() {
+						goto l60
 					}
-					goto l57
-				l58:
-					position, tokenIndex, depth = position57, tokenIndex57, depth57
-					if !_rules[ruleLevel5]() {
-						goto l55
+					if !_rules[rulePipe]() {
+						goto l60
 					}
+					goto l59
+				l60:
+					position, tokenIndex, depth = position60, tokenIndex60, depth60
 				}
-			l57:
 				depth--
-				add(ruleLevel6, position56)
+				add(rulePiped, position58)
 			}
 			return true
-		l55:
-			position, tokenIndex, depth = position55, tokenIndex55, depth55
+		l57:
+			position, tokenIndex, depth = position57, tokenIndex57, depth57
 			return false
 		},
-		/* 15 Conditional <- <(Level5 ws '?' Expression ':' Expression)> */
+		/* 15 Pipe <- <('|' '>' req_ws Level6)> */
 		func() bool {
-			position59, tokenIndex59, depth59 := position, tokenIndex, depth
+			position61, tokenIndex61, depth61 := position, tokenIndex, depth
+			{
+				position62 := position
+				depth++
+				if buffer[position] != rune('|') {
+					goto l61
+				}
+				position++
+				if buffer[position] != rune('>') {
+					goto l61
+				}
+				position++
+				if !_rules[rulereq_ws]() {
+					goto l61
+				}
+				if !_rules[ruleLevel6]() {
+					goto l61
+				}
+				depth--
+				add(rulePipe, position62)
+			}
+			return true
+		l61:
+			position, tokenIndex, depth = position61, tokenIndex61, depth61
+			return false
+		},
+		/* 16 Level6 <- <(Level5 Conditional?)> */
+		func() bool {
+			position63, tokenIndex63, depth63 := position, tokenIndex, depth
 			{
-				position60 := position
+				position64 := position
 				depth++
 				if !_rules[ruleLevel5]() {
-					goto l59
+					goto l63
+				}
+				{
+					position65, tokenIndex65, depth65 := position, tokenIndex, depth
+					if !_rules[ruleConditional]() {
+						goto l65
+					}
+					goto l66
+				l65:
+					position, tokenIndex, depth = position65, tokenIndex65, depth65
 				}
+			l66:
+				depth--
+				add(ruleLevel6, position64)
+			}
+			return true
+		l63:
+			position, tokenIndex, depth = position63, tokenIndex63, depth63
+			return false
+		},
+		/* 17 Conditional <- <(ws '?' Expression ':' Expression)> */
+		func() bool {
+			position67, tokenIndex67, depth67 := position, tokenIndex, depth
+			{
+				position68 := position
+				depth++
 				if !_rules[rulews]() {
-					goto l59
+					goto l67
 				}
 				if buffer[position] != rune('?') {
-					goto l59
+					goto l67
 				}
 				position++
 				if !_rules[ruleExpression]() {
-					goto l59
+					goto l67
 				}
 				if buffer[position] != rune(':') {
-					goto l59
+					goto l67
 				}
 				position++
 				if !_rules[ruleExpression]() {
-					goto l59
+					goto l67
 				}
 				depth--
-				add(ruleConditional, position60)
+				add(ruleConditional, position68)
 			}
 			return true
-		l59:
-			position, tokenIndex, depth = position59, tokenIndex59, depth59
+		l67:
+			position, tokenIndex, depth = position67, tokenIndex67, depth67
 			return false
 		},
-		/* 16 Level5 <- <(Level4 Concatenation*)> */
+		/* 18 Level5 <- <(Level4 Concatenation*)> */
 		func() bool {
-			position61, tokenIndex61, depth61 := position, tokenIndex, depth
+			position69, tokenIndex69, depth69 := position, tokenIndex, depth
 			{
-				position62 := position
+				position70 := position
 				depth++
 				if !_rules[ruleLevel4]() {
-					goto l61
+					goto l69
 				}
-			l63:
+			l71:
 				{
-					position64, tokenIndex64, depth64 := position, tokenIndex, depth
+					position72, tokenIndex72, depth72 := position, tokenIndex, depth
 					if !_rules[ruleConcatenation]() {
-						goto l64
+						goto l72
 					}
-					goto l63
-				l64:
-					position, tokenIndex, depth = position64, tokenIndex64, depth64
+					goto l71
+				l72:
+					position, tokenIndex, depth = position72, tokenIndex72, depth72
 				}
 				depth--
-				add(ruleLevel5, position62)
+				add(ruleLevel5, position70)
 			}
 			return true
-		l61:
-			position, tokenIndex, depth = position61, tokenIndex61, depth61
+		l69:
+			position, tokenIndex, depth = position69, tokenIndex69, depth69
 			return false
 		},
-		/* 17 Concatenation <- <(req_ws Level4)> */
+		/* 19 Concatenation <- <(req_ws Level4)> */
 		func() bool {
-			position65, tokenIndex65, depth65 := position, tokenIndex, depth
+			position73, tokenIndex73, depth73 := position, tokenIndex, depth
 			{
-				position66 := position
+				position74 := position
 				depth++
 				if !_rules[rulereq_ws]() {
-					goto l65
+					goto l73
 				}
 				if !_rules[ruleLevel4]() {
-					goto l65
+					goto l73
 				}
 				depth--
-				add(ruleConcatenation, position66)
+				add(ruleConcatenation, position74)
 			}
 			return true
-		l65:
-			position, tokenIndex, depth = position65, tokenIndex65, depth65
+		l73:
+			position, tokenIndex, depth = position73, tokenIndex73, depth73
 			return false
 		},
-		/* 18 Level4 <- <(Level3 (req_ws (LogOr / LogAnd))*)> */
+		/* 20 Level4 <- <(Level3 (req_ws (LogOr / LogAnd))*)> */
 		func() bool {
-			position67, tokenIndex67, depth67 := position, tokenIndex, depth
+			position75, tokenIndex75, depth75 := position, tokenIndex, depth
 			{
-				position68 := position
+				position76 := position
 				depth++
 				if !_rules[ruleLevel3]() {
-					goto l67
+					goto l75
 				}
-			l69:
+			l77:
 				{
-					position70, tokenIndex70, depth70 := position, tokenIndex, depth
+					position78, tokenIndex78, depth78 := position, tokenIndex, depth
 					if !_rules[rulereq_ws]() {
-						goto l70
+						goto l78
 					}
 					{
-						position71, tokenIndex71, depth71 := position, tokenIndex, depth
+						position79, tokenIndex79, depth79 := position, tokenIndex, depth
 						if !_rules[ruleLogOr]() {
-							goto l72
+							goto l80
 						}
-						goto l71
-					l72:
-						position, tokenIndex, depth = position71, tokenIndex71, depth71
+						goto l79
+					l80:
+						position, tokenIndex, depth = position79, tokenIndex79, depth79
 						if !_rules[ruleLogAnd]() {
-							goto l70
+							goto l78
 						}
 					}
-				l71:
-					goto l69
-				l70:
-					position, tokenIndex, depth = position70, tokenIndex70, depth70
+				l79:
+					goto l77
+				l78:
+					position, tokenIndex, depth = position78, tokenIndex78, depth78
 				}
 				depth--
-				add(ruleLevel4, position68)
+				add(ruleLevel4, position76)
 			}
 			return true
-		l67:
-			position, tokenIndex, depth = position67, tokenIndex67, depth67
+		l75:
+			position, tokenIndex, depth = position75, tokenIndex75, depth75
 			return false
 		},
-		/* 19 LogOr <- <('-' 'o' 'r' req_ws Level3)> */
+		/* 21 LogOr <- <('-' 'o' 'r' req_ws Level3)> */
 		func() bool {
-			position73, tokenIndex73, depth73 := position, tokenIndex, depth
+			position81, tokenIndex81, depth81 := position, tokenIndex, depth
 			{
-				position74 := position
+				position82 := position
 				depth++
 				if buffer[position] != rune('-') {
-					goto l73
+					goto l81
 				}
 				position++
 				if buffer[position] != rune('o') {
-					goto l73
+					goto l81
 				}
 				position++
 				if buffer[position] != rune('r') {
-					goto l73
+					goto l81
 				}
 				position++
 				if !_rules[rulereq_ws]() {
-					goto l73
+					goto l81
 				}
 				if !_rules[ruleLevel3]() {
-					goto l73
+					goto l81
 				}
 				depth--
-				add(ruleLogOr, position74)
+				add(ruleLogOr, position82)
 			}
 			return true
-		l73:
-			position, tokenIndex, depth = position73, tokenIndex73, depth73
+		l81:
+			position, tokenIndex, depth = position81, tokenIndex81, depth81
 			return false
 		},
-		/* 20 LogAnd <- <('-' 'a' 'n' 'd' req_ws Level3)> */
+		/* 22 LogAnd <- <('-' 'a' 'n' 'd' req_ws Level3)> */
 		func() bool {
-			position75, tokenIndex75, depth75 := position, tokenIndex, depth
+			position83, tokenIndex83, depth83 := position, tokenIndex, depth
 			{
-				position76 := position
+				position84 := position
 				depth++
 				if buffer[position] != rune('-') {
-					goto l75
+					goto l83
 				}
 				position++
 				if buffer[position] != rune('a') {
-					goto l75
+					goto l83
 				}
 				position++
 				if buffer[position] != rune('n') {
-					goto l75
+					goto l83
 				}
 				position++
 				if buffer[position] != rune('d') {
-					goto l75
+					goto l83
 				}
 				position++
 				if !_rules[rulereq_ws]() {
-					goto l75
+					goto l83
 				}
 				if !_rules[ruleLevel3]() {
-					goto l75
+					goto l83
 				}
 				depth--
-				add(ruleLogAnd, position76)
+				add(ruleLogAnd, position84)
 			}
 			return true
-		l75:
-			position, tokenIndex, depth = position75, tokenIndex75, depth75
+		l83:
+			position, tokenIndex, depth = position83, tokenIndex83, depth83
 			return false
 		},
-		/* 21 Level3 <- <(Level2 (req_ws Comparison)*)> */
+		/* 23 Level3 <- <(Level2 (req_ws Comparison)*)> */
 		func() bool {
-			position77, tokenIndex77, depth77 := position, tokenIndex, depth
+			position85, tokenIndex85, depth85 := position, tokenIndex, depth
 			{
-				position78 := position
+				position86 := position
 				depth++
 				if !_rules[ruleLevel2]() {
-					goto l77
+					goto l85
 				}
-			l79:
+			l87:
 				{
-					position80, tokenIndex80, depth80 := position, tokenIndex, depth
+					position88, tokenIndex88, depth88 := position, tokenIndex, depth
 					if !_rules[rulereq_ws]() {
-						goto l80
+						goto l88
 					}
 					if !_rules[ruleComparison]() {
-						goto l80
+						goto l88
 					}
-					goto l79
-				l80:
-					position, tokenIndex, depth = position80, tokenIndex80, depth80
+					goto l87
+				l88:
+					position, tokenIndex, depth = position88, tokenIndex88, depth88
 				}
 				depth--
-				add(ruleLevel3, position78)
+				add(ruleLevel3, position86)
 			}
 			return true
-		l77:
-			position, tokenIndex, depth = position77, tokenIndex77, depth77
+		l85:
+			position, tokenIndex, depth = position85, tokenIndex85, depth85
 			return false
 		},
-		/* 22 Comparison <- <(CompareOp req_ws Level2)> */
+		/* 24 Comparison <- <(CompareOp req_ws Level2)> */
 		func() bool {
-			position81, tokenIndex81, depth81 := position, tokenIndex, depth
+			position89, tokenIndex89, depth89 := position, tokenIndex, depth
 			{
-				position82 := position
+				position90 := position
 				depth++
 				if !_rules[ruleCompareOp]() {
-					goto l81
+					goto l89
 				}
 				if !_rules[rulereq_ws]() {
-					goto l81
+					goto l89
 				}
 				if !_rules[ruleLevel2]() {
-					goto l81
+					goto l89
 				}
 				depth--
-				add(ruleComparison, position82)
+				add(ruleComparison, position90)
 			}
 			return true
-		l81:
-			position, tokenIndex, depth = position81, tokenIndex81, depth81
+		l89:
+			position, tokenIndex, depth = position89, tokenIndex89, depth89
 			return false
 		},
-		/* 23 CompareOp <- <(('=' '=') / ('!' '=') / ('<' '=') / ('>' '=') / '>' / '<' / '>')> */
+		/* 25 CompareOp <- <(('=' '=') / ('!' '=') / ('<' '=') / ('>' '=') / '>' / '<' / '>' / ('i' 'n'))> */
 		func() bool {
-			position83, tokenIndex83, depth83 := position, tokenIndex, depth
+			position91, tokenIndex91, depth91 := position, tokenIndex, depth
 			{
-				position84 := position
+				position92 := position
 				depth++
 				{
-					position85, tokenIndex85, depth85 := position, tokenIndex, depth
+					position93, tokenIndex93, depth93 := position, tokenIndex, depth
 					if buffer[position] != rune('=') {
-						goto l86
+						goto l94
 					}
 					position++
 					if buffer[position] != rune('=') {
-						goto l86
+						goto l94
 					}
 					position++
-					goto l85
-				l86:
-					position, tokenIndex, depth = position85, tokenIndex85, depth85
+					goto l93
+				l94:
+					position, tokenIndex, depth = position93, tokenIndex93, depth93
 					if buffer[position] != rune('!') {
-						goto l87
+						goto l95
 					}
 					position++
 					if buffer[position] != rune('=') {
-						goto l87
+						goto l95
 					}
 					position++
-					goto l85
-				l87:
-					position, tokenIndex, depth = position85, tokenIndex85, depth85
+					goto l93
+				l95:
+					position, tokenIndex, depth = position93, tokenIndex93, depth93
 					if buffer[position] != rune('<') {
-						goto l88
+						goto l96
 					}
 					position++
 					if buffer[position] != rune('=') {
-						goto l88
+						goto l96
 					}
 					position++
-					goto l85
-				l88:
-					position, tokenIndex, depth = position85, tokenIndex85, depth85
+					goto l93
+				l96:
+					position, tokenIndex, depth = position93, tokenIndex93, depth93
 					if buffer[position] != rune('>') {
-						goto l89
+						goto l97
 					}
 					position++
 					if buffer[position] != rune('=') {
-						goto l89
+						goto l97
 					}
 					position++
-					goto l85
-				l89:
-					position, tokenIndex, depth = position85, tokenIndex85, depth85
+					goto l93
+				l97:
+					position, tokenIndex, depth = position93, tokenIndex93, depth93
 					if buffer[position] != rune('>') {
-						goto l90
+						goto l98
 					}
 					position++
-					goto l85
-				l90:
-					position, tokenIndex, depth = position85, tokenIndex85, depth85
+					goto l93
+				l98:
+					position, tokenIndex, depth = position93, tokenIndex93, depth93
 					if buffer[position] != rune('<') {
-						goto l91
+						goto l99
 					}
 					position++
-					goto l85
-				l91:
-					position, tokenIndex, depth = position85, tokenIndex85, depth85
+					goto l93
+				l99:
+					position, tokenIndex, depth = position93, tokenIndex93, depth93
 					if buffer[position] != rune('>') {
-						goto l83
+						goto l100
+					}
+					position++
+					goto l93
+				l100:
+					position, tokenIndex, depth = position93, tokenIndex93, depth93
+					if buffer[position] != rune('i') {
+						goto l91
+					}
+					position++
+					if buffer[position] != rune('n') {
+						goto l91
 					}
 					position++
 				}
-			l85:
+			l93:
 				depth--
-				add(ruleCompareOp, position84)
+				add(ruleCompareOp, position92)
 			}
 			return true
-		l83:
-			position, tokenIndex, depth = position83, tokenIndex83, depth83
+		l91:
+			position, tokenIndex, depth = position91, tokenIndex91, depth91
 			return false
 		},
-		/* 24 Level2 <- <(Level1 (req_ws (Addition / Subtraction))*)> */
+		/* 26 Level2 <- <(Level1 (req_ws (Addition / Subtraction))*)> */
 		func() bool {
-			position92, tokenIndex92, depth92 := position, tokenIndex, depth
+			position101, tokenIndex101, depth101 := position, tokenIndex, depth
 			{
-				position93 := position
+				position102 := position
 				depth++
 				if !_rules[ruleLevel1]() {
-					goto l92
+					goto l101
 				}
-			l94:
+			l103:
 				{
-					position95, tokenIndex95, depth95 := position, tokenIndex, depth
+					position104, tokenIndex104, depth104 := position, tokenIndex, depth
 					if !_rules[rulereq_ws]() {
-						goto l95
+						goto l104
 					}
 					{
-						position96, tokenIndex96, depth96 := position, tokenIndex, depth
+						position105, tokenIndex105, depth105 := position, tokenIndex, depth
 						if !_rules[ruleAddition]() {
-							goto l97
+							goto l106
 						}
-						goto l96
-					l97:
-						position, tokenIndex, depth = position96, tokenIndex96, depth96
+						goto l105
+					l106:
+						position, tokenIndex, depth = position105, tokenIndex105, depth105
 						if !_rules[ruleSubtraction]() {
-							goto l95
+							goto l104
 						}
 					}
-				l96:
-					goto l94
-				l95:
-					position, tokenIndex, depth = position95, tokenIndex95, depth95
+				l105:
+					goto l103
+				l104:
+					position, tokenIndex, depth = position104, tokenIndex104, depth104
 				}
 				depth--
-				add(ruleLevel2, position93)
+				add(ruleLevel2, position102)
 			}
 			return true
-		l92:
-			position, tokenIndex, depth = position92, tokenIndex92, depth92
+		l101:
+			position, tokenIndex, depth = position101, tokenIndex101, depth101
 			return false
 		},
-		/* 25 Addition <- <('+' req_ws Level1)> */
+		/* 27 Addition <- <('+' req_ws Level1)> */
 		func() bool {
-			position98, tokenIndex98, depth98 := position, tokenIndex, depth
+			position107, tokenIndex107, depth107 := position, tokenIndex, depth
 			{
-				position99 := position
+				position108 := position
 				depth++
 				if buffer[position] != rune('+') {
-					goto l98
+					goto l107
 				}
 				position++
 				if !_rules[rulereq_ws]() {
-					goto l98
+					goto l107
 				}
 				if !_rules[ruleLevel1]() {
-					goto l98
+					goto l107
 				}
 				depth--
-				add(ruleAddition, position99)
+				add(ruleAddition, position108)
 			}
 			return true
-		l98:
-			position, tokenIndex, depth = position98, tokenIndex98, depth98
+		l107:
+			position, tokenIndex, depth = position107, tokenIndex107, depth107
 			return false
 		},
-		/* 26 Subtraction <- <('-' req_ws Level1)> */
+		/* 28 Subtraction <- <('-' req_ws Level1)> */
 		func() bool {
-			position100, tokenIndex100, depth100 := position, tokenIndex, depth
+			position109, tokenIndex109, depth109 := position, tokenIndex, depth
 			{
-				position101 := position
+				position110 := position
 				depth++
 				if buffer[position] != rune('-') {
-					goto l100
+					goto l109
 				}
 				position++
 				if !_rules[rulereq_ws]() {
-					goto l100
+					goto l109
 				}
 				if !_rules[ruleLevel1]() {
-					goto l100
+					goto l109
 				}
 				depth--
-				add(ruleSubtraction, position101)
+				add(ruleSubtraction, position110)
 			}
 			return true
-		l100:
-			position, tokenIndex, depth = position100, tokenIndex100, depth100
+		l109:
+			position, tokenIndex, depth = position109, tokenIndex109, depth109
 			return false
 		},
-		/* 27 Level1 <- <(Level0 (req_ws (Multiplication / Division / Modulo))*)> */
+		/* 29 Level1 <- <(Level0 (req_ws (Multiplication / Division / Modulo))*)> */
 		func() bool {
-			position102, tokenIndex102, depth102 := position, tokenIndex, depth
+			position111, tokenIndex111, depth111 := position, tokenIndex, depth
 			{
-				position103 := position
+				position112 := position
 				depth++
 				if !_rules[ruleLevel0]() {
-					goto l102
+					goto l111
 				}
-			l104:
+			l113:
 				{
-					position105, tokenIndex105, depth105 := position, tokenIndex, depth
+					position114, tokenIndex114, depth114 := position, tokenIndex, depth
 					if !_rules[rulereq_ws]() {
-						goto l105
+						goto l114
 					}
 					{
-						position106, tokenIndex106, depth106 := position, tokenIndex, depth
+						position115, tokenIndex115, depth115 := position, tokenIndex, depth
 						if !_rules[ruleMultiplication]() {
-							goto l107
+							goto l116
 						}
-						goto l106
-					l107:
-						position, tokenIndex, depth = position106, tokenIndex106, depth106
+						goto l115
+					l116:
+						position, tokenIndex, depth = position115, tokenIndex115, depth115
 						if !_rules[ruleDivision]() {
-							goto l108
+							goto l117
 						}
-						goto l106
-					l108:
-						position, tokenIndex, depth = position106, tokenIndex106, depth106
+						goto l115
+					l117:
+						position, tokenIndex, depth = position115, tokenIndex115, depth115
 						if !_rules[ruleModulo]() {
-							goto l105
+							goto l114
 						}
 					}
-				l106:
-					goto l104
-				l105:
-					position, tokenIndex, depth = position105, tokenIndex105, depth105
+				l115:
+					goto l113
+				l114:
+					position, tokenIndex, depth = position114, tokenIndex114, depth114
 				}
 				depth--
-				add(ruleLevel1, position103)
+				add(ruleLevel1, position112)
 			}
 			return true
-		l102:
-			position, tokenIndex, depth = position102, tokenIndex102, depth102
+		l111:
+			position, tokenIndex, depth = position111, tokenIndex111, depth111
 			return false
 		},
-		/* 28 Multiplication <- <('*' req_ws Level0)> */
+		/* 30 Multiplication <- <('*' req_ws Level0)> */
 		func() bool {
-			position109, tokenIndex109, depth109 := position, tokenIndex, depth
+			position118, tokenIndex118, depth118 := position, tokenIndex, depth
 			{
-				position110 := position
+				position119 := position
 				depth++
 				if buffer[position] != rune('*') {
-					goto l109
+					goto l118
 				}
 				position++
 				if !_rules[rulereq_ws]() {
-					goto l109
+					goto l118
 				}
 				if !_rules[ruleLevel0]() {
-					goto l109
+					goto l118
 				}
 				depth--
-				add(ruleMultiplication, position110)
+				add(ruleMultiplication, position119)
 			}
 			return true
-		l109:
-			position, tokenIndex, depth = position109, tokenIndex109, depth109
+		l118:
+			position, tokenIndex, depth = position118, tokenIndex118, depth118
 			return false
 		},
-		/* 29 Division <- <('/' req_ws Level0)> */
+		/* 31 Division <- <('/' req_ws Level0)> */
 		func() bool {
-			position111, tokenIndex111, depth111 := position, tokenIndex, depth
+			position120, tokenIndex120, depth120 := position, tokenIndex, depth
 			{
-				position112 := position
+				position121 := position
 				depth++
 				if buffer[position] != rune('/') {
-					goto l111
+					goto l120
 				}
 				position++
 				if !_rules[rulereq_ws]() {
-					goto l111
+					goto l120
 				}
 				if !_rules[ruleLevel0]() {
-					goto l111
+					goto l120
 				}
 				depth--
-				add(ruleDivision, position112)
+				add(ruleDivision, position121)
 			}
 			return true
-		l111:
-			position, tokenIndex, depth = position111, tokenIndex111, depth111
+		l120:
+			position, tokenIndex, depth = position120, tokenIndex120, depth120
 			return false
 		},
-		/* 30 Modulo <- <('%' req_ws Level0)> */
+		/* 32 Modulo <- <('%' req_ws Level0)> */
 		func() bool {
-			position113, tokenIndex113, depth113 := position, tokenIndex, depth
+			position122, tokenIndex122, depth122 := position, tokenIndex, depth
 			{
-				position114 := position
+				position123 := position
 				depth++
 				if buffer[position] != rune('%') {
-					goto l113
+					goto l122
 				}
 				position++
 				if !_rules[rulereq_ws]() {
-					goto l113
+					goto l122
 				}
 				if !_rules[ruleLevel0]() {
-					goto l113
+					goto l122
 				}
 				depth--
-				add(ruleModulo, position114)
+				add(ruleModulo, position123)
 			}
 			return true
-		l113:
-			position, tokenIndex, depth = position113, tokenIndex113, depth113
+		l122:
+			position, tokenIndex, depth = position122, tokenIndex122, depth122
 			return false
 		},
-		/* 31 Level0 <- <(IP / String / Number / Boolean / Undefined / Nil / Symbol / Not / Substitution / Merge / Auto / Lambda / Chained)> */
+		/* 33 Level0 <- <(IP / String / RawString / Number / Boolean / Undefined / Nil / Symbol / Not / Substitution / Merge / Auto / Lambda / Chained)> */
 		func() bool {
-			position115, tokenIndex115, depth115 := position, tokenIndex, depth
+			position124, tokenIndex124, depth124 := position, tokenIndex, depth
 			{
-				position116 := position
+				position125 := position
 				depth++
 				{
-					position117, tokenIndex117, depth117 := position, tokenIndex, depth
+					position126, tokenIndex126, depth126 := position, tokenIndex, depth
 					if !_rules[ruleIP]() {
-						goto l118
+						goto l127
 					}
-					goto l117
-				l118:
-					position, tokenIndex, depth = position117, tokenIndex117, depth117
+					goto l126
+				l127:
+					position, tokenIndex, depth = position126, tokenIndex126, depth126
 					if !_rules[ruleString]() {
-						goto l119
+						goto l128
+					}
+					goto l126
+				l128:
+					position, tokenIndex, depth = position126, tokenIndex126, depth126
+					if !_rules[ruleRawString]() {
+						goto l129
 					}
-					goto l117
-				l119:
-					position, tokenIndex, depth = position117, tokenIndex117, depth117
+					goto l126
+				l129:
+					position, tokenIndex, depth = position126, tokenIndex126, depth126
 					if !_rules[ruleNumber]() {
-						goto l120
+						goto l130
 					}
-					goto l117
-				l120:
-					position, tokenIndex, depth = position117, tokenIndex117, depth117
+					goto l126
+				l130:
+					position, tokenIndex, depth = position126, tokenIndex126, depth126
 					if !_rules[ruleBoolean]() {
-						goto l121
+						goto l131
 					}
-					goto l117
-				l121:
-					position, tokenIndex, depth = position117, tokenIndex117, depth117
+					goto l126
+				l131:
+					position, tokenIndex, depth = position126, tokenIndex126, depth126
 					if !_rules[ruleUndefined]() {
-						goto l122
+						goto l132
 					}
-					goto l117
-				l122:
-					position, tokenIndex, depth = position117, tokenIndex117, depth117
+					goto l126
+				l132:
+					position, tokenIndex, depth = position126, tokenIndex126, depth126
 					if !_rules[ruleNil]() {
-						goto l123
+						goto l133
 					}
-					goto l117
-				l123:
-					position, tokenIndex, depth = position117, tokenIndex117, depth117
+					goto l126
+				l133:
+					position, tokenIndex, depth = position126, tokenIndex126, depth126
 					if !_rules[ruleSymbol]() {
-						goto l124
+						goto l134
 					}
-					goto l117
-				l124:
-					position, tokenIndex, depth = position117, tokenIndex117, depth117
+					goto l126
+				l134:
+					position, tokenIndex, depth = position126, tokenIndex126, depth126
 					if !_rules[ruleNot]() {
-						goto l125
+						goto l135
 					}
-					goto l117
-				l125:
-					position, tokenIndex, depth = position117, tokenIndex117, depth117
+					goto l126
+				l135:
+					position, tokenIndex, depth = position126, tokenIndex126, depth126
 					if !_rules[ruleSubstitution]() {
-						goto l126
+						goto l136
 					}
-					goto l117
-				l126:
-					position, tokenIndex, depth = position117, tokenIndex117, depth117
+					goto l126
+				l136:
+					position, tokenIndex, depth = position126, tokenIndex126, depth126
 					if !_rules[ruleMerge]() {
-						goto l127
+						goto l137
 					}
-					goto l117
-				l127:
-					position, tokenIndex, depth = position117, tokenIndex117, depth117
+					goto l126
+				l137:
+					position, tokenIndex, depth = position126, tokenIndex126, depth126
 					if !_rules[ruleAuto]() {
-						goto l128
+						goto l138
 					}
-					goto l117
-				l128:
-					position, tokenIndex, depth = position117, tokenIndex117, depth117
+					goto l126
+				l138:
+					position, tokenIndex, depth = position126, tokenIndex126, depth126
 					if !_rules[ruleLambda]() {
-						goto l129
+						goto l139
 					}
-					goto l117
-				l129:
-					position, tokenIndex, depth = position117, tokenIndex117, depth117
+					goto l126
+				l139:
+					position, tokenIndex, depth = position126, tokenIndex126, depth126
 					if !_rules[ruleChained]() {
-						goto l115
+						goto l124
 					}
 				}
-			l117:
+			l126:
 				depth--
-				add(ruleLevel0, position116)
+				add(ruleLevel0, position125)
 			}
 			return true
-		l115:
-			position, tokenIndex, depth = position115, tokenIndex115, depth115
+		l124:
+			position, tokenIndex, depth = position124, tokenIndex124, depth124
 			return false
 		},
-		/* 32 Chained <- <((MapMapping / Sync / Catch / Mapping / MapSelection / Selection / Sum / List / Map / Range / Grouped / Reference / TopIndex) ChainedQualifiedExpression*)> */
+		/* 34 Chained <- <((MapMapping / Sync / Catch / Mapping / MapSelection / Selection / Sum / List / Map / Range / Grouped / Reference / TopIndex) ChainedQualifiedExpression*)> */
 		func() bool {
-			position130, tokenIndex130, depth130 := position, tokenIndex, depth
+			position140, tokenIndex140, depth140 := position, tokenIndex, depth
 			{
-				position131 := position
+				position141 := position
 				depth++
 				{
-					position132, tokenIndex132, depth132 := position, tokenIndex, depth
+					position142, tokenIndex142, depth142 := position, tokenIndex, depth
 					if !_rules[ruleMapMapping]() {
-						goto l133
+						goto l143
 					}
-					goto l132
-				l133:
-					position, tokenIndex, depth = position132, tokenIndex132, depth132
+					goto l142
+				l143:
+					position, tokenIndex, depth = position142, tokenIndex142, depth142
 					if !_rules[ruleSync]() {
-						goto l134
+						goto l144
 					}
-					goto l132
-				l134:
-					position, tokenIndex, depth = position132, tokenIndex132, depth132
+					goto l142
+				l144:
+					position, tokenIndex, depth = position142, tokenIndex142, depth142
 					if !_rules[ruleCatch]() {
-						goto l135
+						goto l145
 					}
-					goto l132
-				l135:
-					position, tokenIndex, depth = position132, tokenIndex132, depth132
+					goto l142
+				l145:
+					position, tokenIndex, depth = position142, tokenIndex142, depth142
 					if !_rules[ruleMapping]() {
-						goto l136
+						goto l146
 					}
-					goto l132
-				l136:
-					position, tokenIndex, depth = position132, tokenIndex132, depth132
+					goto l142
+				l146:
+					position, tokenIndex, depth = position142, tokenIndex142, depth142
 					if !_rules[ruleMapSelection]() {
-						goto l137
+						goto l147
 					}
-					goto l132
-				l137:
-					position, tokenIndex, depth = position132, tokenIndex132, depth132
+					goto l142
+				l147:
+					position, tokenIndex, depth = position142, tokenIndex142, depth142
 					if !_rules[ruleSelection]() {
-						goto l138
+						goto l148
 					}
-					goto l132
-				l138:
-					position, tokenIndex, depth = position132, tokenIndex132, depth132
+					goto l142
+				l148:
+					position, tokenIndex, depth = position142, tokenIndex142, depth142
 					if !_rules[ruleSum]() {
-						goto l139
+						goto l149
 					}
-					goto l132
-				l139:
-					position, tokenIndex, depth = position132, tokenIndex132, depth132
+					goto l142
+				l149:
+					position, tokenIndex, depth = position142, tokenIndex142, depth142
 					if !_rules[ruleList]() {
-						goto l140
+						goto l150
 					}
-					goto l132
-				l140:
-					position, tokenIndex, depth = position132, tokenIndex132, depth132
+					goto l142
+				l150:
+					position, tokenIndex, depth = position142, tokenIndex142, depth142
 					if !_rules[ruleMap]() {
-						goto l141
+						goto l151
 					}
-					goto l132
-				l141:
-					position, tokenIndex, depth = position132, tokenIndex132, depth132
+					goto l142
+				l151:
+					position, tokenIndex, depth = position142, tokenIndex142, depth142
 					if !_rules[ruleRange]() {
-						goto l142
+						goto l152
 					}
-					goto l132
-				l142:
-					position, tokenIndex, depth = position132, tokenIndex132, depth132
+					goto l142
+				l152:
+					position, tokenIndex, depth = position142, tokenIndex142, depth142
 					if !_rules[ruleGrouped]() {
-						goto l143
+						goto l153
 					}
-					goto l132
-				l143:
-					position, tokenIndex, depth = position132, tokenIndex132, depth132
+					goto l142
+				l153:
+					position, tokenIndex, depth = position142, tokenIndex142, depth142
 					if !_rules[ruleReference]() {
-						goto l144
+						goto l154
 					}
-					goto l132
-				l144:
-					position, tokenIndex, depth = position132, tokenIndex132, depth132
+					goto l142
+				l154:
+					position, tokenIndex, depth = position142, tokenIndex142, depth142
 					if !_rules[ruleTopIndex]() {
-						goto l130
+						goto l140
 					}
 				}
-			l132:
-			l145:
+			l142:
+			l155:
 				{
-					position146, tokenIndex146, depth146 := position, tokenIndex, depth
+					position156, tokenIndex156, depth156 := position, tokenIndex, depth
 					if !_rules[ruleChainedQualifiedExpression]() {
-						goto l146
+						goto l156
 					}
-					goto l145
-				l146:
-					position, tokenIndex, depth = position146, tokenIndex146, depth146
+					goto l155
+				l156:
+					position, tokenIndex, depth = position156, tokenIndex156, depth156
 				}
 				depth--
-				add(ruleChained, position131)
+				add(ruleChained, position141)
 			}
 			return true
-		l130:
-			position, tokenIndex, depth = position130, tokenIndex130, depth130
+		l140:
+			position, tokenIndex, depth = position140, tokenIndex140, depth140
 			return false
 		},
-		/* 33 ChainedQualifiedExpression <- <(ChainedCall / Currying / ChainedRef / ChainedDynRef / Projection)> */
+		/* 35 ChainedQualifiedExpression <- <(ChainedCall / Currying / ChainedRef / ChainedDynRef / Projection)> */
 		func() bool {
-			position147, tokenIndex147, depth147 := position, tokenIndex, depth
+			position157, tokenIndex157, depth157 := position, tokenIndex, depth
 			{
-				position148 := position
+				position158 := position
 				depth++
 				{
-					position149, tokenIndex149, depth149 := position, tokenIndex, depth
+					position159, tokenIndex159, depth159 := position, tokenIndex, depth
 					if !_rules[ruleChainedCall]() {
-						goto l150
+						goto l160
 					}
-					goto l149
-				l150:
-					position, tokenIndex, depth = position149, tokenIndex149, depth149
+					goto l159
+				l160:
+					position, tokenIndex, depth = position159, tokenIndex159, depth159
 					if !_rules[ruleCurrying]() {
-						goto l151
+						goto l161
 					}
-					goto l149
-				l151:
-					position, tokenIndex, depth = position149, tokenIndex149, depth149
+					goto l159
+				l161:
+					position, tokenIndex, depth = position159, tokenIndex159, depth159
 					if !_rules[ruleChainedRef]() {
-						goto l152
+						goto l162
 					}
-					goto l149
-				l152:
-					position, tokenIndex, depth = position149, tokenIndex149, depth149
+					goto l159
+				l162:
+					position, tokenIndex, depth = position159, tokenIndex159, depth159
 					if !_rules[ruleChainedDynRef]() {
-						goto l153
+						goto l163
 					}
-					goto l149
-				l153:
-					position, tokenIndex, depth = position149, tokenIndex149, depth149
+					goto l159
+				l163:
+					position, tokenIndex, depth = position159, tokenIndex159, depth159
 					if !_rules[ruleProjection]() {
-						goto l147
+						goto l157
 					}
 				}
-			l149:
+			l159:
 				depth--
-				add(ruleChainedQualifiedExpression, position148)
+				add(ruleChainedQualifiedExpression, position158)
 			}
 			return true
-		l147:
-			position, tokenIndex, depth = position147, tokenIndex147, depth147
+		l157:
+			position, tokenIndex, depth = position157, tokenIndex157, depth157
 			return false
 		},
-		/* 34 ChainedRef <- <(PathComponent FollowUpRef)> */
+		/* 36 ChainedRef <- <(PathComponent FollowUpRef)> */
 		func() bool {
-			position154, tokenIndex154, depth154 := position, tokenIndex, depth
+			position164, tokenIndex164, depth164 := position, tokenIndex, depth
 			{
-				position155 := position
+				position165 := position
 				depth++
 				if !_rules[rulePathComponent]() {
-					goto l154
+					goto l164
 				}
 				if !_rules[ruleFollowUpRef]() {
-					goto l154
+					goto l164
 				}
 				depth--
-				add(ruleChainedRef, position155)
+				add(ruleChainedRef, position165)
 			}
 			return true
-		l154:
-			position, tokenIndex, depth = position154, tokenIndex154, depth154
+		l164:
+			position, tokenIndex, depth = position164, tokenIndex164, depth164
 			return false
 		},
-		/* 35 ChainedDynRef <- <('.'? Indices)> */
+		/* 37 ChainedDynRef <- <('.'? Indices)> */
 		func() bool {
-			position156, tokenIndex156, depth156 := position, tokenIndex, depth
+			position166, tokenIndex166, depth166 := position, tokenIndex, depth
 			{
-				position157 := position
+				position167 := position
 				depth++
 				{
-					position158, tokenIndex158, depth158 := position, tokenIndex, depth
+					position168, tokenIndex168, depth168 := position, tokenIndex, depth
 					if buffer[position] != rune('.') {
-						goto l158
+						goto l168
 					}
 					position++
-					goto l159
-				l158:
-					position, tokenIndex, depth = position158, tokenIndex158, depth158
+					goto l169
+				l168:
+					position, tokenIndex, depth = position168, tokenIndex168, depth168
 				}
-			l159:
+			l169:
 				if !_rules[ruleIndices]() {
-					goto l156
+					goto l166
 				}
 				depth--
-				add(ruleChainedDynRef, position157)
+				add(ruleChainedDynRef, position167)
 			}
 			return true
-		l156:
-			position, tokenIndex, depth = position156, tokenIndex156, depth156
+		l166:
+			position, tokenIndex, depth = position166, tokenIndex166, depth166
 			return false
 		},
-		/* 36 TopIndex <- <('.' Indices)> */
+		/* 38 TopIndex <- <('.' Indices)> */
 		func() bool {
-			position160, tokenIndex160, depth160 := position, tokenIndex, depth
+			position170, tokenIndex170, depth170 := position, tokenIndex, depth
 			{
-				position161 := position
+				position171 := position
 				depth++
 				if buffer[position] != rune('.') {
-					goto l160
+					goto l170
 				}
 				position++
 				if !_rules[ruleIndices]() {
-					goto l160
+					goto l170
 				}
 				depth--
-				add(ruleTopIndex, position161)
+				add(ruleTopIndex, position171)
 			}
 			return true
-		l160:
-			position, tokenIndex, depth = position160, tokenIndex160, depth160
+		l170:
+			position, tokenIndex, depth = position170, tokenIndex170, depth170
 			return false
 		},
-		/* 37 Indices <- <(StartList ExpressionList ']')> */
+		/* 39 Indices <- <(StartList ExpressionList ']')> */
 		func() bool {
-			position162, tokenIndex162, depth162 := position, tokenIndex, depth
+			position172, tokenIndex172, depth172 := position, tokenIndex, depth
 			{
-				position163 := position
+				position173 := position
 				depth++
 				if !_rules[ruleStartList]() {
-					goto l162
+					goto l172
 				}
 				if !_rules[ruleExpressionList]() {
-					goto l162
+					goto l172
 				}
 				if buffer[position] != rune(']') {
-					goto l162
+					goto l172
 				}
 				position++
 				depth--
-				add(ruleIndices, position163)
+				add(ruleIndices, position173)
 			}
 			return true
-		l162:
-			position, tokenIndex, depth = position162, tokenIndex162, depth162
+		l172:
+			position, tokenIndex, depth = position172, tokenIndex172, depth172
 			return false
 		},
-		/* 38 Slice <- <Range> */
+		/* 40 Slice <- <Range> */
 		func() bool {
-			position164, tokenIndex164, depth164 := position, tokenIndex, depth
+			position174, tokenIndex174, depth174 := position, tokenIndex, depth
 			{
-				position165 := position
+				position175 := position
 				depth++
 				if !_rules[ruleRange]() {
-					goto l164
+					goto l174
 				}
 				depth--
-				add(ruleSlice, position165)
+				add(ruleSlice, position175)
 			}
 			return true
-		l164:
-			position, tokenIndex, depth = position164, tokenIndex164, depth164
+		l174:
+			position, tokenIndex, depth = position174, tokenIndex174, depth174
 			return false
 		},
-		/* 39 Currying <- <('*' ChainedCall)> */
+		/* 41 Currying <- <('*' ChainedCall)> */
 		func() bool {
-			position166, tokenIndex166, depth166 := position, tokenIndex, depth
+			position176, tokenIndex176, depth176 := position, tokenIndex, depth
 			{
-				position167 := position
+				position177 := position
 				depth++
 				if buffer[position] != rune('*') {
-					goto l166
+					goto l176
 				}
 				position++
 				if !_rules[ruleChainedCall]() {
-					goto l166
+					goto l176
 				}
 				depth--
-				add(ruleCurrying, position167)
+				add(ruleCurrying, position177)
 			}
 			return true
-		l166:
-			position, tokenIndex, depth = position166, tokenIndex166, depth166
+		l176:
+			position, tokenIndex, depth = position176, tokenIndex176, depth176
 			return false
 		},
-		/* 40 ChainedCall <- <(StartArguments NameArgumentList? ')')> */
+		/* 42 ChainedCall <- <(StartArguments NameArgumentList? ')')> */
 		func() bool {
-			position168, tokenIndex168, depth168 := position, tokenIndex, depth
+			position178, tokenIndex178, depth178 := position, tokenIndex, depth
 			{
-				position169 := position
+				position179 := position
 				depth++
 				if !_rules[ruleStartArguments]() {
-					goto l168
+					goto l178
 				}
 				{
-					position170, tokenIndex170, depth170 := position, tokenIndex, depth
+					position180, tokenIndex180, depth180 := position, tokenIndex, depth
 					if !_rules[ruleNameArgumentList]() {
-						goto l170
+						goto l180
 					}
-					goto l171
-				l170:
-					position, tokenIndex, depth = position170, tokenIndex170, depth170
+					goto l181
+				l180:
+					position, tokenIndex, depth = position180, tokenIndex180, depth180
 				}
-			l171:
+			l181:
 				if buffer[position] != rune(')') {
-					goto l168
+					goto l178
 				}
 				position++
 				depth--
-				add(ruleChainedCall, position169)
+				add(ruleChainedCall, position179)
 			}
 			return true
-		l168:
-			position, tokenIndex, depth = position168, tokenIndex168, depth168
+		l178:
+			position, tokenIndex, depth = position178, tokenIndex178, depth178
 			return false
 		},
-		/* 41 StartArguments <- <('(' ws)> */
+		/* 43 StartArguments <- <('(' ws)> */
 		func() bool {
-			position172, tokenIndex172, depth172 := position, tokenIndex, depth
+			position182, tokenIndex182, depth182 := position, tokenIndex, depth
 			{
-				position173 := position
+				position183 := position
 				depth++
 				if buffer[position] != rune('(') {
-					goto l172
+					goto l182
 				}
 				position++
 				if !_rules[rulews]() {
-					goto l172
+					goto l182
 				}
 				depth--
-				add(ruleStartArguments, position173)
+				add(ruleStartArguments, position183)
 			}
 			return true
-		l172:
-			position, tokenIndex, depth = position172, tokenIndex172, depth172
+		l182:
+			position, tokenIndex, depth = position182, tokenIndex182, depth182
 			return false
 		},
-		/* 42 NameArgumentList <- <(((NextNameArgument (',' NextNameArgument)*) / NextExpression) (',' NextExpression)*)> */
+		/* 44 NameArgumentList <- <(((NextNameArgument (',' NextNameArgument)*) / NextExpression) (',' NextExpression)*)> */
 		func() bool {
-			position174, tokenIndex174, depth174 := position, tokenIndex, depth
+			position184, tokenIndex184, depth184 := position, tokenIndex, depth
 			{
-				position175 := position
+				position185 := position
 				depth++
 				{
-					position176, tokenIndex176, depth176 := position, tokenIndex, depth
+					position186, tokenIndex186, depth186 := position, tokenIndex, depth
 					if !_rules[ruleNextNameArgument]() {
-						goto l177
+						goto l187
 					}
-				l178:
+				l188:
 					{
-						position179, tokenIndex179, depth179 := position, tokenIndex, depth
+						position189, tokenIndex189, depth189 := position, tokenIndex, depth
 						if buffer[position] != rune(',') {
-							goto l179
+							goto l189
 						}
 						position++
 						if !_rules[ruleNextNameArgument]() {
-							goto l179
+							goto l189
 						}
-						goto l178
-					l179:
-						position, tokenIndex, depth = position179, tokenIndex179, depth179
+						goto l188
+					l189:
+						position, tokenIndex, depth = position189, tokenIndex189, depth189
 					}
-					goto l176
-				l177:
-					position, tokenIndex, depth = position176, tokenIndex176, depth176
+					goto l186
+				l187:
+					position, tokenIndex, depth = position186, tokenIndex186, depth186
 					if !_rules[ruleNextExpression]() {
-						goto l174
+						goto l184
 					}
 				}
-			l176:
-			l180:
+			l186:
+			l190:
 				{
-					position181, tokenIndex181, depth181 := position, tokenIndex, depth
+					position191, tokenIndex191, depth191 := position, tokenIndex, depth
 					if buffer[position] != rune(',') {
-						goto l181
+						goto l191
 					}
 					position++
 					if !_rules[ruleNextExpression]() {
-						goto l181
+						goto l191
 					}
-					goto l180
-				l181:
-					position, tokenIndex, depth = position181, tokenIndex181, depth181
+					goto l190
+				l191:
+					position, tokenIndex, depth = position191, tokenIndex191, depth191
 				}
 				depth--
-				add(ruleNameArgumentList, position175)
+				add(ruleNameArgumentList, position185)
 			}
 			return true
-		l174:
-			position, tokenIndex, depth = position174, tokenIndex174, depth174
+		l184:
+			position, tokenIndex, depth = position184, tokenIndex184, depth184
 			return false
 		},
-		/* 43 NextNameArgument <- <(ws Name ws '=' ws Expression ws)> */
+		/* 45 NextNameArgument <- <(ws Name ws '=' ws Expression ws)> */
 		func() bool {
-			position182, tokenIndex182, depth182 := position, tokenIndex, depth
+			position192, tokenIndex192, depth192 := position, tokenIndex, depth
 			{
-				position183 := position
+				position193 := position
 				depth++
 				if !_rules[rulews]() {
-					goto l182
+					goto l192
 				}
 				if !_rules[ruleName]() {
-					goto l182
+					goto l192
 				}
 				if !_rules[rulews]() {
-					goto l182
+					goto l192
 				}
 				if buffer[position] != rune('=') {
-					goto l182
+					goto l192
 				}
 				position++
 				if !_rules[rulews]() {
-					goto l182
+					goto l192
 				}
 				if !_rules[ruleExpression]() {
-					goto l182
+					goto l192
 				}
 				if !_rules[rulews]() {
-					goto l182
+					goto l192
 				}
 				depth--
-				add(ruleNextNameArgument, position183)
+				add(ruleNextNameArgument, position193)
 			}
 			return true
-		l182:
-			position, tokenIndex, depth = position182, tokenIndex182, depth182
+		l192:
+			position, tokenIndex, depth = position192, tokenIndex192, depth192
 			return false
 		},
-		/* 44 ExpressionList <- <(NextExpression (',' NextExpression)*)> */
+		/* 46 ExpressionList <- <(NextExpression (',' NextExpression)*)> */
 		func() bool {
-			position184, tokenIndex184, depth184 := position, tokenIndex, depth
+			position194, tokenIndex194, depth194 := position, tokenIndex, depth
 			{
-				position185 := position
+				position195 := position
 				depth++
 				if !_rules[ruleNextExpression]() {
-					goto l184
+					goto l194
 				}
-			l186:
+			l196:
 				{
-					position187, tokenIndex187, depth187 := position, tokenIndex, depth
+					position197, tokenIndex197, depth197 := position, tokenIndex, depth
 					if buffer[position] != rune(',') {
-						goto l187
+						goto l197
 					}
 					position++
 					if !_rules[ruleNextExpression]() {
-						goto l187
+						goto l197
 					}
-					goto l186
-				l187:
-					position, tokenIndex, depth = position187, tokenIndex187, depth187
+					goto l196
+				l197:
+					position, tokenIndex, depth = position197, tokenIndex197, depth197
 				}
 				depth--
-				add(ruleExpressionList, position185)
+				add(ruleExpressionList, position195)
 			}
 			return true
-		l184:
-			position, tokenIndex, depth = position184, tokenIndex184, depth184
+		l194:
+			position, tokenIndex, depth = position194, tokenIndex194, depth194
 			return false
 		},
-		/* 45 NextExpression <- <(Expression ListExpansion?)> */
+		/* 47 NextExpression <- <(Expression ListExpansion?)> */
 		func() bool {
-			position188, tokenIndex188, depth188 := position, tokenIndex, depth
+			position198, tokenIndex198, depth198 := position, tokenIndex, depth
 			{
-				position189 := position
+				position199 := position
 				depth++
 				if !_rules[ruleExpression]() {
-					goto l188
+					goto l198
 				}
 				{
-					position190, tokenIndex190, depth190 := position, tokenIndex, depth
+					position200, tokenIndex200, depth200 := position, tokenIndex, depth
 					if !_rules[ruleListExpansion]() {
-						goto l190
+						goto l200
 					}
-					goto l191
-				l190:
-					position, tokenIndex, depth = position190, tokenIndex190, depth190
+					goto l201
+				l200:
+					position, tokenIndex, depth = position200, tokenIndex200, depth200
 				}
-			l191:
+			l201:
 				depth--
-				add(ruleNextExpression, position189)
+				add(ruleNextExpression, position199)
 			}
 			return true
-		l188:
-			position, tokenIndex, depth = position188, tokenIndex188, depth188
+		l198:
+			position, tokenIndex, depth = position198, tokenIndex198, depth198
 			return false
 		},
-		/* 46 ListExpansion <- <('.' '.' '.' ws)> */
+		/* 48 ListExpansion <- <('.' '.' '.' ws)> */
 		func() bool {
-			position192, tokenIndex192, depth192 := position, tokenIndex, depth
+			position202, tokenIndex202, depth202 := position, tokenIndex, depth
 			{
-				position193 := position
+				position203 := position
 				depth++
 				if buffer[position] != rune('.') {
-					goto l192
+					goto l202
 				}
 				position++
 				if buffer[position] != rune('.') {
-					goto l192
+					goto l202
 				}
 				position++
 				if buffer[position] != rune('.') {
-					goto l192
+					goto l202
 				}
 				position++
 				if !_rules[rulews]() {
-					goto l192
+					goto l202
 				}
 				depth--
-				add(ruleListExpansion, position193)
+				add(ruleListExpansion, position203)
 			}
 			return true
-		l192:
-			position, tokenIndex, depth = position192, tokenIndex192, depth192
+		l202:
+			position, tokenIndex, depth = position202, tokenIndex202, depth202
 			return false
 		},
-		/* 47 Projection <- <('.'? (('[' '*' ']') / Slice) ProjectionValue ChainedQualifiedExpression*)> */
+		/* 49 Projection <- <('.'? (('[' '*' ']') / Slice) ProjectionValue ChainedQualifiedExpression*)> */
 		func() bool {
-			position194, tokenIndex194, depth194 := position, tokenIndex, depth
+			position204, tokenIndex204, depth204 := position, tokenIndex, depth
 			{
-				position195 := position
+				position205 := position
 				depth++
 				{
-					position196, tokenIndex196, depth196 := position, tokenIndex, depth
+					position206, tokenIndex206, depth206 := position, tokenIndex, depth
 					if buffer[position] != rune('.') {
-						goto l196
+						goto l206
 					}
 					position++
-					goto l197
-				l196:
-					position, tokenIndex, depth = position196, tokenIndex196, depth196
+					goto l207
+				l206:
+					position, tokenIndex, depth = position206, tokenIndex206, depth206
 				}
-			l197:
+			l207:
 				{
-					position198, tokenIndex198, depth198 := position, tokenIndex, depth
+					position208, tokenIndex208, depth208 := position, tokenIndex, depth
 					if buffer[position] != rune('[') {
-						goto l199
+						goto l209
 					}
 					position++
 					if buffer[position] != rune('*') {
-						goto l199
+						goto l209
 					}
 					position++
 					if buffer[position] != rune(']') {
-						goto l199
+						goto l209
 					}
 					position++
-					goto l198
-				l199:
-					position, tokenIndex, depth = position198, tokenIndex198, depth198
+					goto l208
+				l209:
+					position, tokenIndex, depth = position208, tokenIndex208, depth208
 					if !_rules[ruleSlice]() {
-						goto l194
+						goto l204
 					}
 				}
-			l198:
+			l208:
 				if !_rules[ruleProjectionValue]() {
-					goto l194
+					goto l204
 				}
-			l200:
+			l210:
 				{
-					position201, tokenIndex201, depth201 := position, tokenIndex, depth
+					position211, tokenIndex211, depth211 := position, tokenIndex, depth
 					if !_rules[ruleChainedQualifiedExpression]() {
-						goto l201
+						goto l211
 					}
-					goto l200
-				l201:
-					position, tokenIndex, depth = position201, tokenIndex201, depth201
+					goto l210
+				l211:
+					position, tokenIndex, depth = position211, tokenIndex211, depth211
 				}
 				depth--
-				add(ruleProjection, position195)
+				add(ruleProjection, position205)
 			}
 			return true
-		l194:
-			position, tokenIndex, depth = position194, tokenIndex194, depth194
+		l204:
+			position, tokenIndex, depth = position204, tokenIndex204, depth204
 			return false
 		},
-		/* 48 ProjectionValue <- <Action0> */
+		/* 50 ProjectionValue <- <Action0> */
 		func() bool {
-			position202, tokenIndex202, depth202 := position, tokenIndex, depth
+			position212, tokenIndex212, depth212 := position, tokenIndex, depth
 			{
-				position203 := position
+				position213 := position
 				depth++
 				if !_rules[ruleAction0]() {
-					goto l202
+					goto l212
 				}
 				depth--
-				add(ruleProjectionValue, position203)
+				add(ruleProjectionValue, position213)
 			}
 			return true
-		l202:
-			position, tokenIndex, depth = position202, tokenIndex202, depth202
+		l212:
+			position, tokenIndex, depth = position212, tokenIndex212, depth212
 			return false
 		},
-		/* 49 Substitution <- <('*' Level0)> */
+		/* 51 Substitution <- <('*' Level0)> */
 		func() bool {
-			position204, tokenIndex204, depth204 := position, tokenIndex, depth
+			position214, tokenIndex214, depth214 := position, tokenIndex, depth
 			{
-				position205 := position
+				position215 := position
 				depth++
 				if buffer[position] != rune('*') {
-					goto l204
+					goto l214
 				}
 				position++
 				if !_rules[ruleLevel0]() {
-					goto l204
+					goto l214
 				}
 				depth--
-				add(ruleSubstitution, position205)
+				add(ruleSubstitution, position215)
 			}
 			return true
-		l204:
-			position, tokenIndex, depth = position204, tokenIndex204, depth204
+		l214:
+			position, tokenIndex, depth = position214, tokenIndex214, depth214
 			return false
 		},
-		/* 50 Not <- <('!' ws Level0)> */
+		/* 52 Not <- <('!' ws Level0)> */
 		func() bool {
-			position206, tokenIndex206, depth206 := position, tokenIndex, depth
+			position216, tokenIndex216, depth216 := position, tokenIndex, depth
 			{
-				position207 := position
+				position217 := position
 				depth++
 				if buffer[position] != rune('!') {
-					goto l206
+					goto l216
 				}
 				position++
 				if !_rules[rulews]() {
-					goto l206
+					goto l216
 				}
 				if !_rules[ruleLevel0]() {
-					goto l206
+					goto l216
 				}
 				depth--
-				add(ruleNot, position207)
+				add(ruleNot, position217)
 			}
 			return true
-		l206:
-			position, tokenIndex, depth = position206, tokenIndex206, depth206
+		l216:
+			position, tokenIndex, depth = position216, tokenIndex216, depth216
 			return false
 		},
-		/* 51 Grouped <- <('(' Expression ')')> */
+		/* 53 Grouped <- <('(' Expression ')')> */
 		func() bool {
-			position208, tokenIndex208, depth208 := position, tokenIndex, depth
+			position218, tokenIndex218, depth218 := position, tokenIndex, depth
 			{
-				position209 := position
+				position219 := position
 				depth++
 				if buffer[position] != rune('(') {
-					goto l208
+					goto l218
 				}
 				position++
 				if !_rules[ruleExpression]() {
-					goto l208
+					goto l218
 				}
 				if buffer[position] != rune(')') {
-					goto l208
+					goto l218
 				}
 				position++
 				depth--
-				add(ruleGrouped, position209)
+				add(ruleGrouped, position219)
 			}
 			return true
-		l208:
-			position, tokenIndex, depth = position208, tokenIndex208, depth208
+		l218:
+			position, tokenIndex, depth = position218, tokenIndex218, depth218
 			return false
 		},
-		/* 52 Range <- <(StartRange Expression? RangeOp Expression? ']')> */
+		/* 54 Range <- <(StartRange Expression? RangeOp Expression? ']')> */
 		func() bool {
-			position210, tokenIndex210, depth210 := position, tokenIndex, depth
+			position220, tokenIndex220, depth220 := position, tokenIndex, depth
 			{
-				position211 := position
+				position221 := position
 				depth++
 				if !_rules[ruleStartRange]() {
-					goto l210
+					goto l220
 				}
 				{
-					position212, tokenIndex212, depth212 := position, tokenIndex, depth
+					position222, tokenIndex222, depth222 := position, tokenIndex, depth
 					if !_rules[ruleExpression]() {
-						goto l212
+						goto l222
 					}
-					goto l213
-				l212:
-					position, tokenIndex, depth = position212, tokenIndex212, depth212
+					goto l223
+				l222:
+					position, tokenIndex, depth = position222, tokenIndex222, depth222
 				}
-			l213:
+			l223:
 				if !_rules[ruleRangeOp]() {
-					goto l210
+					goto l220
 				}
 				{
-					position214, tokenIndex214, depth214 := position, tokenIndex, depth
+					position224, tokenIndex224, depth224 := position, tokenIndex, depth
 					if !_rules[ruleExpression]() {
-						goto l214
+						goto l224
 					}
-					goto l215
-				l214:
-					position, tokenIndex, depth = position214, tokenIndex214, depth214
+					goto l225
+				l224:
+					position, tokenIndex, depth = position224, tokenIndex224, depth224
 				}
-			l215:
+			l225:
 				if buffer[position] != rune(']') {
-					goto l210
+					goto l220
 				}
 				position++
 				depth--
-				add(ruleRange, position211)
+				add(ruleRange, position221)
 			}
 			return true
-		l210:
-			position, tokenIndex, depth = position210, tokenIndex210, depth210
+		l220:
+			position, tokenIndex, depth = position220, tokenIndex220, depth220
 			return false
 		},
-		/* 53 StartRange <- <'['> */
+		/* 55 StartRange <- <'['> */
 		func() bool {
-			position216, tokenIndex216, depth216 := position, tokenIndex, depth
+			position226, tokenIndex226, depth226 := position, tokenIndex, depth
 			{
-				position217 := position
+				position227 := position
 				depth++
 				if buffer[position] != rune('[') {
-					goto l216
+					goto l226
 				}
 				position++
 				depth--
-				add(ruleStartRange, position217)
+				add(ruleStartRange, position227)
 			}
 			return true
-		l216:
-			position, tokenIndex, depth = position216, tokenIndex216, depth216
+		l226:
+			position, tokenIndex, depth = position226, tokenIndex226, depth226
 			return false
 		},
-		/* 54 RangeOp <- <('.' '.')> */
+		/* 56 RangeOp <- <('.' '.')> */
 		func() bool {
-			position218, tokenIndex218, depth218 := position, tokenIndex, depth
+			position228, tokenIndex228, depth228 := position, tokenIndex, depth
 			{
-				position219 := position
+				position229 := position
 				depth++
 				if buffer[position] != rune('.') {
-					goto l218
+					goto l228
 				}
 				position++
 				if buffer[position] != rune('.') {
-					goto l218
+					goto l228
 				}
 				position++
 				depth--
-				add(ruleRangeOp, position219)
+				add(ruleRangeOp, position229)
 			}
 			return true
-		l218:
-			position, tokenIndex, depth = position218, tokenIndex218, depth218
+		l228:
+			position, tokenIndex, depth = position228, tokenIndex228, depth228
 			return false
 		},
-		/* 55 Number <- <('-'? [0-9] ([0-9] / '_')* ('.' [0-9] [0-9]*)? (('e' / 'E') '-'? [0-9] [0-9]*)? !(':' ':'))> */
+		/* 57 Number <- <('-'? (PrefixedInteger / (Digits ('.' Digits)? (('e' / 'E') '-'? Digits)?)) !(':' ':'))> */
 		func() bool {
-			position220, tokenIndex220, depth220 := position, tokenIndex, depth
+			position230, tokenIndex230, depth230 := position, tokenIndex, depth
 			{
-				position221 := position
+				position231 := position
 				depth++
 				{
-					position222, tokenIndex222, depth222 := position, tokenIndex, depth
+					position232, tokenIndex232, depth232 := position, tokenIndex, depth
 					if buffer[position] != rune('-') {
-						goto l222
+						goto l232
 					}
 					position++
-					goto l223
-				l222:
-					position, tokenIndex, depth = position222, tokenIndex222, depth222
-				}
-			l223:
-				if c := buffer[position]; c < rune('0') || c > rune('9') {
-					goto l220
+					goto l233
+				l232:
+					position, tokenIndex, depth = position232, tokenIndex232, depth232
 				}
-				position++
-			l224:
+			l233:
 				{
-					position225, tokenIndex225, depth225 := position, tokenIndex, depth
+					position234, tokenIndex234, depth234 := position, tokenIndex, depth
+					if !_rules[rulePrefixedInteger]() {
+						goto l235
+					}
+					goto l234
+				l235:
+					position, tokenIndex, depth = position234, tokenIndex234, depth234
+					if !_rules[ruleDigits]() {
+						goto l230
+					}
 					{
-						position226, tokenIndex226, depth226 := position, tokenIndex, depth
-						if c := buffer[position]; c < rune('0') || c > rune('9') {
-							goto l227
+						position236, tokenIndex236, depth236 := position, tokenIndex, depth
+						if buffer[position] != rune('.') {
+							goto l236
 						}
 						position++
-						goto l226
-					l227:
-						position, tokenIndex, depth = position226, tokenIndex226, depth226
-						if buffer[position] != rune('_') {
-							goto l225
+						if !_rules[ruleDigits]() {
+							goto l236
 						}
-						position++
+						goto l237
+					l236:
+						position, tokenIndex, depth = position236, tokenIndex236, depth236
+					}
+				l237:
+					{
+						position238, tokenIndex238, depth238 := position, tokenIndex, depth
+						{
+							position240, tokenIndex240, depth240 := position, tokenIndex, depth
+							if buffer[position] != rune('e') {
+								goto l241
+							}
+							position++
+							goto l240
+						l241:
+							position, tokenIndex, depth = position240, tokenIndex240, depth240
+							if buffer[position] != rune('E') {
+								goto l238
+							}
+							position++
+						}
+					l240:
+						{
+							position242, tokenIndex242, depth242 := position, tokenIndex, depth
+							if buffer[position] != rune('-') {
+								goto l242
+							}
+							position++
+							goto l243
+						l242:
+							position, tokenIndex, depth = position242, tokenIndex242, depth242
+						}
+					l243:
+						if !_rules[ruleDigits]() {
+							goto l238
+						}
+						goto l239
+					l238:
+						position, tokenIndex, depth = position238, tokenIndex238, depth238
 					}
-				l226:
-					goto l224
-				l225:
-					position, tokenIndex, depth = position225, tokenIndex225, depth225
+				l239:
 				}
+			l234:
 				{
-					position228, tokenIndex228, depth228 := position, tokenIndex, depth
-					if buffer[position] != rune('.') {
-						goto l228
+					position244, tokenIndex244, depth244 := position, tokenIndex, depth
+					if buffer[position] != rune(':') {
+						goto l244
 					}
 					position++
+					if buffer[position] != rune(':') {
+						goto l244
+					}
+					position++
+					goto l230
+				l244:
+					position, tokenIndex, depth = position244, tokenIndex244, depth244
+				}
+				depth--
+				add(ruleNumber, position231)
+			}
+			return true
+		l230:
+			position, tokenIndex, depth = position230, tokenIndex230, depth230
+			return false
+		},
+		/* 58 Digits <- <([0-9]+ ('_' [0-9]+)*)> */
+		func() bool {
+			position245, tokenIndex245, depth245 := position, tokenIndex, depth
+			{
+				position246 := position
+				depth++
+				if c := buffer[position]; c < rune('0') || c > rune('9') {
+					goto l245
+				}
+				position++
+			l247:
+				{
+					position248, tokenIndex248, depth248 := position, tokenIndex, depth
 					if c := buffer[position]; c < rune('0') || c > rune('9') {
-						goto l228
+						goto l248
+					}
+					position++
+					goto l247
+				l248:
+					position, tokenIndex, depth = position248, tokenIndex248, depth248
+				}
+			l249:
+				{
+					position250, tokenIndex250, depth250 := position, tokenIndex, depth
+					if buffer[position] != rune('_') {
+						goto l250
 					}
 					position++
-				l230:
+					if c := buffer[position]; c < rune('0') || c > rune('9') {
+						goto l250
+					}
+					position++
+				l251:
 					{
-						position231, tokenIndex231, depth231 := position, tokenIndex, depth
+						position252, tokenIndex252, depth252 := position, tokenIndex, depth
 						if c := buffer[position]; c < rune('0') || c > rune('9') {
-							goto l231
+							goto l252
 						}
 						position++
-						goto l230
-					l231:
-						position, tokenIndex, depth = position231, tokenIndex231, depth231
+						goto l251
+					l252:
+						position, tokenIndex, depth = position252, tokenIndex252, depth252
 					}
-					goto l229
-				l228:
-					position, tokenIndex, depth = position228, tokenIndex228, depth228
+					goto l249
+				l250:
+					position, tokenIndex, depth = position250, tokenIndex250, depth250
 				}
-			l229:
+				depth--
+				add(ruleDigits, position246)
+			}
+			return true
+		l245:
+			position, tokenIndex, depth = position245, tokenIndex245, depth245
+			return false
+		},
+		/* 59 PrefixedInteger <- <((((('0' 'x') / ('0' 'X')) '_'? ([0-9] / [a-f] / [A-F])+ ('_' ([0-9] / [a-f] / [A-F])+)*) / ((('0' 'o') / ('0' 'O')) '_'? [0-7]+ ('_' [0-7]+)*) / ((('0' 'b') / ('0' 'B')) '_'? ('0' / '1')+ ('_' ('0' / '1')+)*)) !([a-z] / [A-Z] / [0-9] / '_'))> */
+		func() bool {
+			position253, tokenIndex253, depth253 := position, tokenIndex, depth
+			{
+				position254 := position
+				depth++
 				{
-					position232, tokenIndex232, depth232 := position, tokenIndex, depth
+					position255, tokenIndex255, depth255 := position, tokenIndex, depth
 					{
-						position234, tokenIndex234, depth234 := position, tokenIndex, depth
-						if buffer[position] != rune('e') {
-							goto l235
+						position257, tokenIndex257, depth257 := position, tokenIndex, depth
+						if buffer[position] != rune('0') {
+							goto l258
 						}
 						position++
-						goto l234
-					l235:
-						position, tokenIndex, depth = position234, tokenIndex234, depth234
-						if buffer[position] != rune('E') {
-							goto l232
+						if buffer[position] != rune('x') {
+							goto l258
+						}
+						position++
+						goto l257
+					l258:
+						position, tokenIndex, depth = position257, tokenIndex257, depth257
+						if buffer[position] != rune('0') {
+							goto l256
+						}
+						position++
+						if buffer[position] != rune('X') {
+							goto l256
 						}
 						position++
 					}
-				l234:
+				l257:
 					{
-						position236, tokenIndex236, depth236 := position, tokenIndex, depth
-						if buffer[position] != rune('-') {
-							goto l236
+						position259, tokenIndex259, depth259 := position, tokenIndex, depth
+						if buffer[position] != rune('_') {
+							goto l259
 						}
 						position++
-						goto l237
-					l236:
-						position, tokenIndex, depth = position236, tokenIndex236, depth236
+						goto l260
+					l259:
+						position, tokenIndex, depth = position259, tokenIndex259, depth259
 					}
-				l237:
-					if c := buffer[position]; c < rune('0') || c > rune('9') {
-						goto l232
+				l260:
+					{
+						position263, tokenIndex263, depth263 := position, tokenIndex, depth
+						if c := buffer[position]; c < rune('0') || c > rune('9') {
+							goto l264
+						}
+						position++
+						goto l263
+					l264:
+						position, tokenIndex, depth = position263, tokenIndex263, depth263
+						if c := buffer[position]; c < rune('a') || c > rune('f') {
+							goto l265
+						}
+						position++
+						goto l263
+					l265:
+						position, tokenIndex, depth = position263, tokenIndex263, depth263
+						if c := buffer[position]; c < rune('A') || c > rune('F') {
+							goto l256
+						}
+						position++
+					}
+				l263:
+				l261:
+					{
+						position262, tokenIndex262, depth262 := position, tokenIndex, depth
+						{
+							position266, tokenIndex266, depth266 := position, tokenIndex, depth
+							if c := buffer[position]; c < rune('0') || c > rune('9') {
+								goto l267
+							}
+							position++
+							goto l266
+						l267:
+							position, tokenIndex, depth = position266, tokenIndex266, depth266
+							if c := buffer[position]; c < rune('a') || c > rune('f') {
+								goto l268
+							}
+							position++
+							goto l266
+						l268:
+							position, tokenIndex, depth = position266, tokenIndex266, depth266
+							if c := buffer[position]; c < rune('A') || c > rune('F') {
+								goto l262
+							}
+							position++
+						}
+					l266:
+						goto l261
+					l262:
+						position, tokenIndex, depth = position262, tokenIndex262, depth262
+					}
+				l269:
+					{
+						position270, tokenIndex270, depth270 := position, tokenIndex, depth
+						if buffer[position] != rune('_') {
+							goto l270
+						}
+						position++
+						{
+							position273, tokenIndex273, depth273 := position, tokenIndex, depth
+							if c := buffer[position]; c < rune('0') || c > rune('9') {
+								goto l274
+							}
+							position++
+							goto l273
+						l274:
+							position, tokenIndex, depth = position273, tokenIndex273, depth273
+							if c := buffer[position]; c < rune('a') || c > rune('f') {
+								goto l275
+							}
+							position++
+							goto l273
+						l275:
+							position, tokenIndex, depth = position273, tokenIndex273, depth273
+							if c := buffer[position]; c < rune('A') || c > rune('F') {
+								goto l270
+							}
+							position++
+						}
+					l273:
+					l271:
+						{
+							position272, tokenIndex272, depth272 := position, tokenIndex, depth
+							{
+								position276, tokenIndex276, depth276 := position, tokenIndex, depth
+								if c := buffer[position]; c < rune('0') || c > rune('9') {
+									goto l277
+								}
+								position++
+								goto l276
+							l277:
+								position, tokenIndex, depth = position276, tokenIndex276, depth276
+								if c := buffer[position]; c < rune('a') || c > rune('f') {
+									goto l278
+								}
+								position++
+								goto l276
+							l278:
+								position, tokenIndex, depth = position276, tokenIndex276, depth276
+								if c := buffer[position]; c < rune('A') || c > rune('F') {
+									goto l272
+								}
+								position++
+							}
+						l276:
+							goto l271
+						l272:
+							position, tokenIndex, depth = position272, tokenIndex272, depth272
+						}
+						goto l269
+					l270:
+						position, tokenIndex, depth = position270, tokenIndex270, depth270
+					}
+					goto l255
+				l256:
+					position, tokenIndex, depth = position255, tokenIndex255, depth255
+					{
+						position280, tokenIndex280, depth280 := position, tokenIndex, depth
+						if buffer[position] != rune('0') {
+							goto l281
+						}
+						position++
+						if buffer[position] != rune('o') {
+							goto l281
+						}
+						position++
+						goto l280
+					l281:
+						position, tokenIndex, depth = position280, tokenIndex280, depth280
+						if buffer[position] != rune('0') {
+							goto l279
+						}
+						position++
+						if buffer[position] != rune('O') {
+							goto l279
+						}
+						position++
+					}
+				l280:
+					{
+						position282, tokenIndex282, depth282 := position, tokenIndex, depth
+						if buffer[position] != rune('_') {
+							goto l282
+						}
+						position++
+						goto l283
+					l282:
+						position, tokenIndex, depth = position282, tokenIndex282, depth282
+					}
+				l283:
+					if c := buffer[position]; c < rune('0') || c > rune('7') {
+						goto l279
 					}
 					position++
-				l238:
+				l284:
 					{
-						position239, tokenIndex239, depth239 := position, tokenIndex, depth
-						if c := buffer[position]; c < rune('0') || c > rune('9') {
-							goto l239
+						position285, tokenIndex285, depth285 := position, tokenIndex, depth
+						if c := buffer[position]; c < rune('0') || c > rune('7') {
+							goto l285
 						}
 						position++
-						goto l238
-					l239:
-						position, tokenIndex, depth = position239, tokenIndex239, depth239
+						goto l284
+					l285:
+						position, tokenIndex, depth = position285, tokenIndex285, depth285
+					}
+				l286:
+					{
+						position287, tokenIndex287, depth287 := position, tokenIndex, depth
+						if buffer[position] != rune('_') {
+							goto l287
+						}
+						position++
+						if c := buffer[position]; c < rune('0') || c > rune('7') {
+							goto l287
+						}
+						position++
+					l288:
+						{
+							position289, tokenIndex289, depth289 := position, tokenIndex, depth
+							if c := buffer[position]; c < rune('0') || c > rune('7') {
+								goto l289
+							}
+							position++
+							goto l288
+						l289:
+							position, tokenIndex, depth = position289, tokenIndex289, depth289
+						}
+						goto l286
+					l287:
+						position, tokenIndex, depth = position287, tokenIndex287, depth287
+					}
+					goto l255
+				l279:
+					position, tokenIndex, depth = position255, tokenIndex255, depth255
+					{
+						position290, tokenIndex290, depth290 := position, tokenIndex, depth
+						if buffer[position] != rune('0') {
+							goto l291
+						}
+						position++
+						if buffer[position] != rune('b') {
+							goto l291
+						}
+						position++
+						goto l290
+					l291:
+						position, tokenIndex, depth = position290, tokenIndex290, depth290
+						if buffer[position] != rune('0') {
+							goto l253
+						}
+						position++
+						if buffer[position] != rune('B') {
+							goto l253
+						}
+						position++
+					}
+				l290:
+					{
+						position292, tokenIndex292, depth292 := position, tokenIndex, depth
+						if buffer[position] != rune('_') {
+							goto l292
+						}
+						position++
+						goto l293
+					l292:
+						position, tokenIndex, depth = position292, tokenIndex292, depth292
+					}
+				l293:
+					{
+						position296, tokenIndex296, depth296 := position, tokenIndex, depth
+						if buffer[position] != rune('0') {
+							goto l297
+						}
+						position++
+						goto l296
+					l297:
+						position, tokenIndex, depth = position296, tokenIndex296, depth296
+						if buffer[position] != rune('1') {
+							goto l253
+						}
+						position++
+					}
+				l296:
+				l294:
+					{
+						position295, tokenIndex295, depth295 := position, tokenIndex, depth
+						{
+							position298, tokenIndex298, depth298 := position, tokenIndex, depth
+							if buffer[position] != rune('0') {
+								goto l299
+							}
+							position++
+							goto l298
+						l299:
+							position, tokenIndex, depth = position298, tokenIndex298, depth298
+							if buffer[position] != rune('1') {
+								goto l295
+							}
+							position++
+						}
+					l298:
+						goto l294
+					l295:
+						position, tokenIndex, depth = position295, tokenIndex295, depth295
+					}
+				l300:
+					{
+						position301, tokenIndex301, depth301 := position, tokenIndex, depth
+						if buffer[position] != rune('_') {
+							goto l301
+						}
+						position++
+						{
+							position304, tokenIndex304, depth304 := position, tokenIndex, depth
+							if buffer[position] != rune('0') {
+								goto l305
+							}
+							position++
+							goto l304
+						l305:
+							position, tokenIndex, depth = position304, tokenIndex304, depth304
+							if buffer[position] != rune('1') {
+								goto l301
+							}
+							position++
+						}
+					l304:
+					l302:
+						{
+							position303, tokenIndex303, depth303 := position, tokenIndex, depth
+							{
+								position306, tokenIndex306, depth306 := position, tokenIndex, depth
+								if buffer[position] != rune('0') {
+									goto l307
+								}
+								position++
+								goto l306
+							l307:
+								position, tokenIndex, depth = position306, tokenIndex306, depth306
+								if buffer[position] != rune('1') {
+									goto l303
+								}
+								position++
+							}
+						l306:
+							goto l302
+						l303:
+							position, tokenIndex, depth = position303, tokenIndex303, depth303
+						}
+						goto l300
+					l301:
+						position, tokenIndex, depth = position301, tokenIndex301, depth301
 					}
-					goto l233
-				l232:
-					position, tokenIndex, depth = position232, tokenIndex232, depth232
 				}
-			l233:
+			l255:
 				{
-					position240, tokenIndex240, depth240 := position, tokenIndex, depth
-					if buffer[position] != rune(':') {
-						goto l240
-					}
-					position++
-					if buffer[position] != rune(':') {
-						goto l240
+					position308, tokenIndex308, depth308 := position, tokenIndex, depth
+					{
+						position309, tokenIndex309, depth309 := position, tokenIndex, depth
+						if c := buffer[position]; c < rune('a') || c > rune('z') {
+							goto l310
+						}
+						position++
+						goto l309
+					l310:
+						position, tokenIndex, depth = position309, tokenIndex309, depth309
+						if c := buffer[position]; c < rune('A') || c > rune('Z') {
+							goto l311
+						}
+						position++
+						goto l309
+					l311:
+						position, tokenIndex, depth = position309, tokenIndex309, depth309
+						if c := buffer[position]; c < rune('0') || c > rune('9') {
+							goto l312
+						}
+						position++
+						goto l309
+					l312:
+						position, tokenIndex, depth = position309, tokenIndex309, depth309
+						if buffer[position] != rune('_') {
+							goto l308
+						}
+						position++
 					}
-					position++
-					goto l220
-				l240:
-					position, tokenIndex, depth = position240, tokenIndex240, depth240
+				l309:
+					goto l253
+				l308:
+					position, tokenIndex, depth = position308, tokenIndex308, depth308
 				}
 				depth--
-				add(ruleNumber, position221)
+				add(rulePrefixedInteger, position254)
 			}
 			return true
-		l220:
-			position, tokenIndex, depth = position220, tokenIndex220, depth220
+		l253:
+			position, tokenIndex, depth = position253, tokenIndex253, depth253
 			return false
 		},
-		/* 56 String <- <('"' (('\\' '"') / (!'"' .))* '"')> */
+		/* 60 String <- <('"' (('\\' .) / (!'"' .))* '"')> */
 		func() bool {
-			position241, tokenIndex241, depth241 := position, tokenIndex, depth
+			position313, tokenIndex313, depth313 := position, tokenIndex, depth
 			{
-				position242 := position
+				position314 := position
 				depth++
 				if buffer[position] != rune('"') {
-					goto l241
+					goto l313
 				}
 				position++
-			l243:
+			l315:
 				{
-					position244, tokenIndex244, depth244 := position, tokenIndex, depth
+					position316, tokenIndex316, depth316 := position, tokenIndex, depth
 					{
-						position245, tokenIndex245, depth245 := position, tokenIndex, depth
+						position317, tokenIndex317, depth317 := position, tokenIndex, depth
 						if buffer[position] != rune('\\') {
-							goto l246
+							goto l318
 						}
 						position++
-						if buffer[position] != rune('"') {
-							goto l246
+						if !matchDot() {
+							goto l318
 						}
-						position++
-						goto l245
-					l246:
-						position, tokenIndex, depth = position245, tokenIndex245, depth245
+						goto l317
+					l318:
+						position, tokenIndex, depth = position317, tokenIndex317, depth317
 						{
-							position247, tokenIndex247, depth247 := position, tokenIndex, depth
+							position319, tokenIndex319, depth319 := position, tokenIndex, depth
 							if buffer[position] != rune('"') {
-								goto l247
+								goto l319
 							}
 							position++
-							goto l244
-						l247:
-							position, tokenIndex, depth = position247, tokenIndex247, depth247
+							goto l316
+						l319:
+							position, tokenIndex, depth = position319, tokenIndex319, depth319
 						}
 						if !matchDot() {
-							goto l244
+							goto l316
 						}
 					}
-				l245:
-					goto l243
-				l244:
-					position, tokenIndex, depth = position244, tokenIndex244, depth244
+				l317:
+					goto l315
+				l316:
+					position, tokenIndex, depth = position316, tokenIndex316, depth316
 				}
 				if buffer[position] != rune('"') {
-					goto l241
+					goto l313
 				}
 				position++
 				depth--
-				add(ruleString, position242)
+				add(ruleString, position314)
 			}
 			return true
-		l241:
-			position, tokenIndex, depth = position241, tokenIndex241, depth241
+		l313:
+			position, tokenIndex, depth = position313, tokenIndex313, depth313
 			return false
 		},
-		/* 57 Boolean <- <(('t' 'r' 'u' 'e') / ('f' 'a' 'l' 's' 'e'))> */
+		/* 61 RawString <- <('`' (!'`' .)* '`')> */
 		func() bool {
-			position248, tokenIndex248, depth248 := position, tokenIndex, depth
+			position320, tokenIndex320, depth320 := position, tokenIndex, depth
 			{
-				position249 := position
+				position321 := position
 				depth++
+				if buffer[position] != rune('`') {
+					goto l320
+				}
+				position++
+			l322:
 				{
-					position250, tokenIndex250, depth250 := position, tokenIndex, depth
+					position323, tokenIndex323, depth323 := position, tokenIndex, depth
+					{
+						position324, tokenIndex324, depth324 := position, tokenIndex, depth
+						if buffer[position] != rune('`') {
+							goto l324
+						}
+						position++
+						goto l323
+					l324:
+						position, tokenIndex, depth = position324, tokenIndex324, depth324
+					}
+					if !matchDot() {
+						goto l323
+					}
+					goto l322
+				l323:
+					position, tokenIndex, depth = position323, tokenIndex323, depth323
+				}
+				if buffer[position] != rune('`') {
+					goto l320
+				}
+				position++
+				depth--
+				add(ruleRawString, position321)
+			}
+			return true
+		l320:
+			position, tokenIndex, depth = position320, tokenIndex320, depth320
+			return false
+		},
+		/* 62 Boolean <- <(('t' 'r' 'u' 'e') / ('f' 'a' 'l' 's' 'e'))> */
+		func() bool {
+			position325, tokenIndex325, depth325 := position, tokenIndex, depth
+			{
+				position326 := position
+				depth++
+				{
+					position327, tokenIndex327, depth327 := position, tokenIndex, depth
 					if buffer[position] != rune('t') {
-						goto l251
+						goto l328
 					}
 					position++
 					if buffer[position] != rune('r') {
-						goto l251
+						goto l328
 					}
 					position++
 					if buffer[position] != rune('u') {
-						goto l251
+						goto l328
 					}
 					position++
 					if buffer[position] != rune('e') {
-						goto l251
+						goto l328
 					}
 					position++
-					goto l250
-				l251:
-					position, tokenIndex, depth = position250, tokenIndex250, depth250
+					goto l327
+				l328:
+					position, tokenIndex, depth = position327, tokenIndex327, depth327
 					if buffer[position] != rune('f') {
-						goto l248
+						goto l325
 					}
 					position++
 					if buffer[position] != rune('a') {
-						goto l248
+						goto l325
 					}
 					position++
 					if buffer[position] != rune('l') {
-						goto l248
+						goto l325
 					}
 					position++
 					if buffer[position] != rune('s') {
-						goto l248
+						goto l325
 					}
 					position++
 					if buffer[position] != rune('e') {
-						goto l248
+						goto l325
 					}
 					position++
 				}
-			l250:
+			l327:
 				depth--
-				add(ruleBoolean, position249)
+				add(ruleBoolean, position326)
 			}
 			return true
-		l248:
-			position, tokenIndex, depth = position248, tokenIndex248, depth248
+		l325:
+			position, tokenIndex, depth = position325, tokenIndex325, depth325
 			return false
 		},
-		/* 58 Nil <- <(('n' 'i' 'l') / '~')> */
+		/* 63 Nil <- <(('n' 'i' 'l') / '~')> */
 		func() bool {
-			position252, tokenIndex252, depth252 := position, tokenIndex, depth
+			position329, tokenIndex329, depth329 := position, tokenIndex, depth
 			{
-				position253 := position
+				position330 := position
 				depth++
 				{
-					position254, tokenIndex254, depth254 := position, tokenIndex, depth
+					position331, tokenIndex331, depth331 := position, tokenIndex, depth
 					if buffer[position] != rune('n') {
-						goto l255
+						goto l332
 					}
 					position++
 					if buffer[position] != rune('i') {
-						goto l255
+						goto l332
 					}
 					position++
 					if buffer[position] != rune('l') {
-						goto l255
+						goto l332
 					}
 					position++
-					goto l254
-				l255:
-					position, tokenIndex, depth = position254, tokenIndex254, depth254
+					goto l331
+				l332:
+					position, tokenIndex, depth = position331, tokenIndex331, depth331
 					if buffer[position] != rune('~') {
-						goto l252
+						goto l329
 					}
 					position++
 				}
-			l254:
+			l331:
 				depth--
-				add(ruleNil, position253)
+				add(ruleNil, position330)
 			}
 			return true
-		l252:
-			position, tokenIndex, depth = position252, tokenIndex252, depth252
+		l329:
+			position, tokenIndex, depth = position329, tokenIndex329, depth329
 			return false
 		},
-		/* 59 Undefined <- <('~' '~')> */
+		/* 64 Undefined <- <('~' '~')> */
 		func() bool {
-			position256, tokenIndex256, depth256 := position, tokenIndex, depth
+			position333, tokenIndex333, depth333 := position, tokenIndex, depth
 			{
-				position257 := position
+				position334 := position
 				depth++
 				if buffer[position] != rune('~') {
-					goto l256
+					goto l333
 				}
 				position++
 				if buffer[position] != rune('~') {
-					goto l256
+					goto l333
 				}
 				position++
 				depth--
-				add(ruleUndefined, position257)
+				add(ruleUndefined, position334)
 			}
 			return true
-		l256:
-			position, tokenIndex, depth = position256, tokenIndex256, depth256
+		l333:
+			position, tokenIndex, depth = position333, tokenIndex333, depth333
 			return false
 		},
-		/* 60 Symbol <- <('$' Name)> */
+		/* 65 Symbol <- <('$' Name)> */
 		func() bool {
-			position258, tokenIndex258, depth258 := position, tokenIndex, depth
+			position335, tokenIndex335, depth335 := position, tokenIndex, depth
 			{
-				position259 := position
+				position336 := position
 				depth++
 				if buffer[position] != rune('$') {
-					goto l258
+					goto l335
 				}
 				position++
 				if !_rules[ruleName]() {
-					goto l258
+					goto l335
 				}
 				depth--
-				add(ruleSymbol, position259)
+				add(ruleSymbol, position336)
 			}
 			return true
-		l258:
-			position, tokenIndex, depth = position258, tokenIndex258, depth258
+		l335:
+			position, tokenIndex, depth = position335, tokenIndex335, depth335
 			return false
 		},
-		/* 61 List <- <(StartList ExpressionList? ']')> */
+		/* 66 List <- <(StartList ExpressionList? ']')> */
 		func() bool {
-			position260, tokenIndex260, depth260 := position, tokenIndex, depth
+			position337, tokenIndex337, depth337 := position, tokenIndex, depth
 			{
-				position261 := position
+				position338 := position
 				depth++
 				if !_rules[ruleStartList]() {
-					goto l260
+					goto l337
 				}
 				{
-					position262, tokenIndex262, depth262 := position, tokenIndex, depth
+					position339, tokenIndex339, depth339 := position, tokenIndex, depth
 					if !_rules[ruleExpressionList]() {
-						goto l262
+						goto l339
 					}
-					goto l263
-				l262:
-					position, tokenIndex, depth = position262, tokenIndex262, depth262
+					goto l340
+				l339:
+					position, tokenIndex, depth = position339, tokenIndex339, depth339
 				}
-			l263:
+			l340:
 				if buffer[position] != rune(']') {
-					goto l260
+					goto l337
 				}
 				position++
 				depth--
-				add(ruleList, position261)
+				add(ruleList, position338)
 			}
 			return true
-		l260:
-			position, tokenIndex, depth = position260, tokenIndex260, depth260
+		l337:
+			position, tokenIndex, depth = position337, tokenIndex337, depth337
 			return false
 		},
-		/* 62 StartList <- <('[' ws)> */
+		/* 67 StartList <- <('[' ws)> */
 		func() bool {
-			position264, tokenIndex264, depth264 := position, tokenIndex, depth
+			position341, tokenIndex341, depth341 := position, tokenIndex, depth
 			{
-				position265 := position
+				position342 := position
 				depth++
 				if buffer[position] != rune('[') {
-					goto l264
+					goto l341
 				}
 				position++
 				if !_rules[rulews]() {
-					goto l264
+					goto l341
 				}
 				depth--
-				add(ruleStartList, position265)
+				add(ruleStartList, position342)
 			}
 			return true
-		l264:
-			position, tokenIndex, depth = position264, tokenIndex264, depth264
+		l341:
+			position, tokenIndex, depth = position341, tokenIndex341, depth341
 			return false
 		},
-		/* 63 Map <- <(CreateMap ws Assignments? '}')> */
+		/* 68 Map <- <(CreateMap ws Assignments? '}')> */
 		func() bool {
-			position266, tokenIndex266, depth266 := position, tokenIndex, depth
+			position343, tokenIndex343, depth343 := position, tokenIndex, depth
 			{
-				position267 := position
+				position344 := position
 				depth++
 				if !_rules[ruleCreateMap]() {
-					goto l266
+					goto l343
 				}
 				if !_rules[rulews]() {
-					goto l266
+					goto l343
 				}
 				{
-					position268, tokenIndex268, depth268 := position, tokenIndex, depth
+					position345, tokenIndex345, depth345 := position, tokenIndex, depth
 					if !_rules[ruleAssignments]() {
-						goto l268
+						goto l345
 					}
-					goto l269
-				l268:
-					position, tokenIndex, depth = position268, tokenIndex268, depth268
+					goto l346
+				l345:
+					position, tokenIndex, depth = position345, tokenIndex345, depth345
 				}
-			l269:
+			l346:
 				if buffer[position] != rune('}') {
-					goto l266
+					goto l343
 				}
 				position++
 				depth--
-				add(ruleMap, position267)
+				add(ruleMap, position344)
 			}
 			return true
-		l266:
-			position, tokenIndex, depth = position266, tokenIndex266, depth266
+		l343:
+			position, tokenIndex, depth = position343, tokenIndex343, depth343
 			return false
 		},
-		/* 64 CreateMap <- <'{'> */
+		/* 69 CreateMap <- <'{'> */
 		func() bool {
-			position270, tokenIndex270, depth270 := position, tokenIndex, depth
+			position347, tokenIndex347, depth347 := position, tokenIndex, depth
 			{
-				position271 := position
+				position348 := position
 				depth++
 				if buffer[position] != rune('{') {
-					goto l270
+					goto l347
 				}
 				position++
 				depth--
-				add(ruleCreateMap, position271)
+				add(ruleCreateMap, position348)
 			}
 			return true
-		l270:
-			position, tokenIndex, depth = position270, tokenIndex270, depth270
+		l347:
+			position, tokenIndex, depth = position347, tokenIndex347, depth347
 			return false
 		},
-		/* 65 Assignments <- <(Assignment (',' Assignment)*)> */
+		/* 70 Assignments <- <(Assignment (',' Assignment)*)> */
 		func() bool {
-			position272, tokenIndex272, depth272 := position, tokenIndex, depth
+			position349, tokenIndex349, depth349 := position, tokenIndex, depth
 			{
-				position273 := position
+				position350 := position
 				depth++
 				if !_rules[ruleAssignment]() {
-					goto l272
+					goto l349
 				}
-			l274:
+			l351:
 				{
-					position275, tokenIndex275, depth275 := position, tokenIndex, depth
+					position352, tokenIndex352, depth352 := position, tokenIndex, depth
 					if buffer[position] != rune(',') {
-						goto l275
+						goto l352
 					}
 					position++
 					if !_rules[ruleAssignment]() {
-						goto l275
+						goto l352
 					}
-					goto l274
-				l275:
-					position, tokenIndex, depth = position275, tokenIndex275, depth275
+					goto l351
+				l352:
+					position, tokenIndex, depth = position352, tokenIndex352, depth352
 				}
 				depth--
-				add(ruleAssignments, position273)
+				add(ruleAssignments, position350)
 			}
 			return true
-		l272:
-			position, tokenIndex, depth = position272, tokenIndex272, depth272
+		l349:
+			position, tokenIndex, depth = position349, tokenIndex349, depth349
 			return false
 		},
-		/* 66 Assignment <- <(Expression '=' Expression)> */
+		/* 71 Assignment <- <(Expression '=' Expression)> */
 		func() bool {
-			position276, tokenIndex276, depth276 := position, tokenIndex, depth
+			position353, tokenIndex353, depth353 := position, tokenIndex, depth
 			{
-				position277 := position
+				position354 := position
 				depth++
 				if !_rules[ruleExpression]() {
-					goto l276
+					goto l353
 				}
 				if buffer[position] != rune('=') {
-					goto l276
+					goto l353
 				}
 				position++
 				if !_rules[ruleExpression]() {
-					goto l276
+					goto l353
 				}
 				depth--
-				add(ruleAssignment, position277)
+				add(ruleAssignment, position354)
 			}
 			return true
-		l276:
-			position, tokenIndex, depth = position276, tokenIndex276, depth276
+		l353:
+			position, tokenIndex, depth = position353, tokenIndex353, depth353
 			return false
 		},
-		/* 67 Merge <- <(RefMerge / SimpleMerge)> */
+		/* 72 Merge <- <(RefMerge / SimpleMerge)> */
 		func() bool {
-			position278, tokenIndex278, depth278 := position, tokenIndex, depth
+			position355, tokenIndex355, depth355 := position, tokenIndex, depth
 			{
-				position279 := position
+				position356 := position
 				depth++
 				{
-					position280, tokenIndex280, depth280 := position, tokenIndex, depth
+					position357, tokenIndex357, depth357 := position, tokenIndex, depth
 					if !_rules[ruleRefMerge]() {
-						goto l281
+						goto l358
 					}
-					goto l280
-				l281:
-					position, tokenIndex, depth = position280, tokenIndex280, depth280
+					goto l357
+				l358:
+					position, tokenIndex, depth = position357, tokenIndex357, depth357
 					if !_rules[ruleSimpleMerge]() {
-						goto l278
+						goto l355
 					}
 				}
-			l280:
+			l357:
 				depth--
-				add(ruleMerge, position279)
+				add(ruleMerge, position356)
 			}
 			return true
-		l278:
-			position, tokenIndex, depth = position278, tokenIndex278, depth278
+		l355:
+			position, tokenIndex, depth = position355, tokenIndex355, depth355
 			return false
 		},
-		/* 68 RefMerge <- <('m' 'e' 'r' 'g' 'e' !(req_ws Required) (req_ws (Replace / On))? req_ws Reference)> */
+		/* 73 RefMerge <- <('m' 'e' 'r' 'g' 'e' !(req_ws Required) (req_ws (Replace / On))? req_ws Reference)> */
 		func() bool {
-			position282, tokenIndex282, depth282 := position, tokenIndex, depth
+			position359, tokenIndex359, depth359 := position, tokenIndex, depth
 			{
-				position283 := position
+				position360 := position
 				depth++
 				if buffer[position] != rune('m') {
-					goto l282
+					goto l359
 				}
 				position++
 				if buffer[position] != rune('e') {
-					goto l282
+					goto l359
 				}
 				position++
 				if buffer[position] != rune('r') {
-					goto l282
+					goto l359
 				}
 				position++
 				if buffer[position] != rune('g') {
-					goto l282
+					goto l359
 				}
 				position++
 				if buffer[position] != rune('e') {
-					goto l282
+					goto l359
 				}
 				position++
 				{
-					position284, tokenIndex284, depth284 := position, tokenIndex, depth
+					position361, tokenIndex361, depth361 := position, tokenIndex, depth
 					if !_rules[rulereq_ws]() {
-						goto l284
+						goto l361
 					}
 					if !_rules[ruleRequired]() {
-						goto l284
+						goto l361
 					}
-					goto l282
-				l284:
-					position, tokenIndex, depth = position284, tokenIndex284, depth284
+					goto l359
+				l361:
+					position, tokenIndex, depth = position361, tokenIndex361, depth361
 				}
 				{
-					position285, tokenIndex285, depth285 := position, tokenIndex, depth
+					position362, tokenIndex362, depth362 := position, tokenIndex, depth
 					if !_rules[rulereq_ws]() {
-						goto l285
+						goto l362
 					}
 					{
-						position287, tokenIndex287, depth287 := position, tokenIndex, depth
+						position364, tokenIndex364, depth364 := position, tokenIndex, depth
 						if !_rules[ruleReplace]() {
-							goto l288
+							goto l365
 						}
-						goto l287
-					l288:
-						position, tokenIndex, depth = position287, tokenIndex287, depth287
+						goto l364
+					l365:
+						position, tokenIndex, depth = position364, tokenIndex364, depth364
 						if !_rules[ruleOn]() {
-							goto l285
+							goto l362
 						}
 					}
-				l287:
-					goto l286
-				l285:
-					position, tokenIndex, depth = position285, tokenIndex285, depth285
+				l364:
+					goto l363
+				l362:
+					position, tokenIndex, depth = position362, tokenIndex362, depth362
 				}
-			l286:
+			l363:
 				if !_rules[rulereq_ws]() {
-					goto l282
+					goto l359
 				}
 				if !_rules[ruleReference]() {
-					goto l282
+					goto l359
 				}
 				depth--
-				add(ruleRefMerge, position283)
+				add(ruleRefMerge, position360)
 			}
 			return true
-		l282:
-			position, tokenIndex, depth = position282, tokenIndex282, depth282
+		l359:
+			position, tokenIndex, depth = position359, tokenIndex359, depth359
 			return false
 		},
-		/* 69 SimpleMerge <- <('m' 'e' 'r' 'g' 'e' !'(' (req_ws (Replace / Required / On))?)> */
+		/* 74 SimpleMerge <- <('m' 'e' 'r' 'g' 'e' !('(' / ([a-z] / [A-Z] / [0-9] / '_' / '-')) (req_ws (Replace / Required / On))?)> */
 		func() bool {
-			position289, tokenIndex289, depth289 := position, tokenIndex, depth
+			position366, tokenIndex366, depth366 := position, tokenIndex, depth
 			{
-				position290 := position
+				position367 := position
 				depth++
 				if buffer[position] != rune('m') {
-					goto l289
+					goto l366
 				}
 				position++
 				if buffer[position] != rune('e') {
-					goto l289
+					goto l366
 				}
 				position++
 				if buffer[position] != rune('r') {
-					goto l289
+					goto l366
 				}
 				position++
 				if buffer[position] != rune('g') {
-					goto l289
+					goto l366
 				}
 				position++
 				if buffer[position] != rune('e') {
-					goto l289
+					goto l366
 				}
 				position++
 				{
-					position291, tokenIndex291, depth291 := position, tokenIndex, depth
-					if buffer[position] != rune('(') {
-						goto l291
+					position368, tokenIndex368, depth368 := position, tokenIndex, depth
+					{
+						position369, tokenIndex369, depth369 := position, tokenIndex, depth
+						if buffer[position] != rune('(') {
+							goto l370
+						}
+						position++
+						goto l369
+					l370:
+						position, tokenIndex, depth = position369, tokenIndex369, depth369
+						{
+							position371, tokenIndex371, depth371 := position, tokenIndex, depth
+							if c := buffer[position]; c < rune('a') || c > rune('z') {
+								goto l372
+							}
+							position++
+							goto l371
+						l372:
+							position, tokenIndex, depth = position371, tokenIndex371, depth371
+							if c := buffer[position]; c < rune('A') || c > rune('Z') {
+								goto l373
+							}
+							position++
+							goto l371
+						l373:
+							position, tokenIndex, depth = position371, tokenIndex371, depth371
+							if c := buffer[position]; c < rune('0') || c > rune('9') {
+								goto l374
+							}
+							position++
+							goto l371
+						l374:
+							position, tokenIndex, depth = position371, tokenIndex371, depth371
+							if buffer[position] != rune('_') {
+								goto l375
+							}
+							position++
+							goto l371
+						l375:
+							position, tokenIndex, depth = position371, tokenIndex371, depth371
+							if buffer[position] != rune('-') {
+								goto l368
+							}
+							position++
+						}
+					l371:
 					}
-					position++
-					goto l289
-				l291:
-					position, tokenIndex, depth = position291, tokenIndex291, depth291
+				l369:
+					goto l366
+				l368:
+					position, tokenIndex, depth = position368, tokenIndex368, depth368
 				}
 				{
-					position292, tokenIndex292, depth292 := position, tokenIndex, depth
+					position376, tokenIndex376, depth376 := position, tokenIndex, depth
 					if !_rules[rulereq_ws]() {
-						goto l292
+						goto l376
 					}
 					{
-						position294, tokenIndex294, depth294 := position, tokenIndex, depth
+						position378, tokenIndex378, depth378 := position, tokenIndex, depth
 						if !_rules[ruleReplace]() {
-							goto l295
+							goto l379
 						}
-						goto l294
-					l295:
-						position, tokenIndex, depth = position294, tokenIndex294, depth294
+						goto l378
+					l379:
+						position, tokenIndex, depth = position378, tokenIndex378, depth378
 						if !_rules[ruleRequired]() {
-							goto l296
+							goto l380
 						}
-						goto l294
-					l296:
-						position, tokenIndex, depth = position294, tokenIndex294, depth294
+						goto l378
+					l380:
+						position, tokenIndex, depth = position378, tokenIndex378, depth378
 						if !_rules[ruleOn]() {
-							goto l292
+							goto l376
 						}
 					}
-				l294:
-					goto l293
-				l292:
-					position, tokenIndex, depth = position292, tokenIndex292, depth292
+				l378:
+					goto l377
+				l376:
+					position, tokenIndex, depth = position376, tokenIndex376, depth376
 				}
-			l293:
+			l377:
 				depth--
-				add(ruleSimpleMerge, position290)
+				add(ruleSimpleMerge, position367)
 			}
 			return true
-		l289:
-			position, tokenIndex, depth = position289, tokenIndex289, depth289
+		l366:
+			position, tokenIndex, depth = position366, tokenIndex366, depth366
 			return false
 		},
-		/* 70 Replace <- <('r' 'e' 'p' 'l' 'a' 'c' 'e')> */
+		/* 75 Replace <- <('r' 'e' 'p' 'l' 'a' 'c' 'e')> */
 		func() bool {
-			position297, tokenIndex297, depth297 := position, tokenIndex, depth
+			position381, tokenIndex381, depth381 := position, tokenIndex, depth
 			{
-				position298 := position
+				position382 := position
 				depth++
 				if buffer[position] != rune('r') {
-					goto l297
+					goto l381
 				}
 				position++
 				if buffer[position] != rune('e') {
-					goto l297
+					goto l381
 				}
 				position++
 				if buffer[position] != rune('p') {
-					goto l297
+					goto l381
 				}
 				position++
 				if buffer[position] != rune('l') {
-					goto l297
+					goto l381
 				}
 				position++
 				if buffer[position] != rune('a') {
-					goto l297
+					goto l381
 				}
 				position++
 				if buffer[position] != rune('c') {
-					goto l297
+					goto l381
 				}
 				position++
 				if buffer[position] != rune('e') {
-					goto l297
+					goto l381
 				}
 				position++
 				depth--
-				add(ruleReplace, position298)
+				add(ruleReplace, position382)
 			}
 			return true
-		l297:
-			position, tokenIndex, depth = position297, tokenIndex297, depth297
+		l381:
+			position, tokenIndex, depth = position381, tokenIndex381, depth381
 			return false
 		},
-		/* 71 Required <- <('r' 'e' 'q' 'u' 'i' 'r' 'e' 'd')> */
+		/* 76 Required <- <('r' 'e' 'q' 'u' 'i' 'r' 'e' 'd')> */
 		func() bool {
-			position299, tokenIndex299, depth299 := position, tokenIndex, depth
+			position383, tokenIndex383, depth383 := position, tokenIndex, depth
 			{
-				position300 := position
+				position384 := position
 				depth++
 				if buffer[position] != rune('r') {
-					goto l299
+					goto l383
 				}
 				position++
 				if buffer[position] != rune('e') {
-					goto l299
+					goto l383
 				}
 				position++
 				if buffer[position] != rune('q') {
-					goto l299
+					goto l383
 				}
 				position++
 				if buffer[position] != rune('u') {
-					goto l299
+					goto l383
 				}
 				position++
 				if buffer[position] != rune('i') {
-					goto l299
+					goto l383
 				}
 				position++
 				if buffer[position] != rune('r') {
-					goto l299
+					goto l383
 				}
 				position++
 				if buffer[position] != rune('e') {
-					goto l299
+					goto l383
 				}
 				position++
 				if buffer[position] != rune('d') {
-					goto l299
+					goto l383
 				}
 				position++
 				depth--
-				add(ruleRequired, position300)
+				add(ruleRequired, position384)
 			}
 			return true
-		l299:
-			position, tokenIndex, depth = position299, tokenIndex299, depth299
+		l383:
+			position, tokenIndex, depth = position383, tokenIndex383, depth383
 			return false
 		},
-		/* 72 On <- <('o' 'n' req_ws Name)> */
+		/* 77 On <- <('o' 'n' req_ws Name)> */
 		func() bool {
-			position301, tokenIndex301, depth301 := position, tokenIndex, depth
+			position385, tokenIndex385, depth385 := position, tokenIndex, depth
 			{
-				position302 := position
+				position386 := position
 				depth++
 				if buffer[position] != rune('o') {
-					goto l301
+					goto l385
 				}
 				position++
 				if buffer[position] != rune('n') {
-					goto l301
+					goto l385
 				}
 				position++
 				if !_rules[rulereq_ws]() {
-					goto l301
+					goto l385
 				}
 				if !_rules[ruleName]() {
-					goto l301
+					goto l385
 				}
 				depth--
-				add(ruleOn, position302)
+				add(ruleOn, position386)
 			}
 			return true
-		l301:
-			position, tokenIndex, depth = position301, tokenIndex301, depth301
+		l385:
+			position, tokenIndex, depth = position385, tokenIndex385, depth385
 			return false
 		},
-		/* 73 Auto <- <('a' 'u' 't' 'o')> */
+		/* 78 Auto <- <('a' 'u' 't' 'o')> */
 		func() bool {
-			position303, tokenIndex303, depth303 := position, tokenIndex, depth
+			position387, tokenIndex387, depth387 := position, tokenIndex, depth
 			{
-				position304 := position
+				position388 := position
 				depth++
 				if buffer[position] != rune('a') {
-					goto l303
+					goto l387
 				}
 				position++
 				if buffer[position] != rune('u') {
-					goto l303
+					goto l387
 				}
 				position++
 				if buffer[position] != rune('t') {
-					goto l303
+					goto l387
 				}
 				position++
 				if buffer[position] != rune('o') {
-					goto l303
+					goto l387
 				}
 				position++
 				depth--
-				add(ruleAuto, position304)
+				add(ruleAuto, position388)
 			}
 			return true
-		l303:
-			position, tokenIndex, depth = position303, tokenIndex303, depth303
+		l387:
+			position, tokenIndex, depth = position387, tokenIndex387, depth387
 			return false
 		},
-		/* 74 Default <- <Action1> */
+		/* 79 Default <- <Action1> */
 		func() bool {
-			position305, tokenIndex305, depth305 := position, tokenIndex, depth
+			position389, tokenIndex389, depth389 := position, tokenIndex, depth
 			{
-				position306 := position
+				position390 := position
 				depth++
 				if !_rules[ruleAction1]() {
-					goto l305
+					goto l389
 				}
 				depth--
-				add(ruleDefault, position306)
+				add(ruleDefault, position390)
 			}
 			return true
-		l305:
-			position, tokenIndex, depth = position305, tokenIndex305, depth305
+		l389:
+			position, tokenIndex, depth = position389, tokenIndex389, depth389
 			return false
 		},
-		/* 75 Sync <- <('s' 'y' 'n' 'c' '[' Level7 ((((LambdaExpr LambdaExt) / (LambdaOrExpr LambdaOrExpr)) (('|' Expression) / Default)) / (LambdaOrExpr Default Default)) ']')> */
+		/* 80 Sync <- <('s' 'y' 'n' 'c' '[' Level7 ((((LambdaExpr LambdaExt) / (LambdaOrExpr LambdaOrExpr)) (('|' Expression) / Default)) / (LambdaOrExpr Default Default)) ']')> */
 		func() bool {
-			position307, tokenIndex307, depth307 := position, tokenIndex, depth
+			position391, tokenIndex391, depth391 := position, tokenIndex, depth
 			{
-				position308 := position
+				position392 := position
 				depth++
 				if buffer[position] != rune('s') {
-					goto l307
+					goto l391
 				}
 				position++
 				if buffer[position] != rune('y') {
-					goto l307
+					goto l391
 				}
 				position++
 				if buffer[position] != rune('n') {
-					goto l307
+					goto l391
 				}
 				position++
 				if buffer[position] != rune('c') {
-					goto l307
+					goto l391
 				}
 				position++
 				if buffer[position] != rune('[') {
-					goto l307
+					goto l391
 				}
 				position++
 				if !_rules[ruleLevel7]() {
-					goto l307
+					goto l391
 				}
 				{
-					position309, tokenIndex309, depth309 := position, tokenIndex, depth
+					position393, tokenIndex393, depth393 := position, tokenIndex, depth
 					{
-						position311, tokenIndex311, depth311 := position, tokenIndex, depth
+						position395, tokenIndex395, depth395 := position, tokenIndex, depth
 						if !_rules[ruleLambdaExpr]() {
-							goto l312
+							goto l396
 						}
 						if !_rules[ruleLambdaExt]() {
-							goto l312
+							goto l396
 						}
-						goto l311
-					l312:
-						position, tokenIndex, depth = position311, tokenIndex311, depth311
+						goto l395
+					l396:
+						position, tokenIndex, depth = position395, tokenIndex395, depth395
 						if !_rules[ruleLambdaOrExpr]() {
-							goto l310
+							goto l394
 						}
 						if !_rules[ruleLambdaOrExpr]() {
-							goto l310
+							goto l394
 						}
 					}
-				l311:
+				l395:
 					{
-						position313, tokenIndex313, depth313 := position, tokenIndex, depth
+						position397, tokenIndex397, depth397 := position, tokenIndex, depth
 						if buffer[position] != rune('|') {
-							goto l314
+							goto l398
 						}
 						position++
 						if !_rules[ruleExpression]() {
-							goto l314
+							goto l398
 						}
-						goto l313
-					l314:
-						position, tokenIndex, depth = position313, tokenIndex313, depth313
+						goto l397
+					l398:
+						position, tokenIndex, depth = position397, tokenIndex397, depth397
 						if !_rules[ruleDefault]() {
-							goto l310
+							goto l394
 						}
 					}
-				l313:
-					goto l309
-				l310:
-					position, tokenIndex, depth = position309, tokenIndex309, depth309
+				l397:
+					goto l393
+				l394:
+					position, tokenIndex, depth = position393, tokenIndex393, depth393
 					if !_rules[ruleLambdaOrExpr]() {
-						goto l307
+						goto l391
 					}
 					if !_rules[ruleDefault]() {
-						goto l307
+						goto l391
 					}
 					if !_rules[ruleDefault]() {
-						goto l307
+						goto l391
 					}
 				}
-			l309:
+			l393:
 				if buffer[position] != rune(']') {
-					goto l307
+					goto l391
 				}
 				position++
 				depth--
-				add(ruleSync, position308)
+				add(ruleSync, position392)
 			}
 			return true
-		l307:
-			position, tokenIndex, depth = position307, tokenIndex307, depth307
+		l391:
+			position, tokenIndex, depth = position391, tokenIndex391, depth391
 			return false
 		},
-		/* 76 LambdaExt <- <(',' Expression)> */
+		/* 81 LambdaExt <- <(',' Expression)> */
 		func() bool {
-			position315, tokenIndex315, depth315 := position, tokenIndex, depth
+			position399, tokenIndex399, depth399 := position, tokenIndex, depth
 			{
-				position316 := position
+				position400 := position
 				depth++
 				if buffer[position] != rune(',') {
-					goto l315
+					goto l399
 				}
 				position++
 				if !_rules[ruleExpression]() {
-					goto l315
+					goto l399
 				}
 				depth--
-				add(ruleLambdaExt, position316)
+				add(ruleLambdaExt, position400)
 			}
 			return true
-		l315:
-			position, tokenIndex, depth = position315, tokenIndex315, depth315
+		l399:
+			position, tokenIndex, depth = position399, tokenIndex399, depth399
 			return false
 		},
-		/* 77 LambdaOrExpr <- <(LambdaExpr / ('|' Expression))> */
+		/* 82 LambdaOrExpr <- <(LambdaExpr / ('|' Expression))> */
 		func() bool {
-			position317, tokenIndex317, depth317 := position, tokenIndex, depth
+			position401, tokenIndex401, depth401 := position, tokenIndex, depth
 			{
-				position318 := position
+				position402 := position
 				depth++
 				{
-					position319, tokenIndex319, depth319 := position, tokenIndex, depth
+					position403, tokenIndex403, depth403 := position, tokenIndex, depth
 					if !_rules[ruleLambdaExpr]() {
-						goto l320
+						goto l404
 					}
-					goto l319
-				l320:
-					position, tokenIndex, depth = position319, tokenIndex319, depth319
+					goto l403
+				l404:
+					position, tokenIndex, depth = position403, tokenIndex403, depth403
 					if buffer[position] != rune('|') {
-						goto l317
+						goto l401
 					}
 					position++
 					if !_rules[ruleExpression]() {
-						goto l317
+						goto l401
 					}
 				}
-			l319:
+			l403:
 				depth--
-				add(ruleLambdaOrExpr, position318)
+				add(ruleLambdaOrExpr, position402)
 			}
 			return true
-		l317:
-			position, tokenIndex, depth = position317, tokenIndex317, depth317
+		l401:
+			position, tokenIndex, depth = position401, tokenIndex401, depth401
 			return false
 		},
-		/* 78 Catch <- <('c' 'a' 't' 'c' 'h' '[' Level7 LambdaOrExpr ']')> */
+		/* 83 Catch <- <('c' 'a' 't' 'c' 'h' '[' Level7 LambdaOrExpr ']')> */
 		func() bool {
-			position321, tokenIndex321, depth321 := position, tokenIndex, depth
+			position405, tokenIndex405, depth405 := position, tokenIndex, depth
 			{
-				position322 := position
+				position406 := position
 				depth++
 				if buffer[position] != rune('c') {
-					goto l321
+					goto l405
 				}
 				position++
 				if buffer[position] != rune('a') {
-					goto l321
+					goto l405
 				}
 				position++
 				if buffer[position] != rune('t') {
-					goto l321
+					goto l405
 				}
 				position++
 				if buffer[position] != rune('c') {
-					goto l321
+					goto l405
 				}
 				position++
 				if buffer[position] != rune('h') {
-					goto l321
+					goto l405
 				}
 				position++
 				if buffer[position] != rune('[') {
-					goto l321
+					goto l405
 				}
 				position++
 				if !_rules[ruleLevel7]() {
-					goto l321
+					goto l405
 				}
 				if !_rules[ruleLambdaOrExpr]() {
-					goto l321
+					goto l405
 				}
 				if buffer[position] != rune(']') {
-					goto l321
+					goto l405
 				}
 				position++
 				depth--
-				add(ruleCatch, position322)
+				add(ruleCatch, position406)
 			}
 			return true
-		l321:
-			position, tokenIndex, depth = position321, tokenIndex321, depth321
+		l405:
+			position, tokenIndex, depth = position405, tokenIndex405, depth405
 			return false
 		},
-		/* 79 MapMapping <- <('m' 'a' 'p' '{' Level7 LambdaOrExpr '}')> */
+		/* 84 MapMapping <- <('m' 'a' 'p' '{' Level7 LambdaOrExpr '}')> */
 		func() bool {
-			position323, tokenIndex323, depth323 := position, tokenIndex, depth
+			position407, tokenIndex407, depth407 := position, tokenIndex, depth
 			{
-				position324 := position
+				position408 := position
 				depth++
 				if buffer[position] != rune('m') {
-					goto l323
+					goto l407
 				}
 				position++
 				if buffer[position] != rune('a') {
-					goto l323
+					goto l407
 				}
 				position++
 				if buffer[position] != rune('p') {
-					goto l323
+					goto l407
 				}
 				position++
 				if buffer[position] != rune('{') {
-					goto l323
+					goto l407
 				}
 				position++
 				if !_rules[ruleLevel7]() {
-					goto l323
+					goto l407
 				}
 				if !_rules[ruleLambdaOrExpr]() {
-					goto l323
+					goto l407
 				}
 				if buffer[position] != rune('}') {
-					goto l323
+					goto l407
 				}
 				position++
 				depth--
-				add(ruleMapMapping, position324)
+				add(ruleMapMapping, position408)
 			}
 			return true
-		l323:
-			position, tokenIndex, depth = position323, tokenIndex323, depth323
+		l407:
+			position, tokenIndex, depth = position407, tokenIndex407, depth407
 			return false
 		},
-		/* 80 Mapping <- <('m' 'a' 'p' '[' Level7 LambdaOrExpr ']')> */
+		/* 85 Mapping <- <('m' 'a' 'p' '[' Level7 LambdaOrExpr ']')> */
 		func() bool {
-			position325, tokenIndex325, depth325 := position, tokenIndex, depth
+			position409, tokenIndex409, depth409 := position, tokenIndex, depth
 			{
-				position326 := position
+				position410 := position
 				depth++
 				if buffer[position] != rune('m') {
-					goto l325
+					goto l409
 				}
 				position++
 				if buffer[position] != rune('a') {
-					goto l325
+					goto l409
 				}
 				position++
 				if buffer[position] != rune('p') {
-					goto l325
+					goto l409
 				}
 				position++
 				if buffer[position] != rune('[') {
-					goto l325
+					goto l409
 				}
 				position++
 				if !_rules[ruleLevel7]() {
-					goto l325
+					goto l409
 				}
 				if !_rules[ruleLambdaOrExpr]() {
-					goto l325
+					goto l409
 				}
 				if buffer[position] != rune(']') {
-					goto l325
+					goto l409
 				}
 				position++
 				depth--
-				add(ruleMapping, position326)
+				add(ruleMapping, position410)
 			}
 			return true
-		l325:
-			position, tokenIndex, depth = position325, tokenIndex325, depth325
+		l409:
+			position, tokenIndex, depth = position409, tokenIndex409, depth409
 			return false
 		},
-		/* 81 MapSelection <- <('s' 'e' 'l' 'e' 'c' 't' '{' Level7 LambdaOrExpr '}')> */
+		/* 86 MapSelection <- <('s' 'e' 'l' 'e' 'c' 't' '{' Level7 LambdaOrExpr '}')> */
 		func() bool {
-			position327, tokenIndex327, depth327 := position, tokenIndex, depth
+			position411, tokenIndex411, depth411 := position, tokenIndex, depth
 			{
-				position328 := position
+				position412 := position
 				depth++
 				if buffer[position] != rune('s') {
-					goto l327
+					goto l411
 				}
 				position++
 				if buffer[position] != rune('e') {
-					goto l327
+					goto l411
 				}
 				position++
 				if buffer[position] != rune('l') {
-					goto l327
+					goto l411
 				}
 				position++
 				if buffer[position] != rune('e') {
-					goto l327
+					goto l411
 				}
 				position++
 				if buffer[position] != rune('c') {
-					goto l327
+					goto l411
 				}
 				position++
 				if buffer[position] != rune('t') {
-					goto l327
+					goto l411
 				}
 				position++
 				if buffer[position] != rune('{') {
-					goto l327
+					goto l411
 				}
 				position++
 				if !_rules[ruleLevel7]() {
-					goto l327
+					goto l411
 				}
 				if !_rules[ruleLambdaOrExpr]() {
-					goto l327
+					goto l411
 				}
 				if buffer[position] != rune('}') {
-					goto l327
+					goto l411
 				}
 				position++
 				depth--
-				add(ruleMapSelection, position328)
+				add(ruleMapSelection, position412)
 			}
 			return true
-		l327:
-			position, tokenIndex, depth = position327, tokenIndex327, depth327
+		l411:
+			position, tokenIndex, depth = position411, tokenIndex411, depth411
 			return false
 		},
-		/* 82 Selection <- <('s' 'e' 'l' 'e' 'c' 't' '[' Level7 LambdaOrExpr ']')> */
+		/* 87 Selection <- <('s' 'e' 'l' 'e' 'c' 't' '[' Level7 LambdaOrExpr ']')> */
 		func() bool {
-			position329, tokenIndex329, depth329 := position, tokenIndex, depth
+			position413, tokenIndex413, depth413 := position, tokenIndex, depth
 			{
-				position330 := position
+				position414 := position
 				depth++
 				if buffer[position] != rune('s') {
-					goto l329
+					goto l413
 				}
 				position++
 				if buffer[position] != rune('e') {
-					goto l329
+					goto l413
 				}
 				position++
 				if buffer[position] != rune('l') {
-					goto l329
+					goto l413
 				}
 				position++
 				if buffer[position] != rune('e') {
-					goto l329
+					goto l413
 				}
 				position++
 				if buffer[position] != rune('c') {
-					goto l329
+					goto l413
 				}
 				position++
 				if buffer[position] != rune('t') {
-					goto l329
+					goto l413
 				}
 				position++
 				if buffer[position] != rune('[') {
-					goto l329
+					goto l413
 				}
 				position++
 				if !_rules[ruleLevel7]() {
-					goto l329
+					goto l413
 				}
 				if !_rules[ruleLambdaOrExpr]() {
-					goto l329
+					goto l413
 				}
 				if buffer[position] != rune(']') {
-					goto l329
+					goto l413
 				}
 				position++
 				depth--
-				add(ruleSelection, position330)
+				add(ruleSelection, position414)
 			}
 			return true
-		l329:
-			position, tokenIndex, depth = position329, tokenIndex329, depth329
+		l413:
+			position, tokenIndex, depth = position413, tokenIndex413, depth413
 			return false
 		},
-		/* 83 Sum <- <('s' 'u' 'm' '[' Level7 '|' Level7 LambdaOrExpr ']')> */
+		/* 88 Sum <- <('s' 'u' 'm' '[' Level7 '|' Level7 LambdaOrExpr ']')> */
 		func() bool {
-			position331, tokenIndex331, depth331 := position, tokenIndex, depth
+			position415, tokenIndex415, depth415 := position, tokenIndex, depth
 			{
-				position332 := position
+				position416 := position
 				depth++
 				if buffer[position] != rune('s') {
-					goto l331
+					goto l415
 				}
 				position++
 				if buffer[position] != rune('u') {
-					goto l331
+					goto l415
 				}
 				position++
 				if buffer[position] != rune('m') {
-					goto l331
+					goto l415
 				}
 				position++
 				if buffer[position] != rune('[') {
-					goto l331
+					goto l415
 				}
 				position++
 				if !_rules[ruleLevel7]() {
-					goto l331
+					goto l415
 				}
 				if buffer[position] != rune('|') {
-					goto l331
+					goto l415
 				}
 				position++
 				if !_rules[ruleLevel7]() {
-					goto l331
+					goto l415
 				}
 				if !_rules[ruleLambdaOrExpr]() {
-					goto l331
+					goto l415
 				}
 				if buffer[position] != rune(']') {
-					goto l331
+					goto l415
 				}
 				position++
 				depth--
-				add(ruleSum, position332)
+				add(ruleSum, position416)
 			}
 			return true
-		l331:
-			position, tokenIndex, depth = position331, tokenIndex331, depth331
+		l415:
+			position, tokenIndex, depth = position415, tokenIndex415, depth415
 			return false
 		},
-		/* 84 Lambda <- <('l' 'a' 'm' 'b' 'd' 'a' (LambdaRef / LambdaExpr))> */
+		/* 89 Lambda <- <('l' 'a' 'm' 'b' 'd' 'a' (LambdaRef / LambdaExpr))> */
 		func() bool {
-			position333, tokenIndex333, depth333 := position, tokenIndex, depth
+			position417, tokenIndex417, depth417 := position, tokenIndex, depth
 			{
-				position334 := position
+				position418 := position
 				depth++
 				if buffer[position] != rune('l') {
-					goto l333
+					goto l417
 				}
 				position++
 				if buffer[position] != rune('a') {
-					goto l333
+					goto l417
 				}
 				position++
 				if buffer[position] != rune('m') {
-					goto l333
+					goto l417
 				}
 				position++
 				if buffer[position] != rune('b') {
-					goto l333
+					goto l417
 				}
 				position++
 				if buffer[position] != rune('d') {
-					goto l333
+					goto l417
 				}
 				position++
 				if buffer[position] != rune('a') {
-					goto l333
+					goto l417
 				}
 				position++
 				{
-					position335, tokenIndex335, depth335 := position, tokenIndex, depth
+					position419, tokenIndex419, depth419 := position, tokenIndex, depth
 					if !_rules[ruleLambdaRef]() {
-						goto l336
+						goto l420
 					}
-					goto l335
-				l336:
-					position, tokenIndex, depth = position335, tokenIndex335, depth335
+					goto l419
+				l420:
+					position, tokenIndex, depth = position419, tokenIndex419, depth419
 					if !_rules[ruleLambdaExpr]() {
-						goto l333
+						goto l417
 					}
 				}
-			l335:
+			l419:
 				depth--
-				add(ruleLambda, position334)
+				add(ruleLambda, position418)
 			}
 			return true
-		l333:
-			position, tokenIndex, depth = position333, tokenIndex333, depth333
+		l417:
+			position, tokenIndex, depth = position417, tokenIndex417, depth417
 			return false
 		},
-		/* 85 LambdaRef <- <(req_ws Expression)> */
+		/* 90 LambdaRef <- <(req_ws Expression)> */
 		func() bool {
-			position337, tokenIndex337, depth337 := position, tokenIndex, depth
+			position421, tokenIndex421, depth421 := position, tokenIndex, depth
 			{
-				position338 := position
+				position422 := position
 				depth++
 				if !_rules[rulereq_ws]() {
-					goto l337
+					goto l421
 				}
 				if !_rules[ruleExpression]() {
-					goto l337
+					goto l421
 				}
 				depth--
-				add(ruleLambdaRef, position338)
+				add(ruleLambdaRef, position422)
 			}
 			return true
-		l337:
-			position, tokenIndex, depth = position337, tokenIndex337, depth337
+		l421:
+			position, tokenIndex, depth = position421, tokenIndex421, depth421
 			return false
 		},
-		/* 86 LambdaExpr <- <(ws Params ws ('-' '>') Expression)> */
+		/* 91 LambdaExpr <- <(ws Params ws ('-' '>') Expression)> */
 		func() bool {
-			position339, tokenIndex339, depth339 := position, tokenIndex, depth
+			position423, tokenIndex423, depth423 := position, tokenIndex, depth
 			{
-				position340 := position
+				position424 := position
 				depth++
 				if !_rules[rulews]() {
-					goto l339
+					goto l423
 				}
 				if !_rules[ruleParams]() {
-					goto l339
+					goto l423
 				}
 				if !_rules[rulews]() {
-					goto l339
+					goto l423
 				}
 				if buffer[position] != rune('-') {
-					goto l339
+					goto l423
 				}
 				position++
 				if buffer[position] != rune('>') {
-					goto l339
+					goto l423
 				}
 				position++
 				if !_rules[ruleExpression]() {
-					goto l339
+					goto l423
 				}
 				depth--
-				add(ruleLambdaExpr, position340)
+				add(ruleLambdaExpr, position424)
 			}
 			return true
-		l339:
-			position, tokenIndex, depth = position339, tokenIndex339, depth339
+		l423:
+			position, tokenIndex, depth = position423, tokenIndex423, depth423
 			return false
 		},
-		/* 87 Params <- <('|' StartParams ws Names? '|')> */
+		/* 92 Params <- <('|' StartParams ws Names? '|')> */
 		func() bool {
-			position341, tokenIndex341, depth341 := position, tokenIndex, depth
+			position425, tokenIndex425, depth425 := position, tokenIndex, depth
 			{
-				position342 := position
+				position426 := position
 				depth++
 				if buffer[position] != rune('|') {
-					goto l341
+					goto l425
 				}
 				position++
 				if !_rules[ruleStartParams]() {
-					goto l341
+					goto l425
 				}
 				if !_rules[rulews]() {
-					goto l341
+					goto l425
 				}
 				{
-					position343, tokenIndex343, depth343 := position, tokenIndex, depth
+					position427, tokenIndex427, depth427 := position, tokenIndex, depth
 					if !_rules[ruleNames]() {
-						goto l343
+						goto l427
 					}
-					goto l344
-				l343:
-					position, tokenIndex, depth = position343, tokenIndex343, depth343
+					goto l428
+				l427:
+					position, tokenIndex, depth = position427, tokenIndex427, depth427
 				}
-			l344:
+			l428:
 				if buffer[position] != rune('|') {
-					goto l341
+					goto l425
 				}
 				position++
 				depth--
-				add(ruleParams, position342)
+				add(ruleParams, position426)
 			}
 			return true
-		l341:
-			position, tokenIndex, depth = position341, tokenIndex341, depth341
+		l425:
+			position, tokenIndex, depth = position425, tokenIndex425, depth425
 			return false
 		},
-		/* 88 StartParams <- <Action2> */
+		/* 93 StartParams <- <Action2> */
 		func() bool {
-			position345, tokenIndex345, depth345 := position, tokenIndex, depth
+			position429, tokenIndex429, depth429 := position, tokenIndex, depth
 			{
-				position346 := position
+				position430 := position
 				depth++
 				if !_rules[ruleAction2]() {
-					goto l345
+					goto l429
 				}
 				depth--
-				add(ruleStartParams, position346)
+				add(ruleStartParams, position430)
 			}
 			return true
-		l345:
-			position, tokenIndex, depth = position345, tokenIndex345, depth345
+		l429:
+			position, tokenIndex, depth = position429, tokenIndex429, depth429
 			return false
 		},
-		/* 89 Names <- <(NextName (',' NextName)* DefaultValue? (',' NextName DefaultValue)* VarParams?)> */
+		/* 94 Names <- <(NextName (',' NextName)* DefaultValue? (',' NextName DefaultValue)* VarParams?)> */
 		func() bool {
-			position347, tokenIndex347, depth347 := position, tokenIndex, depth
+			position431, tokenIndex431, depth431 := position, tokenIndex, depth
 			{
-				position348 := position
+				position432 := position
 				depth++
 				if !_rules[ruleNextName]() {
-					goto l347
+					goto l431
 				}
-			l349:
+			l433:
 				{
-					position350, tokenIndex350, depth350 := position, tokenIndex, depth
+					position434, tokenIndex434, depth434 := position, tokenIndex, depth
 					if buffer[position] != rune(',') {
-						goto l350
+						goto l434
 					}
 					position++
 					if !_rules[ruleNextName]() {
-						goto l350
+						goto l434
 					}
-					goto l349
-				l350:
-					position, tokenIndex, depth = position350, tokenIndex350, depth350
+					goto l433
+				l434:
+					position, tokenIndex, depth = position434, tokenIndex434, depth434
 				}
 				{
-					position351, tokenIndex351, depth351 := position, tokenIndex, depth
+					position435, tokenIndex435, depth435 := position, tokenIndex, depth
 					if !_rules[ruleDefaultValue]() {
-						goto l351
+						goto l435
 					}
-					goto l352
-				l351:
-					position, tokenIndex, depth = position351, tokenIndex351, depth351
+					goto l436
+				l435:
+					position, tokenIndex, depth = position435, tokenIndex435, depth435
 				}
-			l352:
-			l353:
+			l436:
+			l437:
 				{
-					position354, tokenIndex354, depth354 := position, tokenIndex, depth
+					position438, tokenIndex438, depth438 := position, tokenIndex, depth
 					if buffer[position] != rune(',') {
-						goto l354
+						goto l438
 					}
 					position++
 					if !_rules[ruleNextName]() {
-						goto l354
+						goto l438
 					}
 					if !_rules[ruleDefaultValue]() {
-						goto l354
+						goto l438
 					}
-					goto l353
-				l354:
-					position, tokenIndex, depth = position354, tokenIndex354, depth354
+					goto l437
+				l438:
+					position, tokenIndex, depth = position438, tokenIndex438, depth438
 				}
 				{
-					position355, tokenIndex355, depth355 := position, tokenIndex, depth
+					position439, tokenIndex439, depth439 := position, tokenIndex, depth
 					if !_rules[ruleVarParams]() {
-						goto l355
+						goto l439
 					}
-					goto l356
-				l355:
-					position, tokenIndex, depth = position355, tokenIndex355, depth355
+					goto l440
+				l439:
+					position, tokenIndex, depth = position439, tokenIndex439, depth439
 				}
-			l356:
+			l440:
 				depth--
-				add(ruleNames, position348)
+				add(ruleNames, position432)
 			}
 			return true
-		l347:
-			position, tokenIndex, depth = position347, tokenIndex347, depth347
+		l431:
+			position, tokenIndex, depth = position431, tokenIndex431, depth431
 			return false
 		},
-		/* 90 NextName <- <(ws Name ws)> */
+		/* 95 NextName <- <(ws Name ws)> */
 		func() bool {
-			position357, tokenIndex357, depth357 := position, tokenIndex, depth
+			position441, tokenIndex441, depth441 := position, tokenIndex, depth
 			{
-				position358 := position
+				position442 := position
 				depth++
 				if !_rules[rulews]() {
-					goto l357
+					goto l441
 				}
 				if !_rules[ruleName]() {
-					goto l357
+					goto l441
 				}
 				if !_rules[rulews]() {
-					goto l357
+					goto l441
 				}
 				depth--
-				add(ruleNextName, position358)
+				add(ruleNextName, position442)
 			}
 			return true
-		l357:
-			position, tokenIndex, depth = position357, tokenIndex357, depth357
+		l441:
+			position, tokenIndex, depth = position441, tokenIndex441, depth441
 			return false
 		},
-		/* 91 Name <- <([a-z] / [A-Z] / [0-9] / '_')+> */
+		/* 96 Name <- <([a-z] / [A-Z] / [0-9] / '_')+> */
 		func() bool {
-			position359, tokenIndex359, depth359 := position, tokenIndex, depth
+			position443, tokenIndex443, depth443 := position, tokenIndex, depth
 			{
-				position360 := position
+				position444 := position
 				depth++
 				{
-					position363, tokenIndex363, depth363 := position, tokenIndex, depth
+					position447, tokenIndex447, depth447 := position, tokenIndex, depth
 					if c := buffer[position]; c < rune('a') || c > rune('z') {
-						goto l364
+						goto l448
 					}
 					position++
-					goto l363
-				l364:
-					position, tokenIndex, depth = position363, tokenIndex363, depth363
+					goto l447
+				l448:
+					position, tokenIndex, depth = position447, tokenIndex447, depth447
 					if c := buffer[position]; c < rune('A') || c > rune('Z') {
-						goto l365
+						goto l449
 					}
 					position++
-					goto l363
-				l365:
-					position, tokenIndex, depth = position363, tokenIndex363, depth363
+					goto l447
+				l449:
+					position, tokenIndex, depth = position447, tokenIndex447, depth447
 					if c := buffer[position]; c < rune('0') || c > rune('9') {
-						goto l366
+						goto l450
 					}
 					position++
-					goto l363
-				l366:
-					position, tokenIndex, depth = position363, tokenIndex363, depth363
+					goto l447
+				l450:
+					position, tokenIndex, depth = position447, tokenIndex447, depth447
 					if buffer[position] != rune('_') {
-						goto l359
+						goto l443
 					}
 					position++
 				}
-			l363:
-			l361:
+			l447:
+			l445:
 				{
-					position362, tokenIndex362, depth362 := position, tokenIndex, depth
+					position446, tokenIndex446, depth446 := position, tokenIndex, depth
 					{
-						position367, tokenIndex367, depth367 := position, tokenIndex, depth
+						position451, tokenIndex451, depth451 := position, tokenIndex, depth
 						if c := buffer[position]; c < rune('a') || c > rune('z') {
-							goto l368
+							goto l452
 						}
 						position++
-						goto l367
-					l368:
-						position, tokenIndex, depth = position367, tokenIndex367, depth367
+						goto l451
+					l452:
+						position, tokenIndex, depth = position451, tokenIndex451, depth451
 						if c := buffer[position]; c < rune('A') || c > rune('Z') {
-							goto l369
+							goto l453
 						}
 						position++
-						goto l367
-					l369:
-						position, tokenIndex, depth = position367, tokenIndex367, depth367
+						goto l451
+					l453:
+						position, tokenIndex, depth = position451, tokenIndex451, depth451
 						if c := buffer[position]; c < rune('0') || c > rune('9') {
-							goto l370
+							goto l454
 						}
 						position++
-						goto l367
-					l370:
-						position, tokenIndex, depth = position367, tokenIndex367, depth367
+						goto l451
+					l454:
+						position, tokenIndex, depth = position451, tokenIndex451, depth451
 						if buffer[position] != rune('_') {
-							goto l362
+							goto l446
 						}
 						position++
 					}
-				l367:
-					goto l361
-				l362:
-					position, tokenIndex, depth = position362, tokenIndex362, depth362
+				l451:
+					goto l445
+				l446:
+					position, tokenIndex, depth = position446, tokenIndex446, depth446
 				}
 				depth--
-				add(ruleName, position360)
+				add(ruleName, position444)
 			}
 			return true
-		l359:
-			position, tokenIndex, depth = position359, tokenIndex359, depth359
+		l443:
+			position, tokenIndex, depth = position443, tokenIndex443, depth443
 			return false
 		},
-		/* 92 DefaultValue <- <('=' Expression)> */
+		/* 97 DefaultValue <- <('=' Expression)> */
 		func() bool {
-			position371, tokenIndex371, depth371 := position, tokenIndex, depth
+			position455, tokenIndex455, depth455 := position, tokenIndex, depth
 			{
-				position372 := position
+				position456 := position
 				depth++
 				if buffer[position] != rune('=') {
-					goto l371
+					goto l455
 				}
 				position++
 				if !_rules[ruleExpression]() {
-					goto l371
+					goto l455
 				}
 				depth--
-				add(ruleDefaultValue, position372)
+				add(ruleDefaultValue, position456)
 			}
 			return true
-		l371:
-			position, tokenIndex, depth = position371, tokenIndex371, depth371
+		l455:
+			position, tokenIndex, depth = position455, tokenIndex455, depth455
 			return false
 		},
-		/* 93 VarParams <- <('.' '.' '.' ws)> */
+		/* 98 VarParams <- <('.' '.' '.' ws)> */
 		func() bool {
-			position373, tokenIndex373, depth373 := position, tokenIndex, depth
+			position457, tokenIndex457, depth457 := position, tokenIndex, depth
 			{
-				position374 := position
+				position458 := position
 				depth++
 				if buffer[position] != rune('.') {
-					goto l373
+					goto l457
 				}
 				position++
 				if buffer[position] != rune('.') {
-					goto l373
+					goto l457
 				}
 				position++
 				if buffer[position] != rune('.') {
-					goto l373
+					goto l457
 				}
 				position++
 				if !_rules[rulews]() {
-					goto l373
+					goto l457
 				}
 				depth--
-				add(ruleVarParams, position374)
+				add(ruleVarParams, position458)
 			}
 			return true
-		l373:
-			position, tokenIndex, depth = position373, tokenIndex373, depth373
+		l457:
+			position, tokenIndex, depth = position457, tokenIndex457, depth457
 			return false
 		},
-		/* 94 Reference <- <(((TagPrefix ('.' / Key)) / ('.'? Key)) FollowUpRef)> */
+		/* 99 Reference <- <(((TagPrefix ('.' / Key)) / ('.'? Key)) FollowUpRef)> */
 		func() bool {
-			position375, tokenIndex375, depth375 := position, tokenIndex, depth
+			position459, tokenIndex459, depth459 := position, tokenIndex, depth
 			{
-				position376 := position
+				position460 := position
 				depth++
 				{
-					position377, tokenIndex377, depth377 := position, tokenIndex, depth
+					position461, tokenIndex461, depth461 := position, tokenIndex, depth
 					if !_rules[ruleTagPrefix]() {
-						goto l378
+						goto l462
 					}
 					{
-						position379, tokenIndex379, depth379 := position, tokenIndex, depth
+						position463, tokenIndex463, depth463 := position, tokenIndex, depth
 						if buffer[position] != rune('.') {
-							goto l380
+							goto l464
 						}
 						position++
-						goto l379
-					l380:
-						position, tokenIndex, depth = position379, tokenIndex379, depth379
+						goto l463
+					l464:
+						position, tokenIndex, depth = position463, tokenIndex463, depth463
 						if !_rules[ruleKey]() {
-							goto l378
+							goto l462
 						}
 					}
-				l379:
-					goto l377
-				l378:
-					position, tokenIndex, depth = position377, tokenIndex377, depth377
+				l463:
+					goto l461
+				l462:
+					position, tokenIndex, depth = position461, tokenIndex461, depth461
 					{
-						position381, tokenIndex381, depth381 := position, tokenIndex, depth
+						position465, tokenIndex465, depth465 := position, tokenIndex, depth
 						if buffer[position] != rune('.') {
-							goto l381
+							goto l465
 						}
 						position++
-						goto l382
-					l381:
-						position, tokenIndex, depth = position381, tokenIndex381, depth381
+						goto l466
+					l465:
+						position, tokenIndex, depth = position465, tokenIndex465, depth465
 					}
-				l382:
+				l466:
 					if !_rules[ruleKey]() {
-						goto l375
+						goto l459
 					}
 				}
-			l377:
+			l461:
 				if !_rules[ruleFollowUpRef]() {
-					goto l375
+					goto l459
 				}
 				depth--
-				add(ruleReference, position376)
+				add(ruleReference, position460)
 			}
 			return true
-		l375:
-			position, tokenIndex, depth = position375, tokenIndex375, depth375
+		l459:
+			position, tokenIndex, depth = position459, tokenIndex459, depth459
 			return false
 		},
-		/* 95 TagPrefix <- <((('d' 'o' 'c' ('.' / ':') '-'? [0-9]+) / Tag) (':' ':'))> */
+		/* 100 TagPrefix <- <((('d' 'o' 'c' ('.' / ':') '-'? [0-9]+) / Tag) (':' ':'))> */
 		func() bool {
-			position383, tokenIndex383, depth383 := position, tokenIndex, depth
+			position467, tokenIndex467, depth467 := position, tokenIndex, depth
 			{
-				position384 := position
+				position468 := position
 				depth++
 				{
-					position385, tokenIndex385, depth385 := position, tokenIndex, depth
+					position469, tokenIndex469, depth469 := position, tokenIndex, depth
 					if buffer[position] != rune('d') {
-						goto l386
+						goto l470
 					}
 					position++
 					if buffer[position] != rune('o') {
-						goto l386
+						goto l470
 					}
 					position++
 					if buffer[position] != rune('c') {
-						goto l386
+						goto l470
 					}
 					position++
 					{
-						position387, tokenIndex387, depth387 := position, tokenIndex, depth
+						position471, tokenIndex471, depth471 := position, tokenIndex, depth
 						if buffer[position] != rune('.') {
-							goto l388
+							goto l472
 						}
 						position++
-						goto l387
-					l388:
-						position, tokenIndex, depth = position387, tokenIndex387, depth387
+						goto l471
+					l472:
+						position, tokenIndex, depth = position471, tokenIndex471, depth471
 						if buffer[position] != rune(':') {
-							goto l386
+							goto l470
 						}
 						position++
 					}
-				l387:
+				l471:
 					{
-						position389, tokenIndex389, depth389 := position, tokenIndex, depth
+						position473, tokenIndex473, depth473 := position, tokenIndex, depth
 						if buffer[position] != rune('-') {
-							goto l389
+							goto l473
 						}
 						position++
-						goto l390
-					l389:
-						position, tokenIndex, depth = position389, tokenIndex389, depth389
+						goto l474
+					l473:
+						position, tokenIndex, depth = position473, tokenIndex473, depth473
 					}
-				l390:
+				l474:
 					if c := buffer[position]; c < rune('0') || c > rune('9') {
-						goto l386
+						goto l470
 					}
 					position++
-				l391:
+				l475:
 					{
-						position392, tokenIndex392, depth392 := position, tokenIndex, depth
+						position476, tokenIndex476, depth476 := position, tokenIndex, depth
 						if c := buffer[position]; c < rune('0') || c > rune('9') {
-							goto l392
+							goto l476
 						}
 						position++
-						goto l391
-					l392:
-						position, tokenIndex, depth = position392, tokenIndex392, depth392
+						goto l475
+					l476:
+						position, tokenIndex, depth = position476, tokenIndex476, depth476
 					}
-					goto l385
-				l386:
-					position, tokenIndex, depth = position385, tokenIndex385, depth385
+					goto l469
+				l470:
+					position, tokenIndex, depth = position469, tokenIndex469, depth469
 					if !_rules[ruleTag]() {
-						goto l383
+						goto l467
 					}
 				}
-			l385:
+			l469:
 				if buffer[position] != rune(':') {
-					goto l383
+					goto l467
 				}
 				position++
 				if buffer[position] != rune(':') {
-					goto l383
+					goto l467
 				}
 				position++
 				depth--
-				add(ruleTagPrefix, position384)
+				add(ruleTagPrefix, position468)
 			}
 			return true
-		l383:
-			position, tokenIndex, depth = position383, tokenIndex383, depth383
+		l467:
+			position, tokenIndex, depth = position467, tokenIndex467, depth467
 			return false
 		},
-		/* 96 Tag <- <(TagComponent (('.' / ':') TagComponent)*)> */
+		/* 101 Tag <- <(TagComponent (('.' / ':') TagComponent)*)> */
 		func() bool {
-			position393, tokenIndex393, depth393 := position, tokenIndex, depth
+			position477, tokenIndex477, depth477 := position, tokenIndex, depth
 			{
-				position394 := position
+				position478 := position
 				depth++
 				if !_rules[ruleTagComponent]() {
-					goto l393
+					goto l477
 				}
-			l395:
+			l479:
 				{
-					position396, tokenIndex396, depth396 := position, tokenIndex, depth
+					position480, tokenIndex480, depth480 := position, tokenIndex, depth
 					{
-						position397, tokenIndex397, depth397 := position, tokenIndex, depth
+						position481, tokenIndex481, depth481 := position, tokenIndex, depth
 						if buffer[position] != rune('.') {
-							goto l398
+							goto l482
 						}
 						position++
-						goto l397
-					l398:
-						position, tokenIndex, depth = position397, tokenIndex397, depth397
+						goto l481
+					l482:
+						position, tokenIndex, depth = position481, tokenIndex481, depth481
 						if buffer[position] != rune(':') {
-							goto l396
+							goto l480
 						}
 						position++
 					}
-				l397:
+				l481:
 					if !_rules[ruleTagComponent]() {
-						goto l396
+						goto l480
 					}
-					goto l395
-				l396:
-					position, tokenIndex, depth = position396, tokenIndex396, depth396
+					goto l479
+				l480:
+					position, tokenIndex, depth = position480, tokenIndex480, depth480
 				}
 				depth--
-				add(ruleTag, position394)
+				add(ruleTag, position478)
 			}
 			return true
-		l393:
-			position, tokenIndex, depth = position393, tokenIndex393, depth393
+		l477:
+			position, tokenIndex, depth = position477, tokenIndex477, depth477
 			return false
 		},
-		/* 97 TagComponent <- <(([a-z] / [A-Z] / '_') ([a-z] / [A-Z] / [0-9] / '_')*)> */
+		/* 102 TagComponent <- <(([a-z] / [A-Z] / '_') ([a-z] / [A-Z] / [0-9] / '_')*)> */
 		func() bool {
-			position399, tokenIndex399, depth399 := position, tokenIndex, depth
+			position483, tokenIndex483, depth483 := position, tokenIndex, depth
 			{
-				position400 := position
+				position484 := position
 				depth++
 				{
-					position401, tokenIndex401, depth401 := position, tokenIndex, depth
+					position485, tokenIndex485, depth485 := position, tokenIndex, depth
 					if c := buffer[position]; c < rune('a') || c > rune('z') {
-						goto l402
+						goto l486
 					}
 					position++
-					goto l401
-				l402:
-					position, tokenIndex, depth = position401, tokenIndex401, depth401
+					goto l485
+				l486:
+					position, tokenIndex, depth = position485, tokenIndex485, depth485
 					if c := buffer[position]; c < rune('A') || c > rune('Z') {
-						goto l403
+						goto l487
 					}
 					position++
-					goto l401
-				l403:
-					position, tokenIndex, depth = position401, tokenIndex401, depth401
+					goto l485
+				l487:
+					position, tokenIndex, depth = position485, tokenIndex485, depth485
 					if buffer[position] != rune('_') {
-						goto l399
+						goto l483
 					}
 					position++
 				}
-			l401:
-			l404:
+			l485:
+			l488:
 				{
-					position405, tokenIndex405, depth405 := position, tokenIndex, depth
+					position489, tokenIndex489, depth489 := position, tokenIndex, depth
 					{
-						position406, tokenIndex406, depth406 := position, tokenIndex, depth
+						position490, tokenIndex490, depth490 := position, tokenIndex, depth
 						if c := buffer[position]; c < rune('a') || c > rune('z') {
-							goto l407
+							goto l491
 						}
 						position++
-						goto l406
-					l407:
-						position, tokenIndex, depth = position406, tokenIndex406, depth406
+						goto l490
+					l491:
+						position, tokenIndex, depth = position490, tokenIndex490, depth490
 						if c := buffer[position]; c < rune('A') || c > rune('Z') {
-							goto l408
+							goto l492
 						}
 						position++
-						goto l406
-					l408:
-						position, tokenIndex, depth = position406, tokenIndex406, depth406
+						goto l490
+					l492:
+						position, tokenIndex, depth = position490, tokenIndex490, depth490
 						if c := buffer[position]; c < rune('0') || c > rune('9') {
-							goto l409
+							goto l493
 						}
 						position++
-						goto l406
-					l409:
-						position, tokenIndex, depth = position406, tokenIndex406, depth406
+						goto l490
+					l493:
+						position, tokenIndex, depth = position490, tokenIndex490, depth490
 						if buffer[position] != rune('_') {
-							goto l405
+							goto l489
 						}
 						position++
 					}
-				l406:
-					goto l404
-				l405:
-					position, tokenIndex, depth = position405, tokenIndex405, depth405
+				l490:
+					goto l488
+				l489:
+					position, tokenIndex, depth = position489, tokenIndex489, depth489
 				}
 				depth--
-				add(ruleTagComponent, position400)
+				add(ruleTagComponent, position484)
 			}
 			return true
-		l399:
-			position, tokenIndex, depth = position399, tokenIndex399, depth399
+		l483:
+			position, tokenIndex, depth = position483, tokenIndex483, depth483
 			return false
 		},
-		/* 98 FollowUpRef <- <PathComponent*> */
+		/* 103 FollowUpRef <- <PathComponent*> */
 		func() bool {
 			{
-				position411 := position
+				position495 := position
 				depth++
-			l412:
+			l496:
 				{
-					position413, tokenIndex413, depth413 := position, tokenIndex, depth
+					position497, tokenIndex497, depth497 := position, tokenIndex, depth
 					if !_rules[rulePathComponent]() {
-						goto l413
+						goto l497
 					}
-					goto l412
-				l413:
-					position, tokenIndex, depth = position413, tokenIndex413, depth413
+					goto l496
+				l497:
+					position, tokenIndex, depth = position497, tokenIndex497, depth497
 				}
 				depth--
-				add(ruleFollowUpRef, position411)
+				add(ruleFollowUpRef, position495)
 			}
 			return true
 		},
-		/* 99 PathComponent <- <(('.' Key) / ('.'? Index))> */
+		/* 104 PathComponent <- <((SafeNavigation? '.' Key) / (SafeNavigation '.' Index) / ('.'? Index))> */
 		func() bool {
-			position414, tokenIndex414, depth414 := position, tokenIndex, depth
+			position498, tokenIndex498, depth498 := position, tokenIndex, depth
 			{
-				position415 := position
+				position499 := position
 				depth++
 				{
-					position416, tokenIndex416, depth416 := position, tokenIndex, depth
+					position500, tokenIndex500, depth500 := position, tokenIndex, depth
+					{
+						position502, tokenIndex502, depth502 := position, tokenIndex, depth
+						if !_rules[ruleSafeNavigation]() {
+							goto l502
+						}
+						goto l503
+					l502:
+						position, tokenIndex, depth = position502, tokenIndex502, depth502
+					}
+				l503:
 					if buffer[position] != rune('.') {
-						goto l417
+						goto l501
 					}
 					position++
 					if !_rules[ruleKey]() {
-						goto l417
+						goto l501
+					}
+					goto l500
+				l501:
+					position, tokenIndex, depth = position500, tokenIndex500, depth500
+					if !_rules[ruleSafeNavigation]() {
+						goto l504
+					}
+					if buffer[position] != rune('.') {
+						goto l504
 					}
-					goto l416
-				l417:
-					position, tokenIndex, depth = position416, tokenIndex416, depth416
+					position++
+					if !_rules[ruleIndex]() {
+						goto l504
+					}
+					goto l500
+				l504:
+					position, tokenIndex, depth = position500, tokenIndex500, depth500
 					{
-						position418, tokenIndex418, depth418 := position, tokenIndex, depth
+						position505, tokenIndex505, depth505 := position, tokenIndex, depth
 						if buffer[position] != rune('.') {
-							goto l418
+							goto l505
 						}
 						position++
-						goto l419
-					l418:
-						position, tokenIndex, depth = position418, tokenIndex418, depth418
+						goto l506
+					l505:
+						position, tokenIndex, depth = position505, tokenIndex505, depth505
 					}
-				l419:
+				l506:
 					if !_rules[ruleIndex]() {
-						goto l414
+						goto l498
+					}
+				}
+			l500:
+				depth--
+				add(rulePathComponent, position499)
+			}
+			return true
+		l498:
+			position, tokenIndex, depth = position498, tokenIndex498, depth498
+			return false
+		},
+		/* 105 SafeNavigation <- <('?' !(Expression ':'))> */
+		func() bool {
+			position507, tokenIndex507, depth507 := position, tokenIndex, depth
+			{
+				position508 := position
+				depth++
+				if buffer[position] != rune('?') {
+					goto l507
+				}
+				position++
+				{
+					position509, tokenIndex509, depth509 := position, tokenIndex, depth
+					if !_rules[ruleExpression]() {
+						goto l509
+					}
+					if buffer[position] != rune(':') {
+						goto l509
 					}
+					position++
+					goto l507
+				l509:
+					position, tokenIndex, depth = position509, tokenIndex509, depth509
 				}
-			l416:
 				depth--
-				add(rulePathComponent, position415)
+				add(ruleSafeNavigation, position508)
 			}
 			return true
-		l414:
-			position, tokenIndex, depth = position414, tokenIndex414, depth414
+		l507:
+			position, tokenIndex, depth = position507, tokenIndex507, depth507
 			return false
 		},
-		/* 100 Key <- <(([a-z] / [A-Z] / [0-9] / '_') ([a-z] / [A-Z] / [0-9] / '_' / '-')* (':' ([a-z] / [A-Z] / [0-9] / '_') ([a-z] / [A-Z] / [0-9] / '_' / '-')*)?)> */
+		/* 106 Key <- <(([a-z] / [A-Z] / [0-9] / '_') ([a-z] / [A-Z] / [0-9] / '_' / '-')* (':' ([a-z] / [A-Z] / [0-9] / '_') ([a-z] / [A-Z] / [0-9] / '_' / '-')*)?)> */
 		func() bool {
-			position420, tokenIndex420, depth420 := position, tokenIndex, depth
+			position510, tokenIndex510, depth510 := position, tokenIndex, depth
 			{
-				position421 := position
+				position511 := position
 				depth++
 				{
-					position422, tokenIndex422, depth422 := position, tokenIndex, depth
+					position512, tokenIndex512, depth512 := position, tokenIndex, depth
 					if c := buffer[position]; c < rune('a') || c > rune('z') {
-						goto l423
+						goto l513
 					}
 					position++
-					goto l422
-				l423:
-					position, tokenIndex, depth = position422, tokenIndex422, depth422
+					goto l512
+				l513:
+					position, tokenIndex, depth = position512, tokenIndex512, depth512
 					if c := buffer[position]; c < rune('A') || c > rune('Z') {
-						goto l424
+						goto l514
 					}
 					position++
-					goto l422
-				l424:
-					position, tokenIndex, depth = position422, tokenIndex422, depth422
+					goto l512
+				l514:
+					position, tokenIndex, depth = position512, tokenIndex512, depth512
 					if c := buffer[position]; c < rune('0') || c > rune('9') {
-						goto l425
+						goto l515
 					}
 					position++
-					goto l422
-				l425:
-					position, tokenIndex, depth = position422, tokenIndex422, depth422
+					goto l512
+				l515:
+					position, tokenIndex, depth = position512, tokenIndex512, depth512
 					if buffer[position] != rune('_') {
-						goto l420
+						goto l510
 					}
 					position++
 				}
-			l422:
-			l426:
+			l512:
+			l516:
 				{
-					position427, tokenIndex427, depth427 := position, tokenIndex, depth
+					position517, tokenIndex517, depth517 := position, tokenIndex, depth
 					{
-						position428, tokenIndex428, depth428 := position, tokenIndex, depth
+						position518, tokenIndex518, depth518 := position, tokenIndex, depth
 						if c := buffer[position]; c < rune('a') || c > rune('z') {
-							goto l429
+							goto l519
 						}
 						position++
-						goto l428
-					l429:
-						position, tokenIndex, depth = position428, tokenIndex428, depth428
+						goto l518
+					l519:
+						position, tokenIndex, depth = position518, tokenIndex518, depth518
 						if c := buffer[position]; c < rune('A') || c > rune('Z') {
-							goto l430
+							goto l520
 						}
 						position++
-						goto l428
-					l430:
-						position, tokenIndex, depth = position428, tokenIndex428, depth428
+						goto l518
+					l520:
+						position, tokenIndex, depth = position518, tokenIndex518, depth518
 						if c := buffer[position]; c < rune('0') || c > rune('9') {
-							goto l431
+							goto l521
 						}
 						position++
-						goto l428
-					l431:
-						position, tokenIndex, depth = position428, tokenIndex428, depth428
+						goto l518
+					l521:
+						position, tokenIndex, depth = position518, tokenIndex518, depth518
 						if buffer[position] != rune('_') {
-							goto l432
+							goto l522
 						}
 						position++
-						goto l428
-					l432:
-						position, tokenIndex, depth = position428, tokenIndex428, depth428
+						goto l518
+					l522:
+						position, tokenIndex, depth = position518, tokenIndex518, depth518
 						if buffer[position] != rune('-') {
-							goto l427
+							goto l517
 						}
 						position++
 					}
-				l428:
-					goto l426
-				l427:
-					position, tokenIndex, depth = position427, tokenIndex427, depth427
+				l518:
+					goto l516
+				l517:
+					position, tokenIndex, depth = position517, tokenIndex517, depth517
 				}
 				{
-					position433, tokenIndex433, depth433 := position, tokenIndex, depth
+					position523, tokenIndex523, depth523 := position, tokenIndex, depth
 					if buffer[position] != rune(':') {
-						goto l433
+						goto l523
 					}
 					position++
 					{
-						position435, tokenIndex435, depth435 := position, tokenIndex, depth
+						position525, tokenIndex525, depth525 := position, tokenIndex, depth
 						if c := buffer[position]; c < rune('a') || c > rune('z') {
-							goto l436
+							goto l526
 						}
 						position++
-						goto l435
-					l436:
-						position, tokenIndex, depth = position435, tokenIndex435, depth435
+						goto l525
+					l526:
+						position, tokenIndex, depth = position525, tokenIndex525, depth525
 						if c := buffer[position]; c < rune('A') || c > rune('Z') {
-							goto l437
+							goto l527
 						}
 						position++
-						goto l435
-					l437:
-						position, tokenIndex, depth = position435, tokenIndex435, depth435
+						goto l525
+					l527:
+						position, tokenIndex, depth = position525, tokenIndex525, depth525
 						if c := buffer[position]; c < rune('0') || c > rune('9') {
-							goto l438
+							goto l528
 						}
 						position++
-						goto l435
-					l438:
-						position, tokenIndex, depth = position435, tokenIndex435, depth435
+						goto l525
+					l528:
+						position, tokenIndex, depth = position525, tokenIndex525, depth525
 						if buffer[position] != rune('_') {
-							goto l433
+							goto l523
 						}
 						position++
 					}
-				l435:
-				l439:
+				l525:
+				l529:
 					{
-						position440, tokenIndex440, depth440 := position, tokenIndex, depth
+						position530, tokenIndex530, depth530 := position, tokenIndex, depth
 						{
-							position441, tokenIndex441, depth441 := position, tokenIndex, depth
+							position531, tokenIndex531, depth531 := position, tokenIndex, depth
 							if c := buffer[position]; c < rune('a') || c > rune('z') {
-								goto l442
+								goto l532
 							}
 							position++
-							goto l441
-						l442:
-							position, tokenIndex, depth = position441, tokenIndex441, depth441
+							goto l531
+						l532:
+							position, tokenIndex, depth = position531, tokenIndex531, depth531
 							if c := buffer[position]; c < rune('A') || c > rune('Z') {
-								goto l443
+								goto l533
 							}
 							position++
-							goto l441
-						l443:
-							position, tokenIndex, depth = position441, tokenIndex441, depth441
+							goto l531
+						l533:
+							position, tokenIndex, depth = position531, tokenIndex531, depth531
 							if c := buffer[position]; c < rune('0') || c > rune('9') {
-								goto l444
+								goto l534
 							}
 							position++
-							goto l441
-						l444:
-							position, tokenIndex, depth = position441, tokenIndex441, depth441
+							goto l531
+						l534:
+							position, tokenIndex, depth = position531, tokenIndex531, depth531
 							if buffer[position] != rune('_') {
-								goto l445
+								goto l535
 							}
 							position++
-							goto l441
-						l445:
-							position, tokenIndex, depth = position441, tokenIndex441, depth441
+							goto l531
+						l535:
+							position, tokenIndex, depth = position531, tokenIndex531, depth531
 							if buffer[position] != rune('-') {
-								goto l440
+								goto l530
 							}
 							position++
 						}
-					l441:
-						goto l439
-					l440:
-						position, tokenIndex, depth = position440, tokenIndex440, depth440
+					l531:
+						goto l529
+					l530:
+						position, tokenIndex, depth = position530, tokenIndex530, depth530
 					}
-					goto l434
-				l433:
-					position, tokenIndex, depth = position433, tokenIndex433, depth433
+					goto l524
+				l523:
+					position, tokenIndex, depth = position523, tokenIndex523, depth523
 				}
-			l434:
+			l524:
 				depth--
-				add(ruleKey, position421)
+				add(ruleKey, position511)
 			}
 			return true
-		l420:
-			position, tokenIndex, depth = position420, tokenIndex420, depth420
+		l510:
+			position, tokenIndex, depth = position510, tokenIndex510, depth510
 			return false
 		},
-		/* 101 Index <- <('[' '-'? [0-9]+ ']')> */
+		/* 107 Index <- <('[' '-'? [0-9]+ ']')> */
 		func() bool {
-			position446, tokenIndex446, depth446 := position, tokenIndex, depth
+			position536, tokenIndex536, depth536 := position, tokenIndex, depth
 			{
-				position447 := position
+				position537 := position
 				depth++
 				if buffer[position] != rune('[') {
-					goto l446
+					goto l536
 				}
 				position++
 				{
-					position448, tokenIndex448, depth448 := position, tokenIndex, depth
+					position538, tokenIndex538, depth538 := position, tokenIndex, depth
 					if buffer[position] != rune('-') {
-						goto l448
+						goto l538
 					}
 					position++
-					goto l449
-				l448:
-					position, tokenIndex, depth = position448, tokenIndex448, depth448
+					goto l539
+				l538:
+					position, tokenIndex, depth = position538, tokenIndex538, depth538
 				}
-			l449:
+			l539:
 				if c := buffer[position]; c < rune('0') || c > rune('9') {
-					goto l446
+					goto l536
 				}
 				position++
-			l450:
+			l540:
 				{
-					position451, tokenIndex451, depth451 := position, tokenIndex, depth
+					position541, tokenIndex541, depth541 := position, tokenIndex, depth
 					if c := buffer[position]; c < rune('0') || c > rune('9') {
-						goto l451
+						goto l541
 					}
 					position++
-					goto l450
-				l451:
-					position, tokenIndex, depth = position451, tokenIndex451, depth451
+					goto l540
+				l541:
+					position, tokenIndex, depth = position541, tokenIndex541, depth541
 				}
 				if buffer[position] != rune(']') {
-					goto l446
+					goto l536
 				}
 				position++
 				depth--
-				add(ruleIndex, position447)
+				add(ruleIndex, position537)
 			}
 			return true
-		l446:
-			position, tokenIndex, depth = position446, tokenIndex446, depth446
+		l536:
+			position, tokenIndex, depth = position536, tokenIndex536, depth536
 			return false
 		},
-		/* 102 IP <- <([0-9]+ '.' [0-9]+ '.' [0-9]+ '.' [0-9]+)> */
+		/* 108 IP <- <([0-9]+ '.' [0-9]+ '.' [0-9]+ '.' [0-9]+)> */
 		func() bool {
-			position452, tokenIndex452, depth452 := position, tokenIndex, depth
+			position542, tokenIndex542, depth542 := position, tokenIndex, depth
 			{
-				position453 := position
+				position543 := position
 				depth++
 				if c := buffer[position]; c < rune('0') || c > rune('9') {
-					goto l452
+					goto l542
 				}
 				position++
-			l454:
+			l544:
 				{
-					position455, tokenIndex455, depth455 := position, tokenIndex, depth
+					position545, tokenIndex545, depth545 := position, tokenIndex, depth
 					if c := buffer[position]; c < rune('0') || c > rune('9') {
-						goto l455
+						goto l545
 					}
 					position++
-					goto l454
-				l455:
-					position, tokenIndex, depth = position455, tokenIndex455, depth455
+					goto l544
+				l545:
+					position, tokenIndex, depth = position545, tokenIndex545, depth545
 				}
 				if buffer[position] != rune('.') {
-					goto l452
+					goto l542
 				}
 				position++
 				if c := buffer[position]; c < rune('0') || c > rune('9') {
-					goto l452
+					goto l542
 				}
 				position++
-			l456:
+			l546:
 				{
-					position457, tokenIndex457, depth457 := position, tokenIndex, depth
+					position547, tokenIndex547, depth547 := position, tokenIndex, depth
 					if c := buffer[position]; c < rune('0') || c > rune('9') {
-						goto l457
+						goto l547
 					}
 					position++
-					goto l456
-				l457:
-					position, tokenIndex, depth = position457, tokenIndex457, depth457
+					goto l546
+				l547:
+					position, tokenIndex, depth = position547, tokenIndex547, depth547
 				}
 				if buffer[position] != rune('.') {
-					goto l452
+					goto l542
 				}
 				position++
 				if c := buffer[position]; c < rune('0') || c > rune('9') {
-					goto l452
+					goto l542
 				}
 				position++
-			l458:
+			l548:
 				{
-					position459, tokenIndex459, depth459 := position, tokenIndex, depth
+					position549, tokenIndex549, depth549 := position, tokenIndex, depth
 					if c := buffer[position]; c < rune('0') || c > rune('9') {
-						goto l459
+						goto l549
 					}
 					position++
-					goto l458
-				l459:
-					position, tokenIndex, depth = position459, tokenIndex459, depth459
+					goto l548
+				l549:
+					position, tokenIndex, depth = position549, tokenIndex549, depth549
 				}
 				if buffer[position] != rune('.') {
-					goto l452
+					goto l542
 				}
 				position++
 				if c := buffer[position]; c < rune('0') || c > rune('9') {
-					goto l452
+					goto l542
 				}
 				position++
-			l460:
+			l550:
 				{
-					position461, tokenIndex461, depth461 := position, tokenIndex, depth
+					position551, tokenIndex551, depth551 := position, tokenIndex, depth
 					if c := buffer[position]; c < rune('0') || c > rune('9') {
-						goto l461
+						goto l551
 					}
 					position++
-					goto l460
-				l461:
-					position, tokenIndex, depth = position461, tokenIndex461, depth461
+					goto l550
+				l551:
+					position, tokenIndex, depth = position551, tokenIndex551, depth551
 				}
 				depth--
-				add(ruleIP, position453)
+				add(ruleIP, position543)
 			}
 			return true
-		l452:
-			position, tokenIndex, depth = position452, tokenIndex452, depth452
+		l542:
+			position, tokenIndex, depth = position542, tokenIndex542, depth542
 			return false
 		},
-		/* 103 ws <- <(' ' / '\t' / '\n' / '\r')*> */
+		/* 109 ws <- <(' ' / '\t' / '\n' / '\r')*> */
 		func() bool {
 			{
-				position463 := position
+				position553 := position
 				depth++
-			l464:
+			l554:
 				{
-					position465, tokenIndex465, depth465 := position, tokenIndex, depth
+					position555, tokenIndex555, depth555 := position, tokenIndex, depth
 					{
-						position466, tokenIndex466, depth466 := position, tokenIndex, depth
+						position556, tokenIndex556, depth556 := position, tokenIndex, depth
 						if buffer[position] != rune(' ') {
-							goto l467
+							goto l557
 						}
 						position++
-						goto l466
-					l467:
-						position, tokenIndex, depth = position466, tokenIndex466, depth466
+						goto l556
+					l557:
+						position, tokenIndex, depth = position556, tokenIndex556, depth556
 						if buffer[position] != rune('\t') {
-							goto l468
+							goto l558
 						}
 						position++
-						goto l466
-					l468:
-						position, tokenIndex, depth = position466, tokenIndex466, depth466
+						goto l556
+					l558:
+						position, tokenIndex, depth = position556, tokenIndex556, depth556
 						if buffer[position] != rune('\n') {
-							goto l469
+							goto l559
 						}
 						position++
-						goto l466
-					l469:
-						position, tokenIndex, depth = position466, tokenIndex466, depth466
+						goto l556
+					l559:
+						position, tokenIndex, depth = position556, tokenIndex556, depth556
 						if buffer[position] != rune('\r') {
-							goto l465
+							goto l555
 						}
 						position++
 					}
-				l466:
-					goto l464
-				l465:
-					position, tokenIndex, depth = position465, tokenIndex465, depth465
+				l556:
+					goto l554
+				l555:
+					position, tokenIndex, depth = position555, tokenIndex555, depth555
 				}
 				depth--
-				add(rulews, position463)
+				add(rulews, position553)
 			}
 			return true
 		},
-		/* 104 req_ws <- <(' ' / '\t' / '\n' / '\r')+> */
+		/* 110 req_ws <- <(' ' / '\t' / '\n' / '\r')+> */
 		func() bool {
-			position470, tokenIndex470, depth470 := position, tokenIndex, depth
+			position560, tokenIndex560, depth560 := position, tokenIndex, depth
 			{
-				position471 := position
+				position561 := position
 				depth++
 				{
-					position474, tokenIndex474, depth474 := position, tokenIndex, depth
+					position564, tokenIndex564, depth564 := position, tokenIndex, depth
 					if buffer[position] != rune(' ') {
-						goto l475
+						goto l565
 					}
 					position++
-					goto l474
-				l475:
-					position, tokenIndex, depth = position474, tokenIndex474, depth474
+					goto l564
+				l565:
+					position, tokenIndex, depth = position564, tokenIndex564, depth564
 					if buffer[position] != rune('\t') {
-						goto l476
+						goto l566
 					}
 					position++
-					goto l474
-				l476:
-					position, tokenIndex, depth = position474, tokenIndex474, depth474
+					goto l564
+				l566:
+					position, tokenIndex, depth = position564, tokenIndex564, depth564
 					if buffer[position] != rune('\n') {
-						goto l477
+						goto l567
 					}
 					position++
-					goto l474
-				l477:
-					position, tokenIndex, depth = position474, tokenIndex474, depth474
+					goto l564
+				l567:
+					position, tokenIndex, depth = position564, tokenIndex564, depth564
 					if buffer[position] != rune('\r') {
-						goto l470
+						goto l560
 					}
 					position++
 				}
-			l474:
-			l472:
+			l564:
+			l562:
 				{
-					position473, tokenIndex473, depth473 := position, tokenIndex, depth
+					position563, tokenIndex563, depth563 := position, tokenIndex, depth
 					{
-						position478, tokenIndex478, depth478 := position, tokenIndex, depth
+						position568, tokenIndex568, depth568 := position, tokenIndex, depth
 						if buffer[position] != rune(' ') {
-							goto l479
+							goto l569
 						}
 						position++
-						goto l478
-					l479:
-						position, tokenIndex, depth = position478, tokenIndex478, depth478
+						goto l568
+					l569:
+						position, tokenIndex, depth = position568, tokenIndex568, depth568
 						if buffer[position] != rune('\t') {
-							goto l480
+							goto l570
 						}
 						position++
-						goto l478
-					l480:
-						position, tokenIndex, depth = position478, tokenIndex478, depth478
+						goto l568
+					l570:
+						position, tokenIndex, depth = position568, tokenIndex568, depth568
 						if buffer[position] != rune('\n') {
-							goto l481
+							goto l571
 						}
 						position++
-						goto l478
-					l481:
-						position, tokenIndex, depth = position478, tokenIndex478, depth478
+						goto l568
+					l571:
+						position, tokenIndex, depth = position568, tokenIndex568, depth568
 						if buffer[position] != rune('\r') {
-							goto l473
+							goto l563
 						}
 						position++
 					}
-				l478:
-					goto l472
-				l473:
-					position, tokenIndex, depth = position473, tokenIndex473, depth473
+				l568:
+					goto l562
+				l563:
+					position, tokenIndex, depth = position563, tokenIndex563, depth563
 				}
 				depth--
-				add(rulereq_ws, position471)
+				add(rulereq_ws, position561)
 			}
 			return true
-		l470:
-			position, tokenIndex, depth = position470, tokenIndex470, depth470
+		l560:
+			position, tokenIndex, depth = position560, tokenIndex560, depth560
 			return false
 		},
-		/* 106 Action0 <- <{}> */
+		/* 112 Action0 <- <{}> */
 		func() bool {
 			{
 				add(ruleAction0, position)
 			}
 			return true
 		},
-		/* 107 Action1 <- <{}> */
+		/* 113 Action1 <- <{}> */
 		func() bool {
 			{
 				add(ruleAction1, position)
 			}
 			return true
 		},
-		/* 108 Action2 <- <{}> */
+		/* 114 Action2 <- <{}> */
 		func() bool {
 			{
 				add(ruleAction2, position)
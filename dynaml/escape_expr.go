@@ -0,0 +1,29 @@
+package dynaml
+
+import (
+	"github.com/mandelsoft/spiff/yaml"
+)
+
+func func_escape_expr(arguments []interface{}, binding Binding) (interface{}, EvaluationInfo, bool) {
+	interpol, delims := interpolationSettings(binding)
+	return _modifystring("escape_expr", func(s string) string {
+		return yaml.EscapeDynaml(s, interpol, delims)
+	}, arguments, binding)
+}
+
+func func_unescape_expr(arguments []interface{}, binding Binding) (interface{}, EvaluationInfo, bool) {
+	interpol, delims := interpolationSettings(binding)
+	return _modifystring("unescape_expr", func(s string) string {
+		return yaml.UnescapeDynaml(yaml.NewNode(s, ""), interpol, delims).Value().(string)
+	}, arguments, binding)
+}
+
+// interpolationSettings provides the interpolation settings of the
+// processing state used to detect expressions in strings.
+func interpolationSettings(binding Binding) (bool, yaml.Delimiters) {
+	if binding == nil || binding.GetState() == nil {
+		return false, yaml.DefaultDelimiters
+	}
+	state := binding.GetState()
+	return state.InterpolationEnabled(), state.InterpolationDelimiters()
+}
@@ -1,6 +1,9 @@
 package dynaml
 
 import (
+	"math/rand"
+	"time"
+
 	"github.com/mandelsoft/vfs/pkg/vfs"
 
 	"github.com/mandelsoft/spiff/features"
@@ -28,18 +31,34 @@ type ExecCache interface {
 type State interface {
 	GetTempName(data []byte) (string, error)
 	GetFileContent(file string, cached bool) ([]byte, error)
+	FileExists(file string, directory bool) bool
 	GetEncryptionKey() string
+	GetEncryptionMethod() string
 	OSAccessAllowed() bool
 	FileAccessAllowed() bool
+	OnlineAllowed() bool
+	GetHTTPLimits() (time.Duration, int64)
 	FileSystem() vfs.VFS
 	GetRegistry() Registry
 	GetFeatures() features.FeatureFlags
 	GetExecCache() ExecCache
+	GetRandom() *rand.Rand
+	GetIncludeDirs() []string
+	GetIncludeStack() []string
+	PushInclude(file string) error
+	PopInclude()
+	PushNesting(name string) error
+	PopNesting()
+	GetTracer() Tracer
+	GetProfiler() *Profiler
 	InterpolationEnabled() bool
+	InterpolationDelimiters() yaml.Delimiters
 	ControlEnabled() bool
 	SetTag(name string, node yaml.Node, path []string, scope TagScope) error
 	GetTag(name string) *Tag
 	GetTags(name string) []*TagInfo
+	GetCachedValue(key string) (interface{}, bool)
+	SetCachedValue(key string, value interface{})
 }
 
 type Binding interface {
@@ -84,12 +103,15 @@ type EvaluationInfo struct {
 	Preferred    bool
 	KeyName      string
 	Source       string
+	Position     yaml.Position
 	LocalError   bool
 	Failed       bool
 	Undefined    bool
 	Raw          bool
 	Issue        yaml.Issue
 	Cleanups     []Cleanup
+	Warnings     []yaml.Issue // non-fatal issues of a successful evaluation
+	Deprecated   []string     // deprecated functions used by the evaluation
 	yaml.NodeFlags
 }
 
@@ -139,9 +161,9 @@ func (e EvaluationInfo) SourceName() string {
 
 func DefaultInfo() EvaluationInfo {
 	return EvaluationInfo{nil, false, false,
-		false, "", "",
+		false, "", "", yaml.Position{},
 		false, false, false, false,
-		yaml.Issue{}, nil, 0}
+		yaml.Issue{}, nil, nil, nil, 0}
 }
 
 type Expression interface {
@@ -169,6 +191,10 @@ func (i *EvaluationInfo) DenyOSOperation(name string) (interface{}, EvaluationIn
 	return i.Error("%s: no OS operations supported in this execution environment", name)
 }
 
+func (i *EvaluationInfo) DenyNetworkOperation(name string) (interface{}, EvaluationInfo, bool) {
+	return i.Error("%s: no network operations supported in this execution environment", name)
+}
+
 func (i *EvaluationInfo) Error(msgfmt interface{}, args ...interface{}) (interface{}, EvaluationInfo, bool) {
 	i.SetError(msgfmt, args...)
 	return nil, *i, false
@@ -192,6 +218,12 @@ func (i *EvaluationInfo) SetError(msgfmt interface{}, args ...interface{}) {
 	}
 }
 
+// Warn adds a non-fatal issue to the evaluation info. In contrast to
+// errors, warnings do not affect the success of the evaluation.
+func (i *EvaluationInfo) Warn(msgfmt string, args ...interface{}) {
+	i.Warnings = append(i.Warnings, yaml.NewIssue(msgfmt, args...))
+}
+
 func (i *EvaluationInfo) PropagateError(value interface{}, state Status, msgfmt string, args ...interface{}) (interface{}, EvaluationInfo, bool) {
 	i.Issue, i.LocalError, i.Failed = state.Issue(msgfmt, args...)
 	if i.LocalError {
@@ -237,6 +269,8 @@ func (i EvaluationInfo) Join(o EvaluationInfo) EvaluationInfo {
 	i.NodeFlags |= o.NodeFlags
 
 	i.Cleanups = append(i.Cleanups, o.Cleanups...)
+	i.Warnings = append(i.Warnings[:len(i.Warnings):len(i.Warnings)], o.Warnings...)
+	i.Deprecated = append(i.Deprecated[:len(i.Deprecated):len(i.Deprecated)], o.Deprecated...)
 	return i
 }
 
@@ -0,0 +1,80 @@
+package dynaml
+
+import (
+	"github.com/mandelsoft/spiff/yaml"
+)
+
+func func_find(arguments []interface{}, binding Binding) (bool, interface{}, EvaluationInfo, bool) {
+	resolved, index, info, ok := findInList("find", true, arguments, binding)
+	if !resolved || !ok || index < 0 {
+		return resolved, nil, info, ok
+	}
+	return true, arguments[0].([]yaml.Node)[index].Value(), info, true
+}
+
+func func_find_index(arguments []interface{}, binding Binding) (bool, interface{}, EvaluationInfo, bool) {
+	resolved, index, info, ok := findInList("find_index", true, arguments, binding)
+	if !resolved || !ok {
+		return resolved, nil, info, ok
+	}
+	return true, int64(index), info, true
+}
+
+func func_any(arguments []interface{}, binding Binding) (bool, interface{}, EvaluationInfo, bool) {
+	resolved, index, info, ok := findInList("any", true, arguments, binding)
+	return resolved, ok && index >= 0, info, ok
+}
+
+func func_all(arguments []interface{}, binding Binding) (bool, interface{}, EvaluationInfo, bool) {
+	resolved, index, info, ok := findInList("all", false, arguments, binding)
+	return resolved, ok && index < 0, info, ok
+}
+
+func func_none(arguments []interface{}, binding Binding) (bool, interface{}, EvaluationInfo, bool) {
+	resolved, index, info, ok := findInList("none", true, arguments, binding)
+	return resolved, ok && index < 0, info, ok
+}
+
+// findInList determines the index of the first list entry for which a
+// predicate given as second argument yields the expected result. For other
+// values the check is the equality with the entry. The evaluation stops at
+// the first match, -1 is returned if nothing matches.
+func findInList(name string, expected bool, arguments []interface{}, binding Binding) (bool, int, EvaluationInfo, bool) {
+	info := DefaultInfo()
+
+	if len(arguments) != 2 {
+		info.SetError("%s requires two arguments (list and predicate or value)", name)
+		return true, -1, info, false
+	}
+	list, ok := arguments[0].([]yaml.Node)
+	if !ok && arguments[0] != nil {
+		info.SetError("%s: first argument must be a list, but found %s", name, ExpressionType(arguments[0]))
+		return true, -1, info, false
+	}
+
+	lambda, ok := arguments[1].(LambdaValue)
+	for i, e := range list {
+		if !ok {
+			if eq, _, _ := compareEquals(e.Value(), arguments[1]); eq == expected {
+				return true, i, info, true
+			}
+			continue
+		}
+		resolved, v, sub, ok := lambda.Evaluate(false, false, false, nil, []interface{}{e.Value()}, binding, false)
+		if !ok {
+			return true, -1, sub, false
+		}
+		if !resolved {
+			return false, -1, sub, true
+		}
+		b, ok := v.(bool)
+		if !ok {
+			info.SetError("%s: predicate for entry %d must return a bool, but found %s", name, i, ExpressionType(v))
+			return true, -1, info, false
+		}
+		if b == expected {
+			return true, i, info, true
+		}
+	}
+	return true, -1, info, true
+}
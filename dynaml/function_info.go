@@ -0,0 +1,256 @@
+package dynaml
+
+import (
+	"sort"
+)
+
+// FunctionInfo describes a dynaml function for documentation purposes.
+// The arity is given as fixed number (2), range (1-3) or minimum
+// number (2+) of arguments.
+type FunctionInfo struct {
+	Name        string `json:"name"`
+	Arity       string `json:"arity"`
+	Description string `json:"description"`
+	// Deprecated is the replacement of a deprecated function.
+	Deprecated string `json:"deprecated,omitempty"`
+}
+
+var function_infos = map[string]FunctionInfo{}
+
+// DescribeFunction registers the description of a function.
+func DescribeFunction(name, arity, description string) {
+	function_infos[name] = FunctionInfo{Name: name, Arity: arity, Description: description}
+}
+
+// builtin functions handled directly by the call expression
+var builtin_functions = map[string]FunctionInfo{}
+
+func describeBuiltin(name, arity, description string) {
+	builtin_functions[name] = FunctionInfo{Name: name, Arity: arity, Description: description}
+}
+
+func init() {
+	describeBuiltin("defined", "1", "check whether an expression can be evaluated")
+	describeBuiltin("require", "1-2", "fail (with an optional message) if an expression is undefined or evaluates to nil")
+	describeBuiltin("require_all", "1-2", "fail (with an optional message) if a list entry is undefined or nil")
+	describeBuiltin("valid", "1", "check whether an expression evaluates to a non-nil value")
+	describeBuiltin("stub", "0-1", "get the value of a field from the stubs")
+	describeBuiltin("catch", "1", "evaluate an expression and return its value and error")
+	describeBuiltin("sync", "1-3", "wait for a condition on the result of an expression")
+	describeBuiltin("coalesce", "1+", "return the first argument that is defined and not nil")
+	describeBuiltin("switch", "2-3", "select the value for a key from a map of cases")
+	describeBuiltin("cond", "1-2", "select the value of the first matching condition")
+	describeBuiltin("with", "2", "evaluate an expression with fallback values for unresolved references")
+	describeBuiltin("static_ips", "1+", "calculate ip addresses from the static ranges of a network")
+	describeBuiltin("join", "1+", "join strings and lists with a separator")
+	describeBuiltin("join_with", "2-4", "join list entries rendered by a lambda with a separator")
+	describeBuiltin("split", "2-3", "split a string by a separator")
+	describeBuiltin("split_match", "2-3", "split a string by a regular expression")
+	describeBuiltin("trim", "1-2", "trim characters from strings")
+	describeBuiltin("length", "1", "get the number of characters of a string or entries of a list or map")
+	describeBuiltin("size", "1", "get the number of characters of a string or entries of a list or map (alias for length)")
+	describeBuiltin("uniq", "1", "remove duplicate list entries")
+	describeBuiltin("element", "2", "get a list element or map entry")
+	describeBuiltin("contains", "2", "check whether a list contains a value")
+	describeBuiltin("index", "2", "get the first index of a value in a list or string")
+	describeBuiltin("lastindex", "2", "get the last index of a value in a list or string")
+	describeBuiltin("replace", "3-4", "replace substrings")
+	describeBuiltin("replace_match", "3-4", "replace matches of a regular expression")
+	describeBuiltin("match", "2-3", "match a string against a regular expression")
+	describeBuiltin("sort", "1-2", "sort a list")
+	describeBuiltin("apply", "1-3", "call a lambda with a list of arguments")
+	describeBuiltin("pipeline", "1+", "pass a value through a sequence of lambdas")
+	describeBuiltin("compose", "0+", "compose lambdas to a new lambda applying them from left to right")
+	describeBuiltin("partition", "2", "split a list into matching and non-matching entries")
+	describeBuiltin("count", "2", "count the list entries matching a predicate or value")
+	describeBuiltin("count_by", "2", "count the list entries per key determined by a lambda")
+	describeBuiltin("find", "2", "get the first list entry matching a predicate or value")
+	describeBuiltin("find_index", "2", "get the index of the first list entry matching a predicate or value")
+	describeBuiltin("any", "2", "check whether any list entry matches a predicate or value")
+	describeBuiltin("all", "2", "check whether all list entries match a predicate or value")
+	describeBuiltin("none", "2", "check whether no list entry matches a predicate or value")
+	describeBuiltin("map_values", "2", "map the values of a map by a lambda")
+	describeBuiltin("map_keys", "2", "map the keys of a map by a lambda")
+	describeBuiltin("mapentries", "2", "map the entries of a map to new key/value pairs by a lambda")
+	describeBuiltin("filter_map", "2", "select the entries of a map by a predicate on key and value")
+	describeBuiltin("filter_keys", "2", "select the entries of a map by a predicate on the key")
+	describeBuiltin("filter_values", "2", "select the entries of a map by a predicate on the value")
+	describeBuiltin("exec", "1+", "execute a command and parse its output (cached)")
+	describeBuiltin("exec_uncached", "1+", "execute a command and parse its output")
+	describeBuiltin("pipe", "2+", "pipe data to a command and parse its output (cached)")
+	describeBuiltin("pipe_uncached", "2+", "pipe data to a command and parse its output")
+	describeBuiltin("http_get", "1", "get the body of an http(s) url")
+	describeBuiltin("http_get_json", "1", "get and parse the json body of an http(s) url")
+	describeBuiltin("eval", "1", "evaluate a string as dynaml expression")
+	describeBuiltin("env", "1+", "get the values of environment variables")
+	describeBuiltin("rand", "0-2", "generate a random number")
+	describeBuiltin("read", "1-2", "read and parse a file (cached)")
+	describeBuiltin("read_uncached", "1-2", "read and parse a file")
+	describeBuiltin("write", "2-3", "write data to a file")
+	describeBuiltin("lookup_file", "2+", "find files in a search path")
+	describeBuiltin("lookup_dir", "2+", "find directories in a search path")
+	describeBuiltin("include", "1", "include and evaluate a yaml file")
+	describeBuiltin("lookup_read", "2-4", "read the first file found in a search path")
+	describeBuiltin("list_files", "1", "list the files of a directory")
+	describeBuiltin("list_dirs", "1", "list the sub directories of a directory")
+	describeBuiltin("tempfile", "1-2", "write data to a temporary file")
+	describeBuiltin("format", "1+", "format values according to a format string")
+	describeBuiltin("error", "0+", "fail with an error message")
+	describeBuiltin("min_ip", "1", "get the first ip address of a CIDR")
+	describeBuiltin("max_ip", "1", "get the last ip address of a CIDR")
+	describeBuiltin("num_ip", "1", "get the number of ip addresses of a CIDR")
+	describeBuiltin("contains_ip", "2", "check whether a CIDR contains an ip address")
+	describeBuiltin("makemap", "0+", "create a map from key/value pairs")
+	describeBuiltin("list_to_map", "1-2", "create a map from a list of maps with a key field")
+	describeBuiltin("ipset", "2+", "select ip addresses from ip ranges")
+	describeBuiltin("merge", "1+", "merge maps")
+	describeBuiltin("base64", "1-2", "base64 encode a string")
+	describeBuiltin("base64_decode", "1", "decode a base64 encoded string")
+	describeBuiltin("md5", "1", "calculate the md5 hash of a string")
+	describeBuiltin("hash", "1-2", "calculate a hash of a string")
+	describeBuiltin("bcrypt", "1-2", "calculate a bcrypt password hash")
+	describeBuiltin("bcrypt_check", "2", "check a password against a bcrypt hash")
+	describeBuiltin("md5crypt", "1", "calculate an apache md5crypt password hash")
+	describeBuiltin("md5crypt_check", "2", "check a password against an apache md5crypt hash")
+	describeBuiltin("asjson", "1", "render a value as json")
+	describeBuiltin("asyaml", "1", "render a value as yaml")
+	describeBuiltin("parse", "1-2", "parse a yaml or json document")
+	describeBuiltin("substr", "2-3", "get a substring")
+	describeBuiltin("lower", "1", "convert a string to lower case")
+	describeBuiltin("upper", "1", "convert a string to upper case")
+	describeBuiltin("swapcase", "1", "invert the case of all letters of a string")
+	describeBuiltin("escape_expr", "1", "escape the dynaml expressions of a string")
+	describeBuiltin("unescape_expr", "1", "unescape the escaped dynaml expressions of a string")
+	describeBuiltin("keys", "1", "get the sorted keys of a map")
+	describeBuiltin("archive", "1-2", "create a tar or targz archive")
+	describeBuiltin("validate", "2+", "validate a value against validators")
+	describeBuiltin("check", "2+", "check a value against validators")
+	describeBuiltin("type", "1", "get the type of a value")
+	describeBuiltin("type_of", "1", "get the type of a value (alias for type)")
+
+	DescribeFunction("abs", "1", "absolute value of a number")
+	DescribeFunction("acos", "1", "arc cosine of a number")
+	DescribeFunction("acosh", "1", "inverse hyperbolic cosine of a number")
+	DescribeFunction("argon2", "1-2", "calculate an argon2id password hash")
+	DescribeFunction("argon2_check", "2", "check a password against an argon2id hash")
+	DescribeFunction("asfloat", "1", "coerce a value to a floating point number")
+	DescribeFunction("asfloats", "1", "coerce all entries of a list to floating point numbers")
+	DescribeFunction("asin", "1", "arc sine of a number")
+	DescribeFunction("asinh", "1", "inverse hyperbolic sine of a number")
+	DescribeFunction("asint", "1", "coerce a value to an integer")
+	DescribeFunction("asints", "1", "coerce all entries of a list to integers")
+	DescribeFunction("asstring", "1", "coerce a value to a string")
+	DescribeFunction("asstrings", "1", "coerce all entries of a list to strings")
+	DescribeFunction("assert", "2-3", "fail with a message if a condition is false")
+	DescribeFunction("assert_type", "2-3", "fail with a message if a value has another type")
+	DescribeFunction("basename", "1", "get the last element of a path")
+	DescribeFunction("bool", "1", "convert a value to a boolean")
+	DescribeFunction("ceil", "1", "round a number up")
+	DescribeFunction("compact", "1", "remove empty entries from a list")
+	DescribeFunction("concat", "0+", "concatenate strings, append lists or merge maps")
+	DescribeFunction("cos", "1", "cosine of a number")
+	DescribeFunction("cosh", "1", "hyperbolic cosine of a number")
+	DescribeFunction("decode_and_parse", "1-2", "decode a base64 string and parse it as yaml or json")
+	DescribeFunction("deflate", "1-2", "compress data with deflate")
+	DescribeFunction("default_deep", "2-3", "fill in missing fields of a value from defaults recursively")
+	DescribeFunction("derive_key", "2-5", "derive an encryption key from a passphrase with scrypt or pbkdf2")
+	DescribeFunction("dirname", "1", "get the directory part of a path")
+	DescribeFunction("ends_with", "2", "check whether a string ends with a suffix")
+	DescribeFunction("exp", "1", "exponential of a number")
+	DescribeFunction("features", "0-1", "get the enabled features or check for a feature")
+	DescribeFunction("float", "1", "convert a value to a floating point number")
+	DescribeFunction("floor", "1", "round a number down")
+	DescribeFunction("gunzip", "1", "decompress gzip data")
+	DescribeFunction("gzip", "1-2", "compress data with gzip")
+	DescribeFunction("hex", "1", "hex encode a string")
+	DescribeFunction("htmlescape", "1", "escape html special characters")
+	DescribeFunction("htmlunescape", "1", "unescape html entities")
+	DescribeFunction("indent", "2-3", "indent the lines of a text")
+	DescribeFunction("index_of", "2-3", "get the index of a substring")
+	DescribeFunction("inflate", "1", "decompress deflate data")
+	DescribeFunction("inspect", "1-2", "print a labeled value in debug mode and return it unchanged")
+	DescribeFunction("integer", "1", "convert a value to an integer")
+	DescribeFunction("intersect", "1+", "get the common entries of lists")
+	DescribeFunction("is_bool", "1", "check whether a value is a boolean")
+	DescribeFunction("is_float", "1", "check whether a value is a floating point number")
+	DescribeFunction("is_int", "1", "check whether a value is an integer")
+	DescribeFunction("is_lambda", "1", "check whether a value is a lambda")
+	DescribeFunction("is_list", "1", "check whether a value is a list")
+	DescribeFunction("is_map", "1", "check whether a value is a map")
+	DescribeFunction("is_nil", "1", "check whether a value is nil")
+	DescribeFunction("is_number", "1", "check whether a value is an integer or floating point number")
+	DescribeFunction("is_string", "1", "check whether a value is a string")
+	DescribeFunction("is_template", "1", "check whether a value is a template")
+	DescribeFunction("leaves", "1-2", "get a map of all leaf paths of a structure to their values")
+	DescribeFunction("log", "1", "natural logarithm of a number")
+	DescribeFunction("log10", "1", "decimal logarithm of a number")
+	DescribeFunction("merge_by_key", "3", "merge two lists of maps by a key field")
+	DescribeFunction("merge_deep", "0+", "merge maps recursively")
+	DescribeFunction("mkdir", "1-2", "create a directory")
+	DescribeFunction("paths", "1-2", "get the list of all leaf paths of a structure")
+	DescribeFunction("query_encode", "1", "encode a map as url query")
+	DescribeFunction("random_choice", "1", "select a random list entry")
+	DescribeFunction("random_int", "2", "generate a random integer in a range")
+	DescribeFunction("random_string", "1-2", "generate a random string")
+	DescribeFunction("reverse", "1", "reverse a list")
+	DescribeFunction("round", "1", "round a number")
+	DescribeFunction("roundtoeven", "1", "round a number to even")
+	DescribeFunction("shellquote", "1", "quote a string for shell usage")
+	DescribeFunction("sin", "1", "sine of a number")
+	DescribeFunction("sinh", "1", "hyperbolic sine of a number")
+	DescribeFunction("split_n", "3", "split a string into a maximum number of parts")
+	DescribeFunction("split_regex", "2-3", "split a string by a regular expression")
+	DescribeFunction("sqrt", "1", "square root of a number")
+	DescribeFunction("starts_with", "2", "check whether a string starts with a prefix")
+	DescribeFunction("string", "1", "convert a value to a string")
+	DescribeFunction("trim_prefix", "2", "remove a prefix from a string")
+	DescribeFunction("trim_space", "1", "remove leading and trailing white space")
+	DescribeFunction("trim_suffix", "2", "remove a suffix from a string")
+	DescribeFunction("unhex", "1", "decode a hex encoded string")
+	DescribeFunction("urldecode", "1", "decode an url encoded string")
+	DescribeFunction("urlencode", "1", "url encode a string")
+	DescribeFunction("wrap", "2", "wrap a text at a given width")
+	DescribeFunction(F_TagDef, "2-3", "define a tag")
+	DescribeFunction(F_ParseURL, "1", "parse an url into its elements")
+	DescribeFunction(F_URLParse, "1", "parse an url into its elements")
+	DescribeFunction(F_URLBuild, "1", "build an url from its elements")
+}
+
+// ListFunctions returns the descriptions of all functions available
+// for the given function set (which may be nil) sorted by name. This
+// includes the builtin functions and all globally registered ones.
+// Functions without description are listed with an unknown arity,
+// deprecated functions with their replacement.
+func ListFunctions(functions Functions) []FunctionInfo {
+	found := map[string]FunctionInfo{}
+	for n, i := range builtin_functions {
+		found[n] = i
+	}
+	add := func(r Functions) {
+		if reg, ok := r.(*functionRegistry); ok && reg != nil {
+			for n := range reg.functions {
+				if i, ok := function_infos[n]; ok {
+					found[n] = i
+				} else {
+					found[n] = FunctionInfo{Name: n, Arity: "?"}
+				}
+			}
+		}
+	}
+	add(function_registry)
+	if functions != nil {
+		add(functions)
+	}
+
+	lookup := function_registry.LookupDeprecation
+	if functions != nil {
+		lookup = functions.LookupDeprecation
+	}
+	result := make([]FunctionInfo, 0, len(found))
+	for n, i := range found {
+		i.Deprecated, _ = lookup(n)
+		result = append(result, i)
+	}
+	sort.Slice(result, func(i, j int) bool { return result[i].Name < result[j].Name })
+	return result
+}
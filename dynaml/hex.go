@@ -0,0 +1,42 @@
+package dynaml
+
+import (
+	"encoding/hex"
+)
+
+func init() {
+	RegisterFunction("hex", func_hex)
+	RegisterFunction("unhex", func_unhex)
+}
+
+func func_hex(arguments []interface{}, binding Binding) (interface{}, EvaluationInfo, bool) {
+	info := DefaultInfo()
+
+	if len(arguments) != 1 {
+		return info.Error("hex takes exactly one argument")
+	}
+
+	str, ok := arguments[0].(string)
+	if !ok {
+		return info.Error("argument for hex must be a string")
+	}
+	return hex.EncodeToString([]byte(str)), info, true
+}
+
+func func_unhex(arguments []interface{}, binding Binding) (interface{}, EvaluationInfo, bool) {
+	info := DefaultInfo()
+
+	if len(arguments) != 1 {
+		return info.Error("unhex takes exactly one argument")
+	}
+
+	str, ok := arguments[0].(string)
+	if !ok {
+		return info.Error("argument for unhex must be a string")
+	}
+	result, err := hex.DecodeString(str)
+	if err != nil {
+		return info.Error("unhex: %s", err)
+	}
+	return string(result), info, true
+}
@@ -0,0 +1,72 @@
+package dynaml
+
+import (
+	"fmt"
+	"io"
+	"io/ioutil"
+	"net/http"
+	"strings"
+
+	"github.com/mandelsoft/spiff/debug"
+	"github.com/mandelsoft/spiff/yaml"
+)
+
+func func_http_get(parse bool, arguments []interface{}, binding Binding) (interface{}, EvaluationInfo, bool) {
+	info := DefaultInfo()
+
+	name := "http_get"
+	if parse {
+		name = "http_get_json"
+	}
+	if len(arguments) != 1 {
+		return info.Error("%s requires exactly one argument", name)
+	}
+	if !binding.GetState().OnlineAllowed() {
+		return info.DenyNetworkOperation(name)
+	}
+	url, ok := arguments[0].(string)
+	if !ok {
+		return info.Error("%s: url must be a string, but found %s", name, ExpressionType(arguments[0]))
+	}
+	if !strings.HasPrefix(url, "http://") && !strings.HasPrefix(url, "https://") {
+		return info.Error("%s: http or https url required, but found %q", name, url)
+	}
+
+	data, err := HTTPGet(url, binding.GetState())
+	if err != nil {
+		return info.Error("%s: %s", name, err)
+	}
+	if !parse {
+		return string(data), info, true
+	}
+	node, err := yaml.Parse(url, data)
+	if err != nil {
+		return info.Error("%s: error parsing response of [%s]: %s", name, url, err)
+	}
+	info.Raw = true
+	return node.Value(), info, true
+}
+
+// HTTPGet gets the body of a successful response for the given url
+// respecting the timeout and size limit configured for the state.
+func HTTPGet(url string, state State) ([]byte, error) {
+	timeout, max := state.GetHTTPLimits()
+	debug.Debug("http get %s (timeout %s)\n", url, timeout)
+	client := &http.Client{Timeout: timeout}
+	response, err := client.Get(url)
+	if err != nil {
+		return nil, err
+	}
+	defer response.Body.Close()
+	if response.StatusCode < 200 || response.StatusCode > 299 {
+		return nil, fmt.Errorf("getting [%s] failed with status %s", url, response.Status)
+	}
+	data, err := ioutil.ReadAll(io.LimitReader(response.Body, max+1))
+	if err != nil {
+		return nil, fmt.Errorf("error reading response of [%s]: %s", url, err)
+	}
+	if int64(len(data)) > max {
+		return nil, fmt.Errorf("response of [%s] exceeds %d bytes", url, max)
+	}
+	return data, nil
+}
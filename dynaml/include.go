@@ -0,0 +1,67 @@
+package dynaml
+
+import (
+	"path"
+	"path/filepath"
+	"strings"
+)
+
+func func_include(arguments []interface{}, binding Binding) (interface{}, EvaluationInfo, bool) {
+	info := DefaultInfo()
+
+	if len(arguments) != 1 {
+		return info.Error("include requires exactly one argument")
+	}
+	state := binding.GetState()
+	if !state.FileAccessAllowed() {
+		return info.DenyOSOperation("include")
+	}
+
+	name, ok := arguments[0].(string)
+	if !ok {
+		return info.Error("include: argument must be a string")
+	}
+	if name == "" {
+		return info.Error("include: argument is empty string")
+	}
+
+	var paths []string
+	if !filepath.IsAbs(name) {
+		// nested includes are resolved relative to the including file first
+		if stack := state.GetIncludeStack(); len(stack) > 0 {
+			paths = append(paths, path.Dir(stack[len(stack)-1]))
+		}
+		paths = append(paths, state.GetIncludeDirs()...)
+		if len(state.GetIncludeDirs()) == 0 {
+			paths = append(paths, ".")
+		}
+	} else {
+		paths = []string{""}
+	}
+
+	file := ""
+	for _, d := range paths {
+		p := name
+		if d != "" {
+			p = d + "/" + name
+		}
+		if checkExistence(binding, p, false) {
+			file = p
+			break
+		}
+	}
+	if file == "" {
+		return info.Error("include: %q not found in include path [%s]", name, strings.Join(paths, ", "))
+	}
+
+	if err := state.PushInclude(file); err != nil {
+		return info.Error("%s", err)
+	}
+	defer state.PopInclude()
+
+	data, err := binding.GetFileContent(file, true)
+	if err != nil {
+		return info.Error("include: %s", err)
+	}
+	return ParseData(file, data, "yaml", binding)
+}
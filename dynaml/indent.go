@@ -0,0 +1,112 @@
+package dynaml
+
+import (
+	"strings"
+	"unicode/utf8"
+)
+
+func init() {
+	RegisterFunction("indent", func_indent)
+	RegisterFunction("wrap", func_wrap)
+}
+
+func func_indent(arguments []interface{}, binding Binding) (interface{}, EvaluationInfo, bool) {
+	info := DefaultInfo()
+
+	if len(arguments) < 2 || len(arguments) > 3 {
+		return info.Error("indent takes two or three arguments")
+	}
+
+	text, ok := arguments[0].(string)
+	if !ok {
+		return info.Error("first argument for indent must be a string")
+	}
+
+	var prefix string
+	switch v := arguments[1].(type) {
+	case int64:
+		if v < 0 {
+			return info.Error("indentation for indent must not be negative")
+		}
+		prefix = strings.Repeat(" ", int(v))
+	case string:
+		prefix = v
+	default:
+		return info.Error("second argument for indent must be an integer or string")
+	}
+
+	first := true
+	if len(arguments) == 3 {
+		first, ok = arguments[2].(bool)
+		if !ok {
+			return info.Error("third argument for indent must be a boolean")
+		}
+	}
+
+	lines := strings.SplitAfter(text, "\n")
+	for i, l := range lines {
+		if i == 0 && !first {
+			continue
+		}
+		if l == "" || l == "\n" || l == "\r\n" {
+			continue
+		}
+		lines[i] = prefix + l
+	}
+	return strings.Join(lines, ""), info, true
+}
+
+func func_wrap(arguments []interface{}, binding Binding) (interface{}, EvaluationInfo, bool) {
+	info := DefaultInfo()
+
+	if len(arguments) != 2 {
+		return info.Error("wrap takes exactly two arguments")
+	}
+
+	text, ok := arguments[0].(string)
+	if !ok {
+		return info.Error("first argument for wrap must be a string")
+	}
+	width, ok := arguments[1].(int64)
+	if !ok {
+		return info.Error("second argument for wrap must be an integer")
+	}
+	if width <= 0 {
+		return info.Error("column width for wrap must be positive")
+	}
+
+	lines := strings.SplitAfter(text, "\n")
+	for i, l := range lines {
+		eol := ""
+		if strings.HasSuffix(l, "\r\n") {
+			eol = "\r\n"
+		} else if strings.HasSuffix(l, "\n") {
+			eol = "\n"
+		}
+		lines[i] = wrapLine(l[:len(l)-len(eol)], int(width), eol) + eol
+	}
+	return strings.Join(lines, ""), info, true
+}
+
+func wrapLine(line string, width int, eol string) string {
+	if eol == "" {
+		eol = "\n"
+	}
+	result := ""
+	cur := 0
+	for _, w := range strings.Fields(line) {
+		l := utf8.RuneCountInString(w)
+		if cur > 0 {
+			if cur+1+l > width {
+				result += eol
+				cur = 0
+			} else {
+				result += " "
+				cur++
+			}
+		}
+		result += w
+		cur += l
+	}
+	return result
+}
@@ -4,8 +4,13 @@ import (
 	"github.com/mandelsoft/spiff/yaml"
 	"strconv"
 	"strings"
+	"unicode/utf8"
 )
 
+func init() {
+	RegisterFunction("index_of", func_index_of)
+}
+
 func func_index(arguments []interface{}, binding Binding) (interface{}, EvaluationInfo, bool) {
 	return _index(true, strings.Index, arguments, binding)
 }
@@ -54,3 +59,39 @@ func _index(first bool, f func(string, string) int, arguments []interface{}, bin
 	}
 	return int64(found), info, true
 }
+
+func func_index_of(arguments []interface{}, binding Binding) (interface{}, EvaluationInfo, bool) {
+	info := DefaultInfo()
+
+	if len(arguments) < 2 || len(arguments) > 3 {
+		return info.Error("index_of takes two or three arguments")
+	}
+
+	str, ok := arguments[0].(string)
+	if !ok {
+		return info.Error("first argument of index_of must be a string")
+	}
+	sub, ok := arguments[1].(string)
+	if !ok {
+		return info.Error("second argument of index_of must be a string")
+	}
+
+	runes := []rune(str)
+	start := int64(0)
+	if len(arguments) == 3 {
+		start, ok = arguments[2].(int64)
+		if !ok {
+			return info.Error("third argument of index_of must be an integer")
+		}
+		if start < 0 || start > int64(len(runes)) {
+			return info.Error("start offset %d for index_of out of range [0,%d]", start, len(runes))
+		}
+	}
+
+	rest := string(runes[start:])
+	i := strings.Index(rest, sub)
+	if i < 0 {
+		return int64(-1), info, true
+	}
+	return start + int64(utf8.RuneCountInString(rest[:i])), info, true
+}
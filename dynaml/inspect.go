@@ -0,0 +1,46 @@
+package dynaml
+
+import (
+	"fmt"
+	"io"
+	"os"
+	"strings"
+
+	"github.com/mandelsoft/spiff/debug"
+	"github.com/mandelsoft/spiff/yaml"
+)
+
+// inspectWriter is the destination for the output of the inspect function.
+var inspectWriter io.Writer = os.Stderr
+
+func init() {
+	RegisterFunction("inspect", func_inspect)
+}
+
+func func_inspect(arguments []interface{}, binding Binding) (interface{}, EvaluationInfo, bool) {
+	info := DefaultInfo()
+
+	if len(arguments) < 1 || len(arguments) > 2 {
+		return info.Error("inspect takes one or two arguments")
+	}
+
+	label := "inspect"
+	if len(arguments) == 2 {
+		s, ok := arguments[1].(string)
+		if !ok {
+			return info.Error("second argument for inspect must be a string label")
+		}
+		label = s
+	}
+
+	if debug.DebugFlag {
+		var text string
+		if data, err := yaml.ValueToJSON(arguments[0]); err == nil {
+			text = string(data)
+		} else {
+			text = fmt.Sprintf("%v", arguments[0])
+		}
+		fmt.Fprintf(inspectWriter, "%s [%s]: %s\n", label, strings.Join(binding.Path(), "."), text)
+	}
+	return arguments[0], info, true
+}
@@ -1,49 +1,186 @@
 package dynaml
 
 import (
-	"strconv"
-	"strings"
-
 	"github.com/mandelsoft/spiff/yaml"
+	"strings"
 )
 
-func func_join(arguments []interface{}, binding Binding) (interface{}, EvaluationInfo, bool) {
+func func_join(arguments []interface{}, binding Binding) (bool, interface{}, EvaluationInfo, bool) {
 	info := DefaultInfo()
 
 	if len(arguments) < 1 {
-		return nil, info, false
+		return true, nil, info, false
+	}
+
+	sep, ok := arguments[0].(string)
+	if !ok {
+		info.SetError("first argument for join must be a string")
+		return true, nil, info, false
+	}
+
+	var lambda *LambdaValue
+	args := arguments[1:]
+	if len(args) > 0 {
+		if l, ok := args[len(args)-1].(LambdaValue); ok {
+			lambda = &l
+			args = args[:len(args)-1]
+		}
 	}
 
-	args := make([]string, 0)
-	for i, arg := range arguments {
+	j := &joiner{name: "join", lambda: lambda, binding: binding, info: info}
+	for i, arg := range args {
 		switch v := arg.(type) {
-		case string:
-			args = append(args, v)
-		case int64:
-			args = append(args, strconv.FormatInt(v, 10))
-		case bool:
-			args = append(args, strconv.FormatBool(v))
 		case []yaml.Node:
-			if i == 0 {
-				return info.Error("first argument for join must be a string")
-			}
 			for _, elem := range v {
-				switch e := elem.Value().(type) {
-				case string:
-					args = append(args, e)
-				case int64:
-					args = append(args, strconv.FormatInt(e, 10))
-				case bool:
-					args = append(args, strconv.FormatBool(e))
-				default:
-					return info.Error("elements of list(arg %d) to join must be simple values", i)
+				if !j.addListEntry(i+1, elem) {
+					return j.result(sep)
 				}
 			}
 		case nil:
 		default:
-			return info.Error("argument %d to join must be simple value or list", i)
+			if lambda == nil && !isSimpleValue(arg) {
+				info.SetError("argument %d to join must be simple value or list", i+1)
+				return true, nil, info, false
+			}
+			if !j.add(i+1, arg) {
+				return j.result(sep)
+			}
 		}
 	}
+	return j.result(sep)
+}
+
+func func_join_with(arguments []interface{}, binding Binding) (bool, interface{}, EvaluationInfo, bool) {
+	info := DefaultInfo()
+
+	if len(arguments) < 2 || len(arguments) > 4 {
+		info.SetError("join_with requires two to four arguments (separator, list, optional lambda and skip nil flag)")
+		return true, nil, info, false
+	}
+
+	sep, ok := arguments[0].(string)
+	if !ok {
+		info.SetError("first argument for join_with must be a string")
+		return true, nil, info, false
+	}
+	list, ok := arguments[1].([]yaml.Node)
+	if !ok && arguments[1] != nil {
+		info.SetError("join_with: second argument must be a list, but found %s", ExpressionType(arguments[1]))
+		return true, nil, info, false
+	}
 
-	return strings.Join(args[1:], args[0]), info, true
+	j := &joiner{name: "join_with", binding: binding, info: info}
+	for i, arg := range arguments[2:] {
+		switch v := arg.(type) {
+		case LambdaValue:
+			if i == 0 {
+				j.lambda = &v
+				continue
+			}
+		case bool:
+			if i == len(arguments)-3 {
+				j.skipNil = v
+				continue
+			}
+		case nil:
+			if i == 0 {
+				continue
+			}
+		}
+		info.SetError("join_with: argument %d must be a lambda or bool, but found %s", i+3, ExpressionType(arg))
+		return true, nil, info, false
+	}
+
+	for _, elem := range list {
+		if !j.addListEntry(2, elem) {
+			break
+		}
+	}
+	return j.result(sep)
+}
+
+// joiner collects the string representations of values to join.
+// If a lambda is given it is used to render every value. Lists found
+// as list entries are flattened one level, nil entries are rendered
+// as empty string or skipped.
+type joiner struct {
+	name    string
+	lambda  *LambdaValue
+	skipNil bool
+	binding Binding
+	args    []string
+
+	// state of an aborted join
+	unresolved bool
+	failed     bool
+	info       EvaluationInfo
+}
+
+func (j *joiner) addListEntry(arg int, elem yaml.Node) bool {
+	v := nodeValue(elem)
+	if l, ok := v.([]yaml.Node); ok {
+		for _, e := range l {
+			if !j.add(arg, nodeValue(e)) {
+				return false
+			}
+		}
+		return true
+	}
+	return j.add(arg, v)
+}
+
+func (j *joiner) add(arg int, v interface{}) bool {
+	if j.lambda != nil {
+		resolved, r, info, ok := j.lambda.Evaluate(false, false, false, nil, []interface{}{v}, j.binding, false)
+		if !ok || !resolved {
+			j.unresolved = !resolved
+			j.failed = !ok
+			j.info = info
+			return false
+		}
+		v = r
+	}
+	if v == nil {
+		if !j.skipNil {
+			j.args = append(j.args, "")
+		}
+		return true
+	}
+	s, err := convertToString(v)
+	if err != nil {
+		j.failed = true
+		if j.lambda != nil {
+			j.info.SetError("%s: lambda result for argument %d: %s", j.name, arg, err)
+		} else {
+			j.info.SetError("elements of list(arg %d) to %s must be simple values", arg, j.name)
+		}
+		return false
+	}
+	j.args = append(j.args, s)
+	return true
+}
+
+func (j *joiner) result(sep string) (bool, interface{}, EvaluationInfo, bool) {
+	switch {
+	case j.failed:
+		return true, nil, j.info, false
+	case j.unresolved:
+		return false, nil, j.info, true
+	}
+	return true, strings.Join(j.args, sep), j.info, true
+}
+
+func isSimpleValue(v interface{}) bool {
+	switch v.(type) {
+	case string, int64, float64, bool:
+		return true
+	}
+	return false
+}
+
+func nodeValue(n yaml.Node) interface{} {
+	if n == nil {
+		return nil
+	}
+	return n.Value()
 }
@@ -0,0 +1,48 @@
+package jwt
+
+import (
+	. "github.com/mandelsoft/spiff/dynaml"
+	"github.com/mandelsoft/spiff/yaml"
+)
+
+const F_Decode = "jwt_decode"
+
+func init() {
+	RegisterFunction(F_Decode, func_decode)
+	DescribeFunction(F_Decode, "1", "decode a json web token without verification")
+}
+
+// one argument
+//  - token
+// the signature is NOT verified
+
+func func_decode(arguments []interface{}, binding Binding) (interface{}, EvaluationInfo, bool) {
+	info := DefaultInfo()
+
+	if len(arguments) != 1 {
+		return info.Error("invalid argument count for %s(<token>)", F_Decode)
+	}
+	str, ok := arguments[0].(string)
+	if !ok {
+		return info.Error("token argument for %s must be a string", F_Decode)
+	}
+
+	t, err := parseToken(str)
+	if err != nil {
+		return info.Error("%s: %s", F_Decode, err)
+	}
+	h, err := decodePart("header", t.raw[0])
+	if err != nil {
+		return info.Error("%s: %s", F_Decode, err)
+	}
+	c, err := decodePart("claims", t.raw[1])
+	if err != nil {
+		return info.Error("%s: %s", F_Decode, err)
+	}
+
+	result := map[string]yaml.Node{
+		"header": NewNode(h, binding),
+		"claims": NewNode(c, binding),
+	}
+	return result, info, true
+}
@@ -0,0 +1,140 @@
+package jwt
+
+import (
+	"crypto"
+	"crypto/hmac"
+	"crypto/rand"
+	"crypto/rsa"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/json"
+	"fmt"
+	"strings"
+
+	"github.com/mandelsoft/spiff/dynaml/x509"
+	"github.com/mandelsoft/spiff/yaml"
+)
+
+const (
+	ALG_HS256 = "HS256"
+	ALG_RS256 = "RS256"
+)
+
+var encoding = base64.RawURLEncoding
+
+type header struct {
+	Algorithm string `json:"alg"`
+	Type      string `json:"typ,omitempty"`
+}
+
+type token struct {
+	header    header
+	raw       []string
+	signature []byte
+}
+
+func checkAlgorithm(alg string) error {
+	switch alg {
+	case ALG_HS256, ALG_RS256:
+		return nil
+	default:
+		return fmt.Errorf("unsupported algorithm %q (use %s or %s)", alg, ALG_HS256, ALG_RS256)
+	}
+}
+
+// keyAlgorithm determines the algorithm a verification key is intended
+// for. PEM encoded RSA keys are used for RS256, all other keys are HS256
+// secrets.
+func keyAlgorithm(key string) string {
+	if _, err := x509.ParsePublicKey(key); err == nil {
+		return ALG_RS256
+	}
+	if _, err := x509.ParsePrivateKey(key); err == nil {
+		return ALG_RS256
+	}
+	return ALG_HS256
+}
+
+func parseToken(str string) (*token, error) {
+	parts := strings.Split(str, ".")
+	if len(parts) != 3 {
+		return nil, fmt.Errorf("invalid token format (expected three dot separated parts)")
+	}
+	data, err := encoding.DecodeString(parts[0])
+	if err != nil {
+		return nil, fmt.Errorf("invalid token header: %s", err)
+	}
+	t := &token{raw: parts}
+	if err := json.Unmarshal(data, &t.header); err != nil {
+		return nil, fmt.Errorf("invalid token header: %s", err)
+	}
+	t.signature, err = encoding.DecodeString(parts[2])
+	if err != nil {
+		return nil, fmt.Errorf("invalid token signature: %s", err)
+	}
+	return t, nil
+}
+
+func decodePart(name, part string) (interface{}, error) {
+	data, err := encoding.DecodeString(part)
+	if err != nil {
+		return nil, fmt.Errorf("invalid token %s: %s", name, err)
+	}
+	if !json.Valid(data) {
+		return nil, fmt.Errorf("invalid token %s: invalid json document", name)
+	}
+	node, err := yaml.Parse(name, data)
+	if err != nil {
+		return nil, fmt.Errorf("invalid token %s: %s", name, err)
+	}
+	return node.Value(), nil
+}
+
+func sign(alg string, key string, content string) ([]byte, error) {
+	switch alg {
+	case ALG_HS256:
+		mac := hmac.New(sha256.New, []byte(key))
+		mac.Write([]byte(content))
+		return mac.Sum(nil), nil
+	case ALG_RS256:
+		priv, err := x509.ParsePrivateKey(key)
+		if err != nil {
+			return nil, err
+		}
+		rsaKey, ok := priv.(*rsa.PrivateKey)
+		if !ok {
+			return nil, fmt.Errorf("%s requires an RSA private key", alg)
+		}
+		sum := sha256.Sum256([]byte(content))
+		return rsa.SignPKCS1v15(rand.Reader, rsaKey, crypto.SHA256, sum[:])
+	}
+	return nil, checkAlgorithm(alg)
+}
+
+func verify(alg string, key string, content string, signature []byte) (bool, error) {
+	switch alg {
+	case ALG_HS256:
+		expected, _ := sign(alg, key, content)
+		return hmac.Equal(signature, expected), nil
+	case ALG_RS256:
+		var rsaKey *rsa.PublicKey
+		pub, err := x509.ParsePublicKey(key)
+		if err != nil {
+			priv, perr := x509.ParsePrivateKey(key)
+			if perr != nil {
+				return false, err
+			}
+			if k, ok := priv.(*rsa.PrivateKey); ok {
+				rsaKey = &k.PublicKey
+			}
+		} else {
+			rsaKey, _ = pub.(*rsa.PublicKey)
+		}
+		if rsaKey == nil {
+			return false, fmt.Errorf("%s requires an RSA key", alg)
+		}
+		sum := sha256.Sum256([]byte(content))
+		return rsa.VerifyPKCS1v15(rsaKey, crypto.SHA256, sum[:], signature) == nil, nil
+	}
+	return false, checkAlgorithm(alg)
+}
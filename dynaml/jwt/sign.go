@@ -0,0 +1,63 @@
+package jwt
+
+import (
+	"encoding/json"
+
+	. "github.com/mandelsoft/spiff/dynaml"
+	"github.com/mandelsoft/spiff/yaml"
+)
+
+const F_Sign = "jwt_sign"
+
+func init() {
+	RegisterFunction(F_Sign, func_sign)
+	DescribeFunction(F_Sign, "2-3", "create a signed json web token")
+}
+
+// two or three arguments
+//  - claims map
+//  - signing key (secret for HS256, private key pem for RS256)
+//  - optional algorithm (default HS256)
+
+func func_sign(arguments []interface{}, binding Binding) (interface{}, EvaluationInfo, bool) {
+	info := DefaultInfo()
+
+	if len(arguments) < 2 || len(arguments) > 3 {
+		return info.Error("invalid argument count for %s(<claims>, <key>[, <algorithm>])", F_Sign)
+	}
+
+	claims, ok := arguments[0].(map[string]yaml.Node)
+	if !ok {
+		return info.Error("claims argument for %s must be a map", F_Sign)
+	}
+	key, ok := arguments[1].(string)
+	if !ok {
+		return info.Error("key argument for %s must be a string", F_Sign)
+	}
+	alg := ALG_HS256
+	if len(arguments) > 2 {
+		alg, ok = arguments[2].(string)
+		if !ok {
+			return info.Error("algorithm argument for %s must be a string", F_Sign)
+		}
+	}
+	if err := checkAlgorithm(alg); err != nil {
+		return info.Error("%s: %s", F_Sign, err)
+	}
+
+	hdata, err := json.Marshal(header{Algorithm: alg, Type: "JWT"})
+	if err != nil {
+		return info.Error("%s: %s", F_Sign, err)
+	}
+	cdata, err := yaml.ValueToJSON(claims)
+	if err != nil {
+		return info.Error("%s: invalid claims: %s", F_Sign, err)
+	}
+
+	content := encoding.EncodeToString(hdata) + "." + encoding.EncodeToString(cdata)
+	signature, err := sign(alg, key, content)
+	if err != nil {
+		return info.Error("%s: %s", F_Sign, err)
+	}
+	return content + "." + encoding.EncodeToString(signature), info, true
+}
@@ -0,0 +1,64 @@
+package jwt
+
+import (
+	. "github.com/mandelsoft/spiff/dynaml"
+)
+
+const F_Verify = "jwt_verify"
+
+func init() {
+	RegisterFunction(F_Verify, func_verify)
+	DescribeFunction(F_Verify, "2-3", "verify the signature of a json web token")
+}
+
+// two or three arguments
+//  - token
+//  - verification key (secret for HS256, public or private key pem for RS256)
+//  - optional expected algorithm, by default it is derived from the key
+//
+// The algorithm is never taken from the token itself. Tokens using
+// another algorithm are rejected.
+
+func func_verify(arguments []interface{}, binding Binding) (interface{}, EvaluationInfo, bool) {
+	info := DefaultInfo()
+
+	if len(arguments) < 2 || len(arguments) > 3 {
+		return info.Error("invalid argument count for %s(<token>, <key>[, <algorithm>])", F_Verify)
+	}
+	str, ok := arguments[0].(string)
+	if !ok {
+		return info.Error("token argument for %s must be a string", F_Verify)
+	}
+	key, ok := arguments[1].(string)
+	if !ok {
+		return info.Error("key argument for %s must be a string", F_Verify)
+	}
+
+	t, err := parseToken(str)
+	if err != nil {
+		return info.Error("%s: %s", F_Verify, err)
+	}
+	alg := keyAlgorithm(key)
+	expected := alg
+	if len(arguments) > 2 {
+		expected, ok = arguments[2].(string)
+		if !ok {
+			return info.Error("algorithm argument for %s must be a string", F_Verify)
+		}
+		if err := checkAlgorithm(expected); err != nil {
+			return info.Error("%s: %s", F_Verify, err)
+		}
+	}
+	if t.header.Algorithm != expected {
+		return info.Error("%s: algorithm mismatch: token uses %s, but %s expected", F_Verify, t.header.Algorithm, expected)
+	}
+	if alg != expected {
+		return info.Error("%s: key cannot be used for %s", F_Verify, expected)
+	}
+
+	valid, err := verify(alg, key, t.raw[0]+"."+t.raw[1], t.signature)
+	if err != nil {
+		return info.Error("%s: %s", F_Verify, err)
+	}
+	return valid, info, true
+}
@@ -319,12 +319,16 @@ func (e LambdaValue) Evaluate(inline bool, curry, autocurry bool, nargs map[stri
 				}
 			}
 		}
+		info := DefaultInfo()
+		if !curry {
+			info.Warn("implicit currying of lambda with %d of %d arguments is deprecated, use the currying operator", len(args), nparams)
+		}
 		return true, LambdaValue{
 			rest,
 			LambdaExpr{rest, varargs, e.lambda.E},
 			inp,
 			e.resolver,
-		}, DefaultInfo(), true
+		}, info, true
 	}
 	if len(args) < nparams {
 		for i := len(args); i < nparams; i++ {
@@ -358,9 +362,17 @@ func (e LambdaValue) Evaluate(inline bool, curry, autocurry bool, nargs map[stri
 		inp[yaml.SELF] = yaml.ResolverNode(NewNode(e, binding), e.resolver)
 		debug.Debug("LAMBDA CALL: effective local %+v\n", inp)
 	}
+	if err := pushNesting(binding, e.lambda.String()); err != nil {
+		info.SetError("%s", err)
+		return false, nil, info, false
+	}
 	value, info, ok := e.lambda.E.Evaluate(binding.WithLocalScope(inp), locally)
+	popNesting(binding)
 	if !ok {
 		debug.Debug("failed LAMBDA CALL: %s", info.Issue.Issue)
+		if isMaxDepthIssue(info.Issue) {
+			return false, nil, info, ok
+		}
 		nested := info.Issue
 		info.SetError("evaluation of lambda expression failed: %s: %s", e, Shorten(Short(inp, false)))
 		info.Issue.Nested = append(info.Issue.Nested, nested)
@@ -1,15 +1,19 @@
 package dynaml
 
 import (
+	"unicode/utf8"
+
 	"github.com/mandelsoft/spiff/yaml"
 )
 
-func func_length(arguments []interface{}, binding Binding) (interface{}, EvaluationInfo, bool) {
+// func_length determines the number of runes of a string, the number of
+// entries of a list or the number of keys of a map.
+func func_length(name string, arguments []interface{}, binding Binding) (interface{}, EvaluationInfo, bool) {
 	var result interface{}
 	info := DefaultInfo()
 
 	if len(arguments) != 1 {
-		return info.Error("length takes exactly 1 argument")
+		return info.Error("%s takes exactly 1 argument", name)
 	}
 
 	switch v := arguments[0].(type) {
@@ -18,9 +22,9 @@ func func_length(arguments []interface{}, binding Binding) (interface{}, Evaluat
 	case map[string]yaml.Node:
 		result = len(v)
 	case string:
-		result = len(v)
+		result = utf8.RuneCountInString(v)
 	default:
-		return info.Error("invalid type for function length")
+		return info.Error("invalid type %s for function %s", ExpressionType(v), name)
 	}
 	return yaml.MassageType(result), info, true
 }
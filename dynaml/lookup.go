@@ -1,10 +1,11 @@
 package dynaml
 
 import (
-	"github.com/mandelsoft/vfs/pkg/vfs"
+	"fmt"
+	"path/filepath"
+	"strings"
 
 	"github.com/mandelsoft/spiff/yaml"
-	"path/filepath"
 )
 
 func func_lookup(directory bool, arguments []interface{}, binding Binding) (interface{}, EvaluationInfo, bool) {
@@ -14,32 +15,12 @@ func func_lookup(directory bool, arguments []interface{}, binding Binding) (inte
 		return info.DenyOSOperation("lookup")
 	}
 
-	paths := []string{}
-
-	switch len(arguments) {
-	case 0, 1:
+	if len(arguments) < 2 {
 		return info.Error("lookup_file requires at least two arguments")
-	default:
-		for index, arg := range arguments[1:] {
-			switch v := arg.(type) {
-			case []yaml.Node:
-				for _, p := range v {
-					if p.Value() == nil {
-						continue
-					}
-					switch v := p.Value().(type) {
-					case string:
-						paths = append(paths, v)
-					default:
-						return info.Error("lookup_file: argument %d must be a list of strings", index)
-					}
-				}
-			case string:
-				paths = append(paths, v)
-			default:
-				return info.Error("lookup_file: argument %d must be a string or a list of strings", index)
-			}
-		}
+	}
+	paths, err := lookupPaths("lookup_file", arguments[1:])
+	if err != nil {
+		return info.Error("%s", err)
 	}
 
 	name, ok := arguments[0].(string)
@@ -70,13 +51,102 @@ func func_lookup(directory bool, arguments []interface{}, binding Binding) (inte
 	return result, info, true
 }
 
-func checkExistence(binding Binding, path string, directory bool) bool {
+func func_lookup_read(cached bool, arguments []interface{}, binding Binding) (interface{}, EvaluationInfo, bool) {
+	info := DefaultInfo()
+
 	if !binding.GetState().FileAccessAllowed() {
-		return false
+		return info.DenyOSOperation("lookup_read")
 	}
-	s, err := binding.GetState().FileSystem().Stat(path)
-	if vfs.IsErrNotExist(err) || err != nil {
+	if len(arguments) < 2 || len(arguments) > 4 {
+		return info.Error("lookup_read requires two to four arguments")
+	}
+
+	name, ok := arguments[0].(string)
+	if !ok {
+		return info.Error("lookup_read: first argument must be a string")
+	}
+	if name == "" {
+		return info.Error("lookup_read: first argument is empty string")
+	}
+	paths, err := lookupPaths("lookup_read", arguments[1:2])
+	if err != nil {
+		return info.Error("%s", err)
+	}
+
+	t := ""
+	required := false
+	for _, arg := range arguments[2:] {
+		switch v := arg.(type) {
+		case bool:
+			required = v
+		case string:
+			t = v
+		default:
+			return info.Error("lookup_read: optional arguments must be a type string or a required flag")
+		}
+	}
+
+	file := ""
+	if filepath.IsAbs(name) {
+		if checkExistence(binding, name, false) {
+			file = name
+		}
+	} else {
+		for _, d := range paths {
+			if d != "" && checkExistence(binding, d+"/"+name, false) {
+				file = d + "/" + name
+				break
+			}
+		}
+	}
+	if file == "" {
+		if required {
+			return info.Error("lookup_read: %q not found in [%s]", name, strings.Join(paths, ", "))
+		}
+		return nil, info, true
+	}
+
+	if t == "" {
+		t = "text"
+		if strings.HasSuffix(file, ".yml") || strings.HasSuffix(file, ".yaml") || strings.HasSuffix(file, ".json") {
+			t = "yaml"
+		}
+	}
+	data, err := binding.GetFileContent(file, cached)
+	if err != nil {
+		return info.Error("lookup_read: %s", err)
+	}
+	return ParseData(file, data, t, binding)
+}
+
+func lookupPaths(fname string, arguments []interface{}) ([]string, error) {
+	paths := []string{}
+	for index, arg := range arguments {
+		switch v := arg.(type) {
+		case []yaml.Node:
+			for _, p := range v {
+				if p.Value() == nil {
+					continue
+				}
+				switch v := p.Value().(type) {
+				case string:
+					paths = append(paths, v)
+				default:
+					return nil, fmt.Errorf("%s: argument %d must be a list of strings", fname, index)
+				}
+			}
+		case string:
+			paths = append(paths, v)
+		default:
+			return nil, fmt.Errorf("%s: argument %d must be a string or a list of strings", fname, index)
+		}
+	}
+	return paths, nil
+}
+
+func checkExistence(binding Binding, path string, directory bool) bool {
+	if !binding.GetState().FileAccessAllowed() {
 		return false
 	}
-	return s.IsDir() == directory
+	return binding.GetState().FileExists(path, directory)
 }
@@ -1,6 +1,9 @@
 package dynaml
 
-import "strings"
+import (
+	"strings"
+	"unicode"
+)
 
 func func_lower(arguments []interface{}, binding Binding) (interface{}, EvaluationInfo, bool) {
 	return _modifystring("lower", strings.ToLower, arguments, binding)
@@ -10,6 +13,22 @@ func func_upper(arguments []interface{}, binding Binding) (interface{}, Evaluati
 	return _modifystring("upper", strings.ToUpper, arguments, binding)
 }
 
+func func_swapcase(arguments []interface{}, binding Binding) (interface{}, EvaluationInfo, bool) {
+	return _modifystring("swapcase", swapCase, arguments, binding)
+}
+
+func swapCase(s string) string {
+	return strings.Map(func(r rune) rune {
+		switch {
+		case unicode.IsUpper(r), unicode.IsTitle(r):
+			return unicode.ToLower(r)
+		case unicode.IsLower(r):
+			return unicode.ToUpper(r)
+		}
+		return r
+	}, s)
+}
+
 func _modifystring(name string, mod func(string) string, arguments []interface{}, binding Binding) (interface{}, EvaluationInfo, bool) {
 
 	info := DefaultInfo()
@@ -20,7 +39,7 @@ func _modifystring(name string, mod func(string) string, arguments []interface{}
 
 	str, ok := arguments[0].(string)
 	if !ok {
-		return info.Error("first argument for %s must be a string", name)
+		return info.Error("first argument for %s must be a string, but found %s", name, ExpressionType(arguments[0]))
 	}
 
 	return mod(str), info, true
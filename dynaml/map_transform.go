@@ -0,0 +1,168 @@
+package dynaml
+
+import (
+	"fmt"
+
+	"github.com/mandelsoft/spiff/yaml"
+)
+
+// entryMapper maps a map entry and the result of the lambda evaluated for it
+// to the key and value of the new entry.
+type entryMapper func(key string, value yaml.Node, result interface{}) (string, interface{}, error)
+
+func func_map_values(arguments []interface{}, binding Binding) (bool, interface{}, EvaluationInfo, bool) {
+	return mapTransform("map_values", false, arguments, binding, func(key string, value yaml.Node, result interface{}) (string, interface{}, error) {
+		return key, result, nil
+	})
+}
+
+func func_map_keys(arguments []interface{}, binding Binding) (bool, interface{}, EvaluationInfo, bool) {
+	return mapTransform("map_keys", true, arguments, binding, func(key string, value yaml.Node, result interface{}) (string, interface{}, error) {
+		k, err := mapKey(result)
+		if err != nil {
+			return "", nil, fmt.Errorf("lambda for key %q must return a simple value, but found %s", key, ExpressionType(result))
+		}
+		return k, value.Value(), nil
+	})
+}
+
+func func_mapentries(arguments []interface{}, binding Binding) (bool, interface{}, EvaluationInfo, bool) {
+	return mapTransform("mapentries", true, arguments, binding, func(key string, value yaml.Node, result interface{}) (string, interface{}, error) {
+		l, ok := result.([]yaml.Node)
+		if !ok || len(l) != 2 {
+			return "", nil, fmt.Errorf("lambda for key %q must return a list with key and value, but found %s", key, ExpressionType(result))
+		}
+		k, err := mapKey(l[0].Value())
+		if err != nil {
+			return "", nil, fmt.Errorf("lambda for key %q must return a simple value as new key, but found %s", key, ExpressionType(l[0].Value()))
+		}
+		return k, l[1].Value(), nil
+	})
+}
+
+func mapKey(v interface{}) (string, error) {
+	if v == nil {
+		return "", fmt.Errorf("nil key")
+	}
+	return convertToString(v)
+}
+
+// mapTransform evaluates the lambda given as second argument for all entries
+// of a map in the order of their keys and composes a new map from the mapped
+// results. The lambda is called with the value, or, if withKey is set, with
+// the key (and the value for lambdas with two parameters). Different entries
+// mapped to the same key are reported as error.
+func mapTransform(name string, withKey bool, arguments []interface{}, binding Binding, mapper entryMapper) (bool, interface{}, EvaluationInfo, bool) {
+	info := DefaultInfo()
+
+	if len(arguments) != 2 {
+		info.SetError("%s requires two arguments (map and lambda)", name)
+		return true, nil, info, false
+	}
+	m, ok := arguments[0].(map[string]yaml.Node)
+	if !ok && arguments[0] != nil {
+		info.SetError("%s: first argument must be a map, but found %s", name, ExpressionType(arguments[0]))
+		return true, nil, info, false
+	}
+	lambda, ok := arguments[1].(LambdaValue)
+	if !ok {
+		info.SetError("%s: second argument must be a lambda value, but found %s", name, ExpressionType(arguments[1]))
+		return true, nil, info, false
+	}
+
+	result := map[string]yaml.Node{}
+	origin := map[string]string{}
+	for _, k := range getSortedKeys(m) {
+		inp := []interface{}{m[k].Value()}
+		if withKey {
+			inp = []interface{}{k, m[k].Value()}
+			if len(lambda.lambda.Parameters) == 1 {
+				inp = inp[:1]
+			}
+		}
+		resolved, v, sub, ok := lambda.Evaluate(false, false, false, nil, inp, binding, false)
+		if !ok {
+			return true, nil, sub, false
+		}
+		if !resolved {
+			return false, nil, sub, true
+		}
+		key, value, err := mapper(k, m[k], v)
+		if err != nil {
+			info.SetError("%s: %s", name, err)
+			return true, nil, info, false
+		}
+		if o, ok := origin[key]; ok {
+			info.SetError("%s: key %q generated for both, %q and %q", name, key, o, k)
+			return true, nil, info, false
+		}
+		origin[key] = k
+		result[key] = NewNode(value, binding)
+	}
+	return true, result, info, true
+}
+
+func func_filter_map(arguments []interface{}, binding Binding) (bool, interface{}, EvaluationInfo, bool) {
+	return filterMap("filter_map", func(k string, v yaml.Node, params int) []interface{} {
+		if params == 1 {
+			return []interface{}{v.Value()}
+		}
+		return []interface{}{k, v.Value()}
+	}, arguments, binding)
+}
+
+func func_filter_keys(arguments []interface{}, binding Binding) (bool, interface{}, EvaluationInfo, bool) {
+	return filterMap("filter_keys", func(k string, v yaml.Node, params int) []interface{} {
+		return []interface{}{k}
+	}, arguments, binding)
+}
+
+func func_filter_values(arguments []interface{}, binding Binding) (bool, interface{}, EvaluationInfo, bool) {
+	return filterMap("filter_values", func(k string, v yaml.Node, params int) []interface{} {
+		return []interface{}{v.Value()}
+	}, arguments, binding)
+}
+
+// filterMap evaluates the predicate given as second argument for all entries
+// of a map in the order of their keys and composes a new map from the
+// entries it yields true for. The arguments for the predicate are
+// determined by the given function.
+func filterMap(name string, input func(k string, v yaml.Node, params int) []interface{}, arguments []interface{}, binding Binding) (bool, interface{}, EvaluationInfo, bool) {
+	info := DefaultInfo()
+
+	if len(arguments) != 2 {
+		info.SetError("%s requires two arguments (map and predicate)", name)
+		return true, nil, info, false
+	}
+	m, ok := arguments[0].(map[string]yaml.Node)
+	if !ok && arguments[0] != nil {
+		info.SetError("%s: first argument must be a map, but found %s", name, ExpressionType(arguments[0]))
+		return true, nil, info, false
+	}
+	lambda, ok := arguments[1].(LambdaValue)
+	if !ok {
+		info.SetError("%s: second argument must be a lambda value, but found %s", name, ExpressionType(arguments[1]))
+		return true, nil, info, false
+	}
+
+	result := map[string]yaml.Node{}
+	for _, k := range getSortedKeys(m) {
+		inp := input(k, m[k], len(lambda.lambda.Parameters))
+		resolved, v, sub, ok := lambda.Evaluate(false, false, false, nil, inp, binding, false)
+		if !ok {
+			return true, nil, sub, false
+		}
+		if !resolved {
+			return false, nil, sub, true
+		}
+		b, ok := v.(bool)
+		if !ok {
+			info.SetError("%s: predicate for key %q must return a bool, but found %s", name, k, ExpressionType(v))
+			return true, nil, info, false
+		}
+		if b {
+			result[k] = m[k]
+		}
+	}
+	return true, result, info, true
+}
@@ -16,6 +16,7 @@ type MappingExpr struct {
 
 func (e MappingExpr) Evaluate(binding Binding, locally bool) (interface{}, EvaluationInfo, bool) {
 	resolved := true
+	defer profileFunction(binding, e.Context.Keyword()+e.Context.Brackets())()
 	inline := isInline(e.Lambda)
 	debug.Debug("evaluate mapping\n")
 	value, info, ok := ResolveExpressionOrPushEvaluation(&e.A, &resolved, nil, binding, true)
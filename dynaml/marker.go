@@ -16,6 +16,8 @@ const (
 	DEFAULT   = "&default"
 	STATE     = "&state"
 	DYNAMIC   = "&dynamic" // POC
+	CACHED    = "&cached"
+	ONCE      = "&once"
 )
 
 type MarkerExpr struct {
@@ -65,7 +67,7 @@ func (e MarkerExpr) GetFlags() yaml.NodeFlags {
 			flags.SetInject()
 		case DEFAULT:
 			flags.SetDefault()
-		case STATE:
+		case STATE, ONCE:
 			flags.SetState()
 		case DYNAMIC:
 			flags.SetDynamic()
@@ -83,6 +85,15 @@ func (e MarkerExpr) Evaluate(binding Binding, locally bool) (interface{}, Evalua
 	}
 	info.AddFlags(e.GetFlags())
 	if e.expr != nil {
+		if e.Has(ONCE) {
+			if v, ok := e.stateValue(binding); ok {
+				debug.Debug("using state value for %v\n", binding.Path())
+				return v, info, true
+			}
+		}
+		if e.Has(CACHED) {
+			return e.evaluateCached(binding, locally, info)
+		}
 		result, infoe, ok := e.expr.Evaluate(binding, locally)
 		infoe = infoe.Join(info)
 		return result, infoe, ok
@@ -90,6 +101,97 @@ func (e MarkerExpr) Evaluate(binding Binding, locally bool) (interface{}, Evalua
 	return nil, info, true
 }
 
+// cachedValue is a cached result of an expression marked with &cached
+// together with the nodes of the fields it has been evaluated for.
+type cachedValue struct {
+	paths  [][]string
+	inputs []yaml.Node
+	value  interface{}
+}
+
+// evaluateCached evaluates the marked expression at most once per field
+// path and input values for the actual cascade. Once the expression is
+// resolved, the result is reused for all subsequent evaluations with the
+// same values of the referenced fields.
+func (e MarkerExpr) evaluateCached(binding Binding, locally bool, info EvaluationInfo) (interface{}, EvaluationInfo, bool) {
+	state := binding.GetState()
+	if state == nil {
+		result, infoe, ok := e.expr.Evaluate(binding, locally)
+		return result, infoe.Join(info), ok
+	}
+	key := fmt.Sprintf("%s: %s", strings.Join(binding.Path(), "."), e.expr)
+	if v, ok := state.GetCachedValue(key); ok {
+		if c := v.(*cachedValue); c.matches(binding) {
+			debug.Debug("using cached value for %s\n", key)
+			return c.value, info, true
+		}
+	}
+	result, infoe, ok := e.expr.Evaluate(binding, locally)
+	if ok && infoe.Issue.Issue == "" && !infoe.Undefined && isResolvedValue(result, binding) {
+		paths := Dependencies(e.expr)
+		if inputs, found := dependencyNodes(binding, paths); found {
+			state.SetCachedValue(key, &cachedValue{paths, inputs, result})
+		}
+	}
+	return result, infoe.Join(info), ok
+}
+
+// matches checks whether the fields the cached value depends on still
+// provide the same values.
+func (c *cachedValue) matches(binding Binding) bool {
+	inputs, ok := dependencyNodes(binding, c.paths)
+	if !ok {
+		return false
+	}
+	for i, n := range inputs {
+		if !n.EquivalentToNode(c.inputs[i]) {
+			return false
+		}
+	}
+	return true
+}
+
+// dependencyNodes looks up the nodes for the given dependency paths
+// without evaluating any expression. If a node cannot be found or is
+// not yet resolved, false is returned.
+func dependencyNodes(binding Binding, paths [][]string) ([]yaml.Node, bool) {
+	nodes := make([]yaml.Node, len(paths))
+	for i, path := range paths {
+		var n yaml.Node
+		var ok bool
+		if path[len(path)-1] == DynamicDependency {
+			path = path[:len(path)-1]
+		}
+		if path[0] == "" {
+			n, ok = binding.FindFromRoot(path[1:])
+		} else {
+			n, ok = binding.FindReference(path)
+		}
+		if !ok || !isResolvedValue(n.Value(), binding) {
+			return nil, false
+		}
+		nodes[i] = n
+	}
+	return nodes, true
+}
+
+// stateValue returns the value provided for the actual field by the
+// incoming state (or any other stub), if present. It is used for nodes
+// marked with &once to omit the generation of already existing values.
+func (e MarkerExpr) stateValue(binding Binding) (interface{}, bool) {
+	if binding.NoMerge() {
+		return nil, false
+	}
+	n, found := binding.FindInStubs(binding.StubPath())
+	if !found || n == nil || n.Value() == nil || n.Flags().Default() {
+		return nil, false
+	}
+	if _, ok := n.Value().(Expression); ok {
+		return nil, false
+	}
+	return n.Value(), true
+}
+
 func (e MarkerExpr) setExpression(expr Expression) MarkerExpr {
 	e.expr = expr
 	return e
@@ -9,6 +9,10 @@ import (
 	"golang.org/x/crypto/md4"
 )
 
+func init() {
+	DeprecateFunction("md5", "hash")
+}
+
 func func_md5(arguments []interface{}, binding Binding) (interface{}, EvaluationInfo, bool) {
 	info := DefaultInfo()
 
@@ -16,6 +16,7 @@ type MergeExpr struct {
 }
 
 func (e MergeExpr) Evaluate(binding Binding, locally bool) (interface{}, EvaluationInfo, bool) {
+	defer profileFunction(binding, "merge")()
 	var info EvaluationInfo
 	info.KeyName = e.KeyName
 	debug.Debug("/// lookup %v\n", e.Path)
@@ -32,6 +33,7 @@ func (e MergeExpr) Evaluate(binding Binding, locally bool) (interface{}, Evaluat
 		info.Replace = e.Replace
 		info.Merged = true
 		info.Source = node.SourceName()
+		info.Position = node.Position()
 		info.NodeFlags = node.Flags()
 		return node.Value(), info, ok
 	} else {
@@ -0,0 +1,107 @@
+package dynaml
+
+import (
+	"github.com/mandelsoft/spiff/yaml"
+)
+
+func init() {
+	RegisterFunction("merge_deep", func_merge_deep)
+	RegisterFunction("merge_by_key", func_merge_by_key)
+}
+
+func func_merge_deep(arguments []interface{}, binding Binding) (interface{}, EvaluationInfo, bool) {
+	var result map[string]yaml.Node
+	info := DefaultInfo()
+
+	for i, arg := range arguments {
+		switch m := arg.(type) {
+		case map[string]yaml.Node:
+			if result == nil {
+				result = m
+			} else {
+				result = mergeDeep(result, m)
+			}
+		case nil:
+		default:
+			return info.Error("merge_deep: argument %d: map expected, but found %s", i+1, ExpressionType(arg))
+		}
+	}
+	if result == nil {
+		result = map[string]yaml.Node{}
+	}
+	return result, info, true
+}
+
+// mergeDeep merges the fields of the overlay map into a copy of the base map.
+// Maps found in both are merged recursively, otherwise the overlay wins.
+func mergeDeep(base, overlay map[string]yaml.Node) map[string]yaml.Node {
+	result := make(map[string]yaml.Node, len(base)+len(overlay))
+	for k, v := range base {
+		result[k] = v
+	}
+	for k, v := range overlay {
+		if old := result[k]; old != nil && v != nil {
+			bm, okb := old.Value().(map[string]yaml.Node)
+			om, oko := v.Value().(map[string]yaml.Node)
+			if okb && oko {
+				result[k] = yaml.SubstituteNode(mergeDeep(bm, om), v)
+				continue
+			}
+		}
+		result[k] = v
+	}
+	return result
+}
+
+func func_merge_by_key(arguments []interface{}, binding Binding) (interface{}, EvaluationInfo, bool) {
+	info := DefaultInfo()
+
+	if len(arguments) != 3 {
+		return info.Error("merge_by_key requires three arguments (base list, overlay list and key field)")
+	}
+	key, ok := arguments[2].(string)
+	if !ok {
+		return info.Error("merge_by_key: key field must be a string, but found %s", ExpressionType(arguments[2]))
+	}
+
+	var lists [2][]yaml.Node
+	for i := range lists {
+		switch l := arguments[i].(type) {
+		case []yaml.Node:
+			for j, e := range l {
+				if _, ok := e.Value().(map[string]yaml.Node); !ok {
+					return info.Error("merge_by_key: argument %d: entry %d must be a map, but found %s", i+1, j, ExpressionType(e))
+				}
+			}
+			lists[i] = l
+		case nil:
+		default:
+			return info.Error("merge_by_key: argument %d: list expected, but found %s", i+1, ExpressionType(arguments[i]))
+		}
+	}
+
+	result := append([]yaml.Node{}, lists[0]...)
+outer:
+	for _, e := range lists[1] {
+		m := e.Value().(map[string]yaml.Node)
+		if kv := m[key]; kv != nil {
+			for i, r := range result {
+				if rv := r.Value().(map[string]yaml.Node)[key]; rv != nil {
+					if eq, _, _ := compareEquals(rv.Value(), kv.Value()); eq {
+						merged := map[string]yaml.Node{}
+						for k, v := range r.Value().(map[string]yaml.Node) {
+							merged[k] = v
+						}
+						for k, v := range m {
+							merged[k] = v
+						}
+						result[i] = yaml.SubstituteNode(merged, e)
+						continue outer
+					}
+				}
+			}
+		}
+		result = append(result, e)
+	}
+	return result, info, true
+}
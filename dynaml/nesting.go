@@ -0,0 +1,31 @@
+package dynaml
+
+import (
+	"strings"
+
+	"github.com/mandelsoft/spiff/yaml"
+)
+
+// MaxDepthExceeded is the message prefix of errors reporting an exceeded
+// nesting depth of lambda calls and template instantiations.
+const MaxDepthExceeded = "maximum nesting depth exceeded"
+
+func pushNesting(binding Binding, name string) error {
+	if s := binding.GetState(); s != nil {
+		return s.PushNesting(name)
+	}
+	return nil
+}
+
+func popNesting(binding Binding) {
+	if s := binding.GetState(); s != nil {
+		s.PopNesting()
+	}
+}
+
+// isMaxDepthIssue checks whether an issue reports an exceeded nesting
+// depth. Such issues are propagated unchanged through all nesting levels
+// instead of being wrapped once per level.
+func isMaxDepthIssue(issue yaml.Issue) bool {
+	return strings.HasPrefix(issue.Issue, MaxDepthExceeded)
+}
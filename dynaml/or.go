@@ -3,6 +3,7 @@ package dynaml
 import (
 	"fmt"
 	"reflect"
+	"strings"
 )
 
 type OrExpr struct {
@@ -27,6 +28,9 @@ func (e OrExpr) Evaluate(binding Binding, locally bool) (interface{}, Evaluation
 	b, infob, ok := e.B.Evaluate(binding, false)
 	info := infoa.CleanError().Join(infob)
 	info.Undefined = infob.Undefined
+	if m, merge := e.A.(MergeExpr); merge && ok && !IsExpression(b) {
+		info.Warn("no override found for '%s', using default", strings.Join(m.Path, "."))
+	}
 	return b, info, ok
 }
 
@@ -4,11 +4,12 @@ package dynaml
 
 import (
 	"container/list"
-	"encoding/json"
-	"errors"
 	"fmt"
+	"sort"
 	"strconv"
 	"strings"
+	"unicode/utf16"
+	"unicode/utf8"
 
 	"github.com/mandelsoft/spiff/debug"
 )
@@ -46,12 +47,42 @@ type operationHelper struct {
 	op string
 }
 
+// Pretty enables the highlighting of the erroneous text in parse
+// errors with ANSI colors.
+var Pretty bool
+
+func highlight(txt string) string {
+	if Pretty {
+		return "\x1B[34m" + txt + "\x1B[m"
+	}
+	return txt
+}
+
 func lineError(lines, syms, linee, syme int, txt string) string {
-	return fmt.Sprintf("parse error near symbol %v - symbol %v: '%s'", syms, syme, txt)
+	return fmt.Sprintf("parse error near symbol %v - symbol %v: '%s'", syms, syme, highlight(txt))
 }
 
 func docError(lines, syms, linee, syme int, txt string) string {
-	return fmt.Sprintf("parse error near line %v symbol %v - line %v symbol %v: '%s'", lines, syms, linee, syme, txt)
+	return fmt.Sprintf("parse error near line %v symbol %v - line %v symbol %v: '%s'", lines, syms, linee, syme, highlight(txt))
+}
+
+// ParseError describes a syntax error in a dynaml expression.
+// Line and Symbol denote the (1-based line and 0-based symbol) position
+// the parser failed at.
+type ParseError struct {
+	Line    int
+	Symbol  int
+	message string
+}
+
+func (e *ParseError) Error() string {
+	return e.message
+}
+
+func (e *parseError) position() (int, int) {
+	begin := int(e.max.begin)
+	translations := translatePositions(e.p.buffer, []int{begin})
+	return translations[begin].line, translations[begin].symbol
 }
 
 func (e *parseError) String() string {
@@ -77,18 +108,56 @@ func (e *parseError) String() string {
 	return error
 }
 
+// Parse parses a dynaml expression. Every call uses its own grammar
+// instance, so Parse is safe for concurrent use.
 func Parse(source string, path []string, stubPath []string) (Expression, error) {
-	grammar := &DynamlGrammar{Buffer: source}
+	grammar := &DynamlGrammar{Buffer: source, Pretty: Pretty}
 	grammar.Init()
 
 	err := grammar.Parse()
 	if err != nil {
-		return nil, errors.New(err.(*parseError).String())
+		perr := err.(*parseError)
+		line, symbol := perr.position()
+		return nil, &ParseError{Line: line, Symbol: symbol, message: perr.String()}
 	}
 
 	return buildExpression(grammar, path, stubPath)
 }
 
+// LiteralToken describes a literal (number, string, ip address, boolean,
+// nil or undefined) in the source of an expression. Begin and End are
+// rune offsets.
+type LiteralToken struct {
+	Begin int
+	End   int
+	Text  string
+}
+
+// Literals returns the literal tokens of an expression ordered by their
+// position in the source.
+func Literals(source string) ([]LiteralToken, error) {
+	grammar := &DynamlGrammar{Buffer: source, Pretty: Pretty}
+	grammar.Init()
+
+	err := grammar.Parse()
+	if err != nil {
+		perr := err.(*parseError)
+		line, symbol := perr.position()
+		return nil, &ParseError{Line: line, Symbol: symbol, message: perr.String()}
+	}
+	buffer := []rune(grammar.Buffer)
+	literals := []LiteralToken{}
+	for token := range grammar.Tokens() {
+		switch token.pegRule {
+		case ruleNumber, ruleString, ruleRawString, ruleIP, ruleBoolean, ruleNil, ruleUndefined:
+			begin, end := int(token.begin), int(token.end)
+			literals = append(literals, LiteralToken{begin, end, string(buffer[begin:end])})
+		}
+	}
+	sort.Slice(literals, func(i, j int) bool { return literals[i].Begin < literals[j].Begin })
+	return literals, nil
+}
+
 func PathComponents(ref string, leading bool) []string {
 	path := []string{}
 	comp := ""
@@ -117,6 +186,21 @@ func PathComponents(ref string, leading bool) []string {
 	//return strings.Split(contents, ".")
 }
 
+// OptionalPath removes the safe navigation markers (`?.`) from a reference.
+// It reports whether the reference is optional and the number of leading
+// path components, which are required to exist.
+func OptionalPath(ref string, leading bool) (string, bool, int) {
+	i := strings.Index(ref, "?.")
+	if i < 0 {
+		return ref, false, 0
+	}
+	required := len(PathComponents(ref[:i], leading)) - 1
+	if required < 0 {
+		required = 0
+	}
+	return strings.ReplaceAll(ref, "?.", "."), true, required
+}
+
 type ExpressionParseError struct {
 	*parseError
 	msg error
@@ -131,12 +215,63 @@ func NewParseError(grammar *DynamlGrammar, token token32, msg error) *Expression
 }
 
 func parseString(s string, g *DynamlGrammar, t token32) (string, *ExpressionParseError) {
-	result := strings.Replace(s, `\"`, `"`, -1)
-	err := json.Unmarshal([]byte(s), &result)
+	var result strings.Builder
+
+	s = s[1 : len(s)-1]
+	for len(s) > 0 {
+		if s[0] != '\\' {
+			r, size := utf8.DecodeRuneInString(s)
+			result.WriteRune(r)
+			s = s[size:]
+			continue
+		}
+		if len(s) > 1 && s[1] == '/' {
+			// json escape for slash
+			result.WriteByte('/')
+			s = s[2:]
+			continue
+		}
+		r, multibyte, tail, err := strconv.UnquoteChar(s, '"')
+		if err != nil && strings.HasPrefix(s, `\u`) {
+			// json style surrogate pairs
+			r, tail, err = unquoteSurrogates(s)
+			multibyte = true
+		}
+		if err != nil {
+			n := 2
+			if n > len(s) {
+				n = len(s)
+			}
+			return "", NewParseError(g, t, fmt.Errorf("invalid escape sequence %q in string literal", s[:n]))
+		}
+		if multibyte {
+			result.WriteRune(r)
+		} else {
+			// \xNN and \NNN denote single bytes like in Go
+			result.WriteByte(byte(r))
+		}
+		s = tail
+	}
+	return result.String(), nil
+}
+
+func unquoteSurrogates(s string) (rune, string, error) {
+	if len(s) < 12 || s[6:8] != `\u` {
+		return 0, "", strconv.ErrSyntax
+	}
+	r1, err := strconv.ParseUint(s[2:6], 16, 16)
 	if err != nil {
-		return "", NewParseError(g, t, err)
+		return 0, "", err
+	}
+	r2, err := strconv.ParseUint(s[8:12], 16, 16)
+	if err != nil {
+		return 0, "", err
+	}
+	r := utf16.DecodeRune(rune(r1), rune(r2))
+	if r == utf8.RuneError {
+		return 0, "", strconv.ErrSyntax
 	}
-	return result, nil
+	return r, s[12:], nil
 }
 
 func buildExpression(grammar *DynamlGrammar, path []string, stubPath []string) (Expression, error) {
@@ -148,8 +283,10 @@ func buildExpression(grammar *DynamlGrammar, path []string, stubPath []string) (
 	required := false
 	keyName := ""
 
+	// token positions are rune offsets
+	buffer := []rune(grammar.Buffer)
 	for token := range grammar.Tokens() {
-		contents := grammar.Buffer[token.begin:token.end]
+		contents := string(buffer[token.begin:token.end])
 
 		switch token.pegRule {
 		case ruleDynaml:
@@ -209,14 +346,19 @@ func buildExpression(grammar *DynamlGrammar, path []string, stubPath []string) (
 					contents = contents[1:]
 				}
 			}
-			comps := PathComponents(contents, true)
-			tokens.Push(NewTaggedReferenceExpr(tag, comps...))
+			ref := NewTaggedReferenceExpr(tag)
+			contents, ref.Optional, ref.Required = OptionalPath(contents, true)
+			ref.Path = PathComponents(contents, true)
+			tokens.Push(ref)
 
 		case ruleChained:
 		case ruleChainedQualifiedExpression:
 		case rulePathComponent:
+		case ruleSafeNavigation:
 		case ruleChainedRef:
-			ref := NewReferenceExpr(PathComponents(contents, false)...)
+			ref := NewReferenceExpr()
+			contents, ref.Optional, ref.Required = OptionalPath(contents, false)
+			ref.Path = PathComponents(contents, false)
 			expr := tokens.Pop()
 			tokens.Push(QualifiedExpr{expr, ref})
 		case ruleChainedDynRef:
@@ -268,19 +410,25 @@ func buildExpression(grammar *DynamlGrammar, path []string, stubPath []string) (
 
 		case ruleNumber:
 			contents = strings.ReplaceAll(contents, "_", "")
-			if strings.ContainsAny(contents, ".eE") {
+			base := 10
+			if digits := strings.TrimPrefix(contents, "-"); len(digits) > 1 && digits[0] == '0' && strings.ContainsAny(digits[1:2], "xXoObB") {
+				// base prefix (0x, 0o or 0b) is evaluated by ParseInt
+				base = 0
+			}
+			if base == 10 && strings.ContainsAny(contents, ".eE") {
 				val, err := strconv.ParseFloat(contents, 64)
 				if err != nil {
-					panic(err)
+					return nil, NewParseError(grammar, token, err)
 				}
 				tokens.Push(FloatExpr{val})
 			} else {
-				val, err := strconv.ParseInt(contents, 10, 64)
+				val, err := strconv.ParseInt(contents, base, 64)
 				if err != nil {
-					panic(err)
+					return nil, NewParseError(grammar, token, err)
 				}
 				tokens.Push(IntegerExpr{val})
 			}
+		case rulePrefixedInteger, ruleDigits:
 
 		case ruleNil:
 			tokens.Push(NilExpr{})
@@ -309,6 +457,8 @@ func buildExpression(grammar *DynamlGrammar, path []string, stubPath []string) (
 				return nil, err
 			}
 			tokens.Push(StringExpr{val})
+		case ruleRawString:
+			tokens.Push(StringExpr{contents[1 : len(contents)-1]})
 		case ruleIP:
 			tokens.Push(StringExpr{contents})
 		case ruleSubstitution:
@@ -347,6 +497,12 @@ func buildExpression(grammar *DynamlGrammar, path []string, stubPath []string) (
 		case ruleOrOp:
 			tokens.Push(operationHelper{op: contents})
 
+		case rulePipe:
+			rhs := tokens.Pop()
+			lhs := tokens.Pop()
+
+			tokens.Push(PipeExpr{A: lhs, B: rhs})
+
 		case ruleNot:
 			tokens.Push(NotExpr{tokens.Pop()})
 
@@ -521,7 +677,7 @@ func buildExpression(grammar *DynamlGrammar, path []string, stubPath []string) (
 		case ruleKey, ruleIndex:
 		case ruleTag, ruleTagComponent, ruleTagPrefix:
 		case ruleLevel0, ruleLevel1, ruleLevel2, ruleLevel3, ruleLevel4, ruleLevel5, ruleLevel6, ruleLevel7:
-		case ruleExpression:
+		case ruleExpression, rulePiped:
 		case ruleExpressionList:
 		case ruleNameArgumentList:
 		case ruleMap:
@@ -0,0 +1,57 @@
+package dynaml
+
+import (
+	"testing"
+)
+
+var fuzzSeeds = []string{
+	"",
+	"foo.bar",
+	"foo.[0].bar",
+	"a || b || c",
+	"1 + 2 * 3 / 4 % 5 - 6",
+	"\"foo\" \"bar\"",
+	"[1, 2, [3]]",
+	"{ \"a\" = 1, $b = 2 }",
+	"( $x = 1 ) x",
+	"|x,y...|->x + length(y)",
+	"map[list|x|->x * 2]",
+	"sum[list|0|s,x|->s + x]",
+	"a ? b :c",
+	"foo.[1..3]",
+	"foo?.bar.alice",
+	"&temporary(1)",
+	"*foo.bar",
+	"join(\", \", list)",
+	"0x1f + 0b101 + 0o17 + 1.5e3",
+	"\"\\u00e4\\n\\t\\\\\"",
+	"(( nested ))",
+	"1..",
+	"[",
+	"{",
+	"\"",
+	"|x|->",
+	"a.[",
+	"\"\\u12\"",
+	"\"\\ud800\"",
+	"[[[[[[([[[[[[(AA",
+	"[([([([([([([([([(1 +)])])])])])])])])]",
+	"map[map[map[map[map[map[map[map[1 +",
+	"lambda [lambda [lambda [lambda [lambda [lambda [1 +",
+}
+
+// FuzzParse checks that the parser neither panics nor hangs for any input.
+func FuzzParse(f *testing.F) {
+	for _, s := range fuzzSeeds {
+		f.Add(s)
+	}
+	f.Fuzz(func(t *testing.T, source string) {
+		expr, err := SafeParse(source, []string{"node"}, []string{"node"})
+		if ierr, ok := err.(*InternalError); ok {
+			t.Fatalf("parser panics: %s", ierr)
+		}
+		if err == nil && expr == nil {
+			t.Errorf("no expression and no error for %q", source)
+		}
+	})
+}
@@ -1,6 +1,11 @@
 package dynaml
 
 import (
+	"reflect"
+	"strings"
+	"sync"
+	"time"
+
 	. "github.com/onsi/ginkgo"
 	. "github.com/onsi/ginkgo/extensions/table"
 	. "github.com/onsi/gomega"
@@ -15,12 +20,87 @@ var _ = Describe("parsing", func() {
 		It("parses negative numbers", func() {
 			parsesAs("-1", IntegerExpr{-1})
 		})
+
+		It("parses numbers with separators", func() {
+			parsesAs("1_000", IntegerExpr{1000})
+			parsesAs("0755", IntegerExpr{755})
+		})
+
+		It("parses hexadecimal, octal and binary numbers", func() {
+			parsesAs("0xFF", IntegerExpr{255})
+			parsesAs("0Xff_ff", IntegerExpr{65535})
+			parsesAs("-0x10", IntegerExpr{-16})
+			parsesAs("0o755", IntegerExpr{493})
+			parsesAs("0b1010", IntegerExpr{10})
+			parsesAs("0b_1111_0000", IntegerExpr{240})
+		})
+
+		It("rejects invalid numbers", func() {
+			for _, source := range []string{"0xFG", "0o8", "0b102", "0x", "0xFFFFFFFFFFFFFFFF", "99999999999999999999",
+				"1_", "1__0", "1._5", "1.5_", "1e_5", "0x_", "0xF__F"} {
+				_, err := Parse(source, nil, nil)
+				Expect(err).To(HaveOccurred(), source)
+			}
+		})
+	})
+
+	Describe("floats", func() {
+		It("parses floats with separators", func() {
+			parsesAs("1_000.5", FloatExpr{1000.5})
+			parsesAs("1_000.123_456e1_0", FloatExpr{1000.123456e10})
+			parsesAs("-1.5e-1_0", FloatExpr{-1.5e-10})
+		})
 	})
 
 	Describe("strings", func() {
 		It("parses strings with escaped quotes", func() {
 			parsesAs(`"foo \"bar\" baz"`, StringExpr{`foo "bar" baz`})
 		})
+
+		It("parses strings with escape sequences", func() {
+			parsesAs(`"a\nb\r\tc"`, StringExpr{"a\nb\r\tc"})
+			parsesAs(`"back\\slash\\"`, StringExpr{`back\slash\`})
+			parsesAs(`"ä\x41\/"`, StringExpr{"äA/"})
+			parsesAs(`"\u00e4\ud83d\ude00"`, StringExpr{"ä\U0001F600"})
+		})
+
+		It("parses byte escape sequences as bytes", func() {
+			parsesAs(`"\303\251"`, StringExpr{"é"})
+			parsesAs(`"\xc3\xa9"`, StringExpr{"é"})
+			parsesAs(`"\xff"`, StringExpr{"\xff"})
+		})
+
+		It("rejects invalid escape sequences", func() {
+			for _, source := range []string{`"\q"`, `"\x4"`, `"\u12"`, `"\ud83d"`} {
+				_, err := Parse(source, nil, nil)
+				Expect(err).To(HaveOccurred(), source)
+			}
+		})
+
+		It("renders strings with escape sequences as parsable expressions", func() {
+			expr := StringExpr{"a\n\x01 \\\"b\xff"}
+			reparsed, err := Parse(expr.String(), nil, nil)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(reparsed).To(Equal(expr))
+		})
+
+		It("parses strings with unicode characters", func() {
+			parsesAs(`"äöü" "ß"`, ConcatenationExpr{StringExpr{"äöü"}, StringExpr{"ß"}})
+		})
+
+		It("parses raw strings", func() {
+			parsesAs("`C:\\temp\\\"dir\"`", StringExpr{`C:\temp\"dir"`})
+			parsesAs("`line1\nline2`", StringExpr{"line1\nline2"})
+			parsesAs("``", StringExpr{""})
+		})
+
+		It("renders raw strings as parsable expressions", func() {
+			expr, err := Parse("|x|->x `\\d+\"`", nil, nil)
+			Expect(err).NotTo(HaveOccurred())
+			reparsed, err := Parse(expr.(LambdaExpr).String(), nil, nil)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(reparsed).To(Equal(expr))
+		})
 	})
 
 	Describe("nil", func() {
@@ -44,6 +124,8 @@ var _ = Describe("parsing", func() {
 			"&inject",
 			"&local",
 			"&default",
+			"&cached",
+			"&once",
 			"&tag:test",
 		}
 		var entries []TableEntry
@@ -114,6 +196,65 @@ var _ = Describe("parsing", func() {
 		It("parses tagged dot reference", func() {
 			parsesAs("tag::.", ReferenceExpr{Tag: "tag", Path: []string{""}})
 		})
+		It("parses safe navigation", func() {
+			parsesAs("foo?.bar.baz", ReferenceExpr{Path: []string{"foo", "bar", "baz"}, Optional: true})
+			parsesAs("foo.bar?.baz", ReferenceExpr{Path: []string{"foo", "bar", "baz"}, Optional: true, Required: 1})
+			parsesAs("foo?.[1]", ReferenceExpr{Path: []string{"foo", "[1]"}, Optional: true})
+		})
+		It("parses conditionals instead of safe navigation", func() {
+			parsesAs("b?.l : 3", CondExpr{
+				ReferenceExpr{Path: []string{"b"}},
+				ReferenceExpr{Path: []string{"", "l"}},
+				IntegerExpr{3},
+			})
+		})
+	})
+
+	Describe("pipes", func() {
+		It("parses the pipe operator", func() {
+			parsesAs(
+				`a |> f |> g(1)`,
+				PipeExpr{
+					A: PipeExpr{
+						A: ReferenceExpr{Path: []string{"a"}},
+						B: ReferenceExpr{Path: []string{"f"}},
+					},
+					B: CallExpr{
+						Function:  ReferenceExpr{Path: []string{"g"}},
+						Arguments: []Expression{IntegerExpr{1}},
+					},
+				},
+			)
+		})
+		It("binds stronger than or", func() {
+			parsesAs(
+				`a || b |> f`,
+				OrExpr{
+					A: ReferenceExpr{Path: []string{"a"}},
+					B: PipeExpr{
+						A: ReferenceExpr{Path: []string{"b"}},
+						B: ReferenceExpr{Path: []string{"f"}},
+					},
+				},
+			)
+		})
+	})
+
+	Describe("membership", func() {
+		It("parses the in operator", func() {
+			parsesAs(
+				`"a" in list`,
+				ComparisonExpr{
+					A:  StringExpr{"a"},
+					Op: "in",
+					B:  ReferenceExpr{Path: []string{"list"}},
+				},
+			)
+		})
+		It("parses references named in", func() {
+			parsesAs("in", ReferenceExpr{Path: []string{"in"}})
+			parsesAs("map.in", ReferenceExpr{Path: []string{"map", "in"}})
+		})
 	})
 
 	Describe("tagged expressions", func() {
@@ -340,6 +481,17 @@ var _ = Describe("parsing", func() {
 			)
 		})
 
+		It("parses calls of functions starting with a keyword", func() {
+			parsesAs(
+				`merge_deep()`,
+				CallExpr{
+					ReferenceExpr{Path: []string{"merge_deep"}},
+					nil,
+					false,
+				},
+			)
+		})
+
 		It("parses simple calls for name", func() {
 			parsesAs(
 				`foo(1)`,
@@ -1245,6 +1397,119 @@ var _ = Describe("parsing", func() {
 		})
 	})
 
+	Describe("errors", func() {
+		It("reports the error position", func() {
+			_, err := Parse(" 1 + ", nil, nil)
+			Expect(err).To(HaveOccurred())
+			Expect(err.Error()).To(Equal("parse error near symbol 5 - symbol 6: ' '"))
+			perr, ok := err.(*ParseError)
+			Expect(ok).To(BeTrue())
+			Expect(perr.Line).To(Equal(1))
+			Expect(perr.Symbol).To(Equal(5))
+		})
+
+		It("reports the error line for multi line expressions", func() {
+			_, err := Parse(" 1 +\n ) 2", nil, nil)
+			Expect(err).To(HaveOccurred())
+			perr, ok := err.(*ParseError)
+			Expect(ok).To(BeTrue())
+			Expect(perr.Line).To(Equal(2))
+		})
+	})
+
+	Describe("concurrent parsing", func() {
+		It("parses expressions from multiple goroutines", func() {
+			sources := []string{
+				"foo.bar[1] + 2 * baz",
+				"merge replace on key foo.bar",
+				"map[list|k,v|->k \"=\" v]",
+				"( $x = 1 ) [x, \"a\\u00e4\"] || ~",
+				"a ? b.[1..-2] : sum[list|0|s,x|->s + x]",
+				"1 +",
+			}
+			expected := make([]interface{}, len(sources))
+			for i, source := range sources {
+				expected[i], _ = Parse(source, []string{"node"}, []string{"node"})
+			}
+
+			var wg sync.WaitGroup
+			failures := make(chan string, 5000)
+			for i := 0; i < 5000; i++ {
+				wg.Add(1)
+				go func(i int) {
+					defer wg.Done()
+					n := i % len(sources)
+					expr, _ := Parse(sources[n], []string{"node"}, []string{"node"})
+					if !reflect.DeepEqual(expr, expected[n]) {
+						failures <- sources[n]
+					}
+				}(i)
+			}
+			wg.Wait()
+			close(failures)
+			for f := range failures {
+				Fail("unexpected parse result for " + f)
+			}
+		})
+	})
+
+	Describe("safe parsing", func() {
+		It("parses regular expressions", func() {
+			expr, err := SafeParse("1 + 2", nil, nil)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(expr).To(Equal(AdditionExpr{IntegerExpr{1}, IntegerExpr{2}}))
+		})
+
+		It("rejects deeply nested expressions", func() {
+			source := "[ \"((((\", " + strings.Repeat("[(", 8) + "1 +" + strings.Repeat(")]", 8) + "]"
+			_, err := SafeParse(source, nil, nil)
+			Expect(err).To(HaveOccurred())
+			Expect(err.Error()).To(Equal("expression nesting exceeds 12 levels"))
+			perr, ok := err.(*ParseError)
+			Expect(ok).To(BeTrue())
+			Expect(perr.Symbol).To(Equal(21))
+		})
+
+		It("counts brackets following names twice", func() {
+			_, err := SafeParse(strings.Repeat("map[", 7)+"1 +", nil, nil)
+			Expect(err).To(HaveOccurred())
+			Expect(err.Error()).To(Equal("expression nesting exceeds 12 levels"))
+			_, err = SafeParse(strings.Repeat("lambda (", 7)+"1 +", nil, nil)
+			Expect(err).To(HaveOccurred())
+			Expect(err.Error()).To(Equal("expression nesting exceeds 12 levels"))
+			_, err = SafeParse(strings.Repeat("[", 7)+"1 +", nil, nil)
+			Expect(err).To(HaveOccurred())
+			Expect(err.Error()).NotTo(Equal("expression nesting exceeds 12 levels"))
+		})
+
+		It("parses malformed expressions in bounded time", func() {
+			patterns := []string{"map[", "sum[", "select[", "sync[", "catch[", "lambda [", "lambda (", "a in [", "[", "(", "a.[", "[(", "[[", "(a)[", "map[[", "(map[", "|x|->[", "a ? ["}
+			for _, p := range patterns {
+				n := 1
+				for checkNesting(strings.Repeat(p, n+1), MaxSafeNesting) == nil {
+					n++
+				}
+				start := time.Now()
+				_, err := SafeParse(strings.Repeat(p, n)+"1 +", nil, nil)
+				Expect(err).To(HaveOccurred())
+				Expect(time.Since(start)).To(BeNumerically("<", time.Second), p)
+			}
+		})
+
+		It("converts panics during evaluation", func() {
+			_, info, ok := SafeEvaluate(nameHelper{name: "x"}, FakeBinding{}, false)
+			Expect(ok).To(BeFalse())
+			Expect(info.Issue.Issue).To(ContainSubstring("not intended to be evaluated"))
+		})
+
+		It("keeps regular evaluation errors", func() {
+			expr, err := SafeParse("1 / 0", nil, nil)
+			Expect(err).NotTo(HaveOccurred())
+			_, info, ok := SafeEvaluate(expr, FakeBinding{}, false)
+			Expect(ok).To(BeFalse())
+			Expect(info.Issue.Issue).To(Equal("division by zero"))
+		})
+	})
 })
 
 func parsesAs(source string, expr Expression, path ...string) {
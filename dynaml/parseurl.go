@@ -3,34 +3,68 @@ package dynaml
 import (
 	"github.com/mandelsoft/spiff/yaml"
 
+	"fmt"
+	"net"
 	"net/url"
 	"strconv"
 )
 
 const F_ParseURL = "parseurl"
+const F_URLParse = "url_parse"
+const F_URLBuild = "url_build"
 
 func init() {
 	RegisterFunction(F_ParseURL, func_parseurl)
+	RegisterFunction(F_URLParse, func_url_parse)
+	RegisterFunction(F_URLBuild, func_url_build)
 }
 
 func func_parseurl(arguments []interface{}, binding Binding) (interface{}, EvaluationInfo, bool) {
-	var err error
+	u, info, ok := parseURLArgument(F_ParseURL, arguments)
+	if !ok {
+		return nil, info, false
+	}
+
+	result := urlFields(u, binding)
+	result["query"] = NewNode(u.RawQuery, binding)
+	result["values"] = NewNode(urlQuery(u, binding), binding)
+	return result, info, true
+}
+
+func func_url_parse(arguments []interface{}, binding Binding) (interface{}, EvaluationInfo, bool) {
+	u, info, ok := parseURLArgument(F_URLParse, arguments)
+	if !ok {
+		return nil, info, false
+	}
+
+	result := urlFields(u, binding)
+	result["query"] = NewNode(urlQuery(u, binding), binding)
+	return result, info, true
+}
+
+func parseURLArgument(name string, arguments []interface{}) (*url.URL, EvaluationInfo, bool) {
 	info := DefaultInfo()
 
 	if len(arguments) != 1 {
-		return info.Error("invalid argument count for %s", F_ParseURL)
+		info.SetError("invalid argument count for %s", name)
+		return nil, info, false
 	}
 
 	urlstr, ok := arguments[0].(string)
 	if !ok {
-		return info.Error("argument for %s must be a string", F_ParseURL)
+		info.SetError("argument for %s must be a string", name)
+		return nil, info, false
 	}
 
 	u, err := url.Parse(urlstr)
 	if err != nil {
-		return info.Error("invalid argument for %s: %s", F_ParseURL, err)
+		info.SetError("invalid argument for %s: %s", name, err)
+		return nil, info, false
 	}
+	return u, info, true
+}
 
+func urlFields(u *url.URL, binding Binding) map[string]yaml.Node {
 	result := map[string]yaml.Node{}
 
 	result["scheme"] = NewNode(u.Scheme, binding)
@@ -44,8 +78,19 @@ func func_parseurl(arguments []interface{}, binding Binding) (interface{}, Evalu
 			result["port"] = NewNode(port, binding)
 		}
 	}
-	result["query"] = NewNode(u.RawQuery, binding)
 
+	if u.User != nil {
+		p := map[string]yaml.Node{}
+		p["username"] = NewNode(u.User.Username(), binding)
+		if pass, ok := u.User.Password(); ok {
+			p["password"] = NewNode(pass, binding)
+		}
+		result["userinfo"] = NewNode(p, binding)
+	}
+	return result
+}
+
+func urlQuery(u *url.URL, binding Binding) map[string]yaml.Node {
 	query := map[string]yaml.Node{}
 	for k, v := range u.Query() {
 		p := []yaml.Node{}
@@ -54,16 +99,123 @@ func func_parseurl(arguments []interface{}, binding Binding) (interface{}, Evalu
 		}
 		query[k] = NewNode(p, binding)
 	}
-	result["values"] = NewNode(query, binding)
+	return query
+}
 
-	if u.User != nil {
-		p := map[string]yaml.Node{}
-		p["username"] = NewNode(u.User.Username(), binding)
-		if pass, ok := u.User.Password(); ok {
-			p["password"] = NewNode(pass, binding)
+func func_url_build(arguments []interface{}, binding Binding) (interface{}, EvaluationInfo, bool) {
+	info := DefaultInfo()
+
+	if len(arguments) != 1 {
+		return info.Error("invalid argument count for %s", F_URLBuild)
+	}
+
+	fields, ok := arguments[0].(map[string]yaml.Node)
+	if !ok {
+		return info.Error("argument for %s must be a map", F_URLBuild)
+	}
+
+	u := &url.URL{}
+	host := ""
+	port := ""
+	for k, v := range fields {
+		switch k {
+		case "scheme", "host", "path", "fragment":
+			s, ok := v.Value().(string)
+			if !ok && v.Value() != nil {
+				return info.Error("field %q for %s must be a string", k, F_URLBuild)
+			}
+			switch k {
+			case "scheme":
+				u.Scheme = s
+			case "host":
+				host = s
+			case "path":
+				u.Path = s
+			case "fragment":
+				u.Fragment = s
+			}
+		case "port":
+			switch p := v.Value().(type) {
+			case int64:
+				port = strconv.FormatInt(p, 10)
+			case string:
+				port = p
+			case nil:
+			default:
+				return info.Error("field %q for %s must be an integer or string", k, F_URLBuild)
+			}
+		case "query":
+			switch q := v.Value().(type) {
+			case string:
+				u.RawQuery = q
+			case map[string]yaml.Node:
+				values, err := queryValues(q)
+				if err != nil {
+					return info.Error("field %q for %s: %s", k, F_URLBuild, err)
+				}
+				u.RawQuery = values.Encode()
+			case nil:
+			default:
+				return info.Error("field %q for %s must be a map or string", k, F_URLBuild)
+			}
+		case "userinfo":
+			m, ok := v.Value().(map[string]yaml.Node)
+			if !ok {
+				return info.Error("field %q for %s must be a map", k, F_URLBuild)
+			}
+			user := ""
+			if n, ok := m["username"]; ok && n.Value() != nil {
+				user = fmt.Sprintf("%v", n.Value())
+			}
+			if n, ok := m["password"]; ok && n.Value() != nil {
+				u.User = url.UserPassword(user, fmt.Sprintf("%v", n.Value()))
+			} else {
+				u.User = url.User(user)
+			}
+		default:
+			return info.Error("unknown field %q for %s", k, F_URLBuild)
+		}
+	}
+	if port != "" {
+		u.Host = net.JoinHostPort(host, port)
+	} else {
+		u.Host = host
+	}
+	return u.String(), info, true
+}
+
+// queryValues converts a map of strings or lists of strings
+// into url query values.
+func queryValues(m map[string]yaml.Node) (url.Values, error) {
+	values := url.Values{}
+	for k, n := range m {
+		switch v := n.Value().(type) {
+		case []yaml.Node:
+			for _, e := range v {
+				s, err := queryValue(e.Value())
+				if err != nil {
+					return nil, fmt.Errorf("value of %q: %s", k, err)
+				}
+				values.Add(k, s)
+			}
+		default:
+			s, err := queryValue(v)
+			if err != nil {
+				return nil, fmt.Errorf("value of %q: %s", k, err)
+			}
+			values.Add(k, s)
 		}
-		result["userinfo"] = NewNode(p, binding)
 	}
+	return values, nil
+}
 
-	return result, info, true
+func queryValue(v interface{}) (string, error) {
+	switch s := v.(type) {
+	case string:
+		return s, nil
+	case int64, bool, float64:
+		return fmt.Sprintf("%v", s), nil
+	default:
+		return "", fmt.Errorf("invalid query value type %s", ExpressionType(v))
+	}
 }
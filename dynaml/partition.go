@@ -0,0 +1,47 @@
+package dynaml
+
+import (
+	"github.com/mandelsoft/spiff/yaml"
+)
+
+func func_partition(arguments []interface{}, binding Binding) (bool, interface{}, EvaluationInfo, bool) {
+	info := DefaultInfo()
+
+	if len(arguments) != 2 {
+		info.SetError("partition requires two arguments (list and predicate)")
+		return true, nil, info, false
+	}
+	list, ok := arguments[0].([]yaml.Node)
+	if !ok && arguments[0] != nil {
+		info.SetError("partition: first argument must be a list, but found %s", ExpressionType(arguments[0]))
+		return true, nil, info, false
+	}
+	lambda, ok := arguments[1].(LambdaValue)
+	if !ok {
+		info.SetError("partition: second argument must be a lambda value, but found %s", ExpressionType(arguments[1]))
+		return true, nil, info, false
+	}
+
+	matching := []yaml.Node{}
+	nonmatching := []yaml.Node{}
+	for i, e := range list {
+		resolved, v, sub, ok := lambda.Evaluate(false, false, false, nil, []interface{}{e.Value()}, binding, false)
+		if !ok {
+			return true, nil, sub, false
+		}
+		if !resolved {
+			return false, nil, sub, true
+		}
+		b, ok := v.(bool)
+		if !ok {
+			info.SetError("partition: predicate for entry %d must return a bool, but found %s", i, ExpressionType(v))
+			return true, nil, info, false
+		}
+		if b {
+			matching = append(matching, e)
+		} else {
+			nonmatching = append(nonmatching, e)
+		}
+	}
+	return true, []yaml.Node{NewNode(matching, binding), NewNode(nonmatching, binding)}, info, true
+}
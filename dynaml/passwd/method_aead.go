@@ -0,0 +1,79 @@
+package passwd
+
+import (
+	"crypto/aes"
+	"crypto/cipher"
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/hex"
+	"fmt"
+	"io"
+	"strings"
+
+	"golang.org/x/crypto/chacha20poly1305"
+)
+
+const AESGCM = "AES-GCM"
+const CHACHA20POLY1305 = "CHACHA20-POLY1305"
+
+// aead implements encodings based on an authenticated encryption
+// algorithm. The encoded text is prefixed by the method name
+// to enable the auto-detection of the method for decryption.
+type aead struct {
+	name   string
+	create func(key []byte) (cipher.AEAD, error)
+}
+
+func newAESGCM(key []byte) (cipher.AEAD, error) {
+	c, err := aes.NewCipher(key)
+	if err != nil {
+		return nil, err
+	}
+	return cipher.NewGCM(c)
+}
+
+func newChaCha20Poly1305(key []byte) (cipher.AEAD, error) {
+	return chacha20poly1305.New(key)
+}
+
+func (e aead) Name() string {
+	return e.name
+}
+
+func (e aead) Encode(text string, key string) (string, error) {
+	c, err := e.getCipher(key)
+	if err != nil {
+		return "", err
+	}
+	nonce := make([]byte, c.NonceSize())
+	if _, err := io.ReadFull(rand.Reader, nonce); err != nil {
+		return "", err
+	}
+	return e.name + ":" + hex.EncodeToString(c.Seal(nonce, nonce, []byte(text), nil)), nil
+}
+
+func (e aead) Decode(text string, key string) (string, error) {
+	c, err := e.getCipher(key)
+	if err != nil {
+		return "", err
+	}
+	ciphertext, err := hex.DecodeString(strings.TrimPrefix(text, e.name+":"))
+	if err != nil {
+		return "", fmt.Errorf("invalid ciphertext: %s", err)
+	}
+	if len(ciphertext) < c.NonceSize() {
+		return "", fmt.Errorf("ciphertext too short")
+	}
+	r, err := c.Open(nil, ciphertext[:c.NonceSize()], ciphertext[c.NonceSize():], nil)
+	if err != nil {
+		return "", fmt.Errorf("invalid key: %s", err)
+	}
+	return string(r), nil
+}
+
+// getCipher derives the 256 bit key for the algorithm from the given
+// password.
+func (e aead) getCipher(key string) (cipher.AEAD, error) {
+	k := sha256.Sum256([]byte(key))
+	return e.create(k[:])
+}
@@ -4,6 +4,7 @@ import (
 	"crypto/rand"
 	"fmt"
 	"io"
+	"strings"
 
 	"crypto/cipher"
 	"crypto/des"
@@ -28,8 +29,7 @@ func (e des1) Encode(text string, key string) (string, error) {
 	if err != nil {
 		return "", err
 	}
-	r := EncodeString(text, c)
-	return r, nil
+	return TRIPPLEDES + ":" + EncodeString(text, c), nil
 }
 
 func (e des1) Decode(text string, key string) (string, error) {
@@ -37,7 +37,7 @@ func (e des1) Decode(text string, key string) (string, error) {
 	if err != nil {
 		return "", err
 	}
-	r, err := DecodeString(text, c)
+	r, err := DecodeString(strings.TrimPrefix(text, TRIPPLEDES+":"), c)
 	if r == "" {
 		return "", fmt.Errorf("invalid key: %s", err)
 	}
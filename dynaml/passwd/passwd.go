@@ -2,6 +2,7 @@ package passwd
 
 import (
 	"fmt"
+	"strings"
 
 	. "github.com/mandelsoft/spiff/dynaml"
 	"github.com/mandelsoft/spiff/legacy/candiedyaml"
@@ -14,7 +15,9 @@ type Encoding interface {
 }
 
 var encodings = map[string]Encoding{
-	TRIPPLEDES: des1{},
+	TRIPPLEDES:       des1{},
+	AESGCM:           aead{AESGCM, newAESGCM},
+	CHACHA20POLY1305: aead{CHACHA20POLY1305, newChaCha20Poly1305},
 }
 
 const F_Decrypt = "decrypt"
@@ -22,7 +25,9 @@ const F_Encrypt = "encrypt"
 
 func init() {
 	RegisterFunction(F_Decrypt, func_decrypt)
+	DescribeFunction(F_Decrypt, "1-3", "decrypt a secret")
 	RegisterFunction(F_Encrypt, func_encrypt)
+	DescribeFunction(F_Encrypt, "1-3", "encrypt a secret")
 }
 
 func RegisterEncryption(name string, e Encoding) {
@@ -33,6 +38,28 @@ func GetEncoding(name string) Encoding {
 	return encodings[name]
 }
 
+// DetectEncoding determines the encoding used for an encrypted text.
+// Texts prefixed by a method name (<method>:<data>) use this method, which
+// must match an explicitly requested method. Texts without prefix use the
+// requested method or the original default method 3DES.
+func DetectEncoding(text string, method string) (Encoding, error) {
+	if i := strings.Index(text, ":"); i > 0 {
+		m := text[:i]
+		if method != "" && method != m {
+			return nil, fmt.Errorf("text is encrypted with method %q instead of %q", m, method)
+		}
+		method = m
+	}
+	if method == "" {
+		method = TRIPPLEDES
+	}
+	e := encodings[method]
+	if e == nil {
+		return nil, fmt.Errorf("invalid encyption method %q", method)
+	}
+	return e, nil
+}
+
 func func_decrypt(arguments []interface{}, binding Binding) (interface{}, EvaluationInfo, bool) {
 	info := DefaultInfo()
 	if len(arguments) < 1 || len(arguments) > 3 {
@@ -45,7 +72,7 @@ func func_decrypt(arguments []interface{}, binding Binding) (interface{}, Evalua
 	}
 
 	key := binding.GetState().GetEncryptionKey()
-	method := TRIPPLEDES
+	method := ""
 	v := ""
 	if len(arguments) > 1 {
 		v, err = StringValue(fmt.Sprintf("%s: 2nd argument", F_Decrypt), arguments[1])
@@ -66,12 +93,13 @@ func func_decrypt(arguments []interface{}, binding Binding) (interface{}, Evalua
 		if err != nil {
 			return info.Error(err)
 		}
+		key = v
 		method = m
 	}
 
-	e := encodings[method]
-	if e == nil {
-		return info.Error("invalid encyption method %q", method)
+	e, err := DetectEncoding(value, method)
+	if err != nil {
+		return info.Error(err)
 	}
 
 	if key == "" {
@@ -97,7 +125,10 @@ func func_encrypt(arguments []interface{}, binding Binding) (interface{}, Evalua
 	}
 
 	key := binding.GetState().GetEncryptionKey()
-	method := TRIPPLEDES
+	method := binding.GetState().GetEncryptionMethod()
+	if method == "" {
+		method = TRIPPLEDES
+	}
 	v := ""
 	if len(arguments) > 1 {
 		v, err = StringValue(fmt.Sprintf("%s: 2nd argument", F_Encrypt), arguments[1])
@@ -118,6 +149,7 @@ func func_encrypt(arguments []interface{}, binding Binding) (interface{}, Evalua
 		if err != nil {
 			return info.Error(err)
 		}
+		key = v
 		method = m
 	}
 
@@ -0,0 +1,95 @@
+package dynaml
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/mandelsoft/spiff/yaml"
+)
+
+func init() {
+	RegisterFunction("paths", func_paths)
+	RegisterFunction("leaves", func_leaves)
+}
+
+func func_paths(arguments []interface{}, binding Binding) (interface{}, EvaluationInfo, bool) {
+	info := DefaultInfo()
+
+	result := []yaml.Node{}
+	err := walkLeaves("paths", arguments, binding, func(path string, n yaml.Node) {
+		result = append(result, NewNode(path, binding))
+	})
+	if err != nil {
+		return info.Error("%s", err)
+	}
+	return result, info, true
+}
+
+func func_leaves(arguments []interface{}, binding Binding) (interface{}, EvaluationInfo, bool) {
+	info := DefaultInfo()
+
+	result := map[string]yaml.Node{}
+	err := walkLeaves("leaves", arguments, binding, func(path string, n yaml.Node) {
+		result[path] = n
+	})
+	if err != nil {
+		return info.Error("%s", err)
+	}
+	return result, info, true
+}
+
+// walkLeaves calls the visitor for every leaf of the structure given as
+// first argument, ordered by map keys and list indices. The optional
+// second argument decides whether empty maps and lists are reported as
+// leaves (default) or skipped.
+func walkLeaves(name string, arguments []interface{}, binding Binding, visit func(path string, n yaml.Node)) error {
+	if len(arguments) < 1 || len(arguments) > 2 {
+		return fmt.Errorf("%s takes one or two arguments", name)
+	}
+
+	switch arguments[0].(type) {
+	case map[string]yaml.Node, []yaml.Node:
+	default:
+		return fmt.Errorf("first argument for %s must be a map or list", name)
+	}
+
+	empty := true
+	if len(arguments) == 2 {
+		b, ok := arguments[1].(bool)
+		if !ok {
+			return fmt.Errorf("second argument for %s must be a boolean", name)
+		}
+		empty = b
+	}
+
+	return yaml.Walk(NewNode(arguments[0], binding), func(path []string, n yaml.Node) error {
+		if len(path) == 0 {
+			return nil
+		}
+		if n != nil {
+			switch v := n.Value().(type) {
+			case map[string]yaml.Node:
+				if len(v) > 0 || !empty {
+					return nil
+				}
+			case []yaml.Node:
+				if len(v) > 0 || !empty {
+					return nil
+				}
+			}
+		}
+		visit(leafPath(path), n)
+		return nil
+	})
+}
+
+func leafPath(path []string) string {
+	s := ""
+	for _, p := range path {
+		if s != "" && !strings.HasPrefix(p, "[") {
+			s += "."
+		}
+		s += p
+	}
+	return s
+}
@@ -0,0 +1,41 @@
+package dynaml
+
+import (
+	"fmt"
+)
+
+// PipeExpr is the pipe operator (`a |> f`). It calls the function given by
+// its right operand with the value of its left operand as first positional
+// argument. If the right operand is a call, the value is inserted before
+// its other positional arguments.
+type PipeExpr struct {
+	A Expression
+	B Expression
+}
+
+func (e PipeExpr) Evaluate(binding Binding, locally bool) (interface{}, EvaluationInfo, bool) {
+	return e.call().Evaluate(binding, locally)
+}
+
+func (e PipeExpr) call() CallExpr {
+	if c, ok := e.B.(CallExpr); ok && !c.Curry {
+		args := []Expression{}
+		inserted := false
+		for _, a := range c.Arguments {
+			if _, ok := a.(NameArgument); !ok && !inserted {
+				args = append(args, e.A)
+				inserted = true
+			}
+			args = append(args, a)
+		}
+		if !inserted {
+			args = append(args, e.A)
+		}
+		return CallExpr{Function: c.Function, Arguments: args}
+	}
+	return CallExpr{Function: e.B, Arguments: []Expression{e.A}}
+}
+
+func (e PipeExpr) String() string {
+	return fmt.Sprintf("%s |> %s", e.A, e.B)
+}
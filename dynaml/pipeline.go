@@ -0,0 +1,65 @@
+package dynaml
+
+// checkStage checks whether a value is a lambda callable with a single argument.
+func checkStage(name string, i int, v interface{}) (LambdaValue, EvaluationInfo, bool) {
+	info := DefaultInfo()
+	lambda, ok := v.(LambdaValue)
+	if !ok {
+		info.SetError("%s: stage %d must be a lambda value, but found %s", name, i, ExpressionType(v))
+		return lambda, info, false
+	}
+	nparams := len(lambda.Parameters)
+	required := nparams - lambda.NumOptional()
+	if lambda.lambda.VarArgs && required == nparams {
+		required--
+	}
+	if nparams == 0 || required > 1 {
+		info.SetError("%s: stage %d (%s) cannot be called with one argument", name, i, lambda)
+		return lambda, info, false
+	}
+	return lambda, info, true
+}
+
+func func_pipeline(arguments []interface{}, binding Binding) (bool, interface{}, EvaluationInfo, bool) {
+	info := DefaultInfo()
+
+	if len(arguments) < 1 {
+		info.SetError("pipeline requires at least one argument")
+		return true, nil, info, false
+	}
+	value := arguments[0]
+	for i, f := range arguments[1:] {
+		lambda, info, ok := checkStage("pipeline", i+1, f)
+		if !ok {
+			return true, nil, info, false
+		}
+		resolved, result, sub, ok := lambda.Evaluate(false, false, false, nil, []interface{}{value}, binding, false)
+		if !ok {
+			nested := sub.Issue
+			sub.SetError("pipeline: stage %d failed", i+1)
+			sub.Issue.Nested = append(sub.Issue.Nested, nested)
+			return true, nil, sub, false
+		}
+		if !resolved {
+			return false, nil, sub, true
+		}
+		value = result
+	}
+	return true, value, info, true
+}
+
+func func_compose(arguments []interface{}, binding Binding) (interface{}, EvaluationInfo, bool) {
+	info := DefaultInfo()
+
+	args := []Expression{ReferenceExpr{Path: []string{"x"}}}
+	for i, f := range arguments {
+		_, info, ok := checkStage("compose", i+1, f)
+		if !ok {
+			return nil, info, false
+		}
+		args = append(args, ValueExpr{f})
+	}
+	params := []Parameter{{Name: "x"}}
+	body := CallExpr{ReferenceExpr{Path: []string{"pipeline"}}, args, false}
+	return LambdaValue{params, LambdaExpr{params, false, body}, nil, binding}, info, true
+}
@@ -0,0 +1,134 @@
+package dynaml
+
+import (
+	"fmt"
+	"io"
+	"sort"
+	"sync"
+	"time"
+)
+
+// ProfileEntry describes the cumulative evaluation time and the number
+// of evaluations recorded for a dynaml function or a document path.
+type ProfileEntry struct {
+	Name     string
+	Count    int
+	Duration time.Duration
+}
+
+// Profiler collects evaluation times and call counts per dynaml function
+// (including the map, select, sum and merge operators) and per top-level
+// document path. Times are cumulative, the time of a function call includes
+// the time of all nested calls.
+type Profiler struct {
+	lock      sync.Mutex
+	depth     int
+	functions map[string]*ProfileEntry
+	paths     map[string]*ProfileEntry
+}
+
+func NewProfiler() *Profiler {
+	return &Profiler{
+		functions: map[string]*ProfileEntry{},
+		paths:     map[string]*ProfileEntry{},
+	}
+}
+
+func (p *Profiler) record(entries map[string]*ProfileEntry, name string, start time.Time) {
+	e := entries[name]
+	if e == nil {
+		e = &ProfileEntry{Name: name}
+		entries[name] = e
+	}
+	e.Count++
+	e.Duration += time.Since(start)
+}
+
+// Function records the evaluation of a function started at the given time.
+func (p *Profiler) Function(name string, start time.Time) {
+	p.lock.Lock()
+	defer p.lock.Unlock()
+	p.record(p.functions, name, start)
+}
+
+// Enter is called before the evaluation of the expression of a node.
+func (p *Profiler) Enter() {
+	p.lock.Lock()
+	defer p.lock.Unlock()
+	p.depth++
+}
+
+// Leave records the evaluation of the expression of the node with the
+// given path started at the given time. Only outermost evaluations are
+// recorded, nested ones are accounted to the outer path.
+func (p *Profiler) Leave(path []string, start time.Time) {
+	p.lock.Lock()
+	defer p.lock.Unlock()
+	p.depth--
+	if p.depth == 0 {
+		name := "<root>"
+		if len(path) > 0 {
+			name = path[0]
+		}
+		p.record(p.paths, name, start)
+	}
+}
+
+// Functions returns the recorded function entries ordered by
+// decreasing duration.
+func (p *Profiler) Functions() []ProfileEntry {
+	p.lock.Lock()
+	defer p.lock.Unlock()
+	return sortedEntries(p.functions)
+}
+
+// Paths returns the recorded entries for top-level paths ordered by
+// decreasing duration.
+func (p *Profiler) Paths() []ProfileEntry {
+	p.lock.Lock()
+	defer p.lock.Unlock()
+	return sortedEntries(p.paths)
+}
+
+// Write writes a summary of the recorded entries.
+func (p *Profiler) Write(w io.Writer) {
+	writeEntries(w, "functions", p.Functions())
+	writeEntries(w, "paths", p.Paths())
+}
+
+func writeEntries(w io.Writer, title string, entries []ProfileEntry) {
+	fmt.Fprintf(w, "%-30s %8s %14s\n", title, "count", "time")
+	for _, e := range entries {
+		fmt.Fprintf(w, "  %-28s %8d %14s\n", e.Name, e.Count, e.Duration)
+	}
+}
+
+func sortedEntries(entries map[string]*ProfileEntry) []ProfileEntry {
+	result := make([]ProfileEntry, 0, len(entries))
+	for _, e := range entries {
+		result = append(result, *e)
+	}
+	sort.Slice(result, func(i, j int) bool {
+		if result[i].Duration != result[j].Duration {
+			return result[i].Duration > result[j].Duration
+		}
+		return result[i].Name < result[j].Name
+	})
+	return result
+}
+
+func noProfile() {}
+
+// profileFunction starts the profiling of a function evaluation. The
+// returned function must be called when the evaluation is finished.
+func profileFunction(binding Binding, name string) func() {
+	if binding == nil || binding.GetState() == nil {
+		return noProfile
+	}
+	p := binding.GetState().GetProfiler()
+	if p == nil {
+		return noProfile
+	}
+	start := time.Now()
+	return func() { p.Function(name, start) }
+}
@@ -34,9 +34,10 @@ func (e QualifiedExpr) Evaluate(binding Binding, locally bool) (interface{}, Eva
 	}
 
 	debug.Debug("qualified reference (%t): %v\n", locally, e.Reference.Path)
+	node := NewNode(root, nil)
 	return e.Reference.find(func(end int, path []string) (yaml.Node, bool) {
-		return yaml.Find(NewNode(root, nil), binding.GetFeatures(), path[:end+1]...)
-	}, binding, locally)
+		return yaml.Find(node, binding.GetFeatures(), path[:end+1]...)
+	}, node, binding, locally)
 }
 
 func (e QualifiedExpr) String() string {
@@ -0,0 +1,56 @@
+package dynaml
+
+import (
+	"fmt"
+	"html"
+	"strings"
+
+	"github.com/mandelsoft/spiff/yaml"
+)
+
+func init() {
+	RegisterFunction("shellquote", func_shellquote)
+	RegisterFunction("htmlescape", func_htmlescape)
+	RegisterFunction("htmlunescape", func_htmlunescape)
+}
+
+func func_shellquote(arguments []interface{}, binding Binding) (interface{}, EvaluationInfo, bool) {
+	info := DefaultInfo()
+
+	if len(arguments) != 1 {
+		return info.Error("shellquote takes exactly one argument")
+	}
+
+	switch v := arguments[0].(type) {
+	case string:
+		return shellQuote(v), info, true
+	case []yaml.Node:
+		args := make([]string, len(v))
+		for i, e := range v {
+			switch s := e.Value().(type) {
+			case string:
+				args[i] = shellQuote(s)
+			case int64, bool:
+				args[i] = shellQuote(fmt.Sprintf("%v", s))
+			default:
+				return info.Error("list element %d for shellquote must be a string, integer or boolean", i)
+			}
+		}
+		return strings.Join(args, " "), info, true
+	default:
+		return info.Error("argument for shellquote must be a string or list")
+	}
+}
+
+// shellQuote quotes a string as single POSIX shell token.
+func shellQuote(s string) string {
+	return "'" + strings.Replace(s, "'", `'\''`, -1) + "'"
+}
+
+func func_htmlescape(arguments []interface{}, binding Binding) (interface{}, EvaluationInfo, bool) {
+	return _modifystring("htmlescape", html.EscapeString, arguments, binding)
+}
+
+func func_htmlunescape(arguments []interface{}, binding Binding) (interface{}, EvaluationInfo, bool) {
+	return _modifystring("htmlunescape", html.UnescapeString, arguments, binding)
+}
@@ -0,0 +1,138 @@
+package dynaml
+
+import (
+	crand "crypto/rand"
+	"encoding/binary"
+	"math/rand"
+	"strings"
+
+	"github.com/mandelsoft/spiff/yaml"
+)
+
+func init() {
+	RegisterFunction("random_string", func_random_string)
+	RegisterFunction("random_int", func_random_int)
+	RegisterFunction("random_choice", func_random_choice)
+}
+
+const (
+	charsLower   = "abcdefghijklmnopqrstuvwxyz"
+	charsUpper   = "ABCDEFGHIJKLMNOPQRSTUVWXYZ"
+	charsDigits  = "0123456789"
+	charsSymbols = "!#$%&()*+,-./:;<=>?@[]^_{|}~"
+)
+
+var randomCharsets = map[string]string{
+	"alnum":   charsLower + charsUpper + charsDigits,
+	"alpha":   charsLower + charsUpper,
+	"lower":   charsLower,
+	"upper":   charsUpper,
+	"digits":  charsDigits,
+	"hex":     charsDigits + "abcdef",
+	"symbols": charsLower + charsUpper + charsDigits + charsSymbols,
+}
+
+type cryptoSource struct{}
+
+func (cryptoSource) Int63() int64 {
+	return int64(cryptoSource{}.Uint64() & (1<<63 - 1))
+}
+
+func (cryptoSource) Uint64() uint64 {
+	var buf [8]byte
+	crand.Read(buf[:])
+	return binary.BigEndian.Uint64(buf[:])
+}
+
+func (cryptoSource) Seed(int64) {
+}
+
+// NewCryptoRandom provides a random generator based
+// on the crypto random number source.
+func NewCryptoRandom() *rand.Rand {
+	return rand.New(cryptoSource{})
+}
+
+func randomGenerator(binding Binding) *rand.Rand {
+	if binding != nil {
+		if s := binding.GetState(); s != nil {
+			if r := s.GetRandom(); r != nil {
+				return r
+			}
+		}
+	}
+	return NewCryptoRandom()
+}
+
+func func_random_string(arguments []interface{}, binding Binding) (interface{}, EvaluationInfo, bool) {
+	info := DefaultInfo()
+
+	if len(arguments) < 1 || len(arguments) > 2 {
+		return info.Error("random_string takes one or two arguments")
+	}
+
+	length, ok := arguments[0].(int64)
+	if !ok {
+		return info.Error("first argument for random_string must be an integer")
+	}
+	if length < 0 {
+		return info.Error("length for random_string must not be negative")
+	}
+
+	set := "alnum"
+	if len(arguments) > 1 {
+		set, ok = arguments[1].(string)
+		if !ok {
+			return info.Error("second argument for random_string must be a string")
+		}
+	}
+	chars, ok := randomCharsets[set]
+	if !ok {
+		return info.Error("invalid character set %q for random_string", set)
+	}
+
+	r := randomGenerator(binding)
+	var b strings.Builder
+	for i := int64(0); i < length; i++ {
+		b.WriteByte(chars[r.Intn(len(chars))])
+	}
+	return b.String(), info, true
+}
+
+func func_random_int(arguments []interface{}, binding Binding) (interface{}, EvaluationInfo, bool) {
+	info := DefaultInfo()
+
+	if len(arguments) != 2 {
+		return info.Error("random_int takes exactly two arguments")
+	}
+
+	min, ok := arguments[0].(int64)
+	if !ok {
+		return info.Error("first argument for random_int must be an integer")
+	}
+	max, ok := arguments[1].(int64)
+	if !ok {
+		return info.Error("second argument for random_int must be an integer")
+	}
+	if max < min || max-min < 0 || max-min == MaxInt {
+		return info.Error("invalid range [%d,%d] for random_int", min, max)
+	}
+	return min + randomGenerator(binding).Int63n(max-min+1), info, true
+}
+
+func func_random_choice(arguments []interface{}, binding Binding) (interface{}, EvaluationInfo, bool) {
+	info := DefaultInfo()
+
+	if len(arguments) != 1 {
+		return info.Error("random_choice takes exactly one argument")
+	}
+
+	list, ok := arguments[0].([]yaml.Node)
+	if !ok {
+		return info.Error("argument for random_choice must be a list")
+	}
+	if len(list) == 0 {
+		return info.Error("random_choice requires a non-empty list")
+	}
+	return list[randomGenerator(binding).Intn(len(list))].Value(), info, true
+}
@@ -71,5 +71,12 @@ func (e RangeExpr) Evaluate(binding Binding, locally bool) (interface{}, Evaluat
 }
 
 func (e RangeExpr) String() string {
-	return fmt.Sprintf("[%s..%s]", e.Start, e.End)
+	start, end := "", ""
+	if e.Start != nil {
+		start = fmt.Sprintf("%s", e.Start)
+	}
+	if e.End != nil {
+		end = fmt.Sprintf("%s", e.End)
+	}
+	return fmt.Sprintf("[%s..%s]", start, end)
 }
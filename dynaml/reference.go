@@ -1,6 +1,7 @@
 package dynaml
 
 import (
+	"fmt"
 	"strings"
 
 	"github.com/mandelsoft/spiff/debug"
@@ -10,14 +11,19 @@ import (
 type ReferenceExpr struct {
 	Tag  string
 	Path []string
+	// Optional is set for safe navigation (`a?.b`). A missing path
+	// component then yields undefined instead of an error, if at least
+	// the first Required components could be found.
+	Optional bool
+	Required int
 }
 
 func NewReferenceExpr(path ...string) ReferenceExpr {
-	return ReferenceExpr{"", path}
+	return ReferenceExpr{Tag: "", Path: path}
 }
 
 func NewTaggedReferenceExpr(tag string, path ...string) ReferenceExpr {
-	return ReferenceExpr{tag, path}
+	return ReferenceExpr{Tag: tag, Path: path}
 }
 
 func (e ReferenceExpr) Evaluate(binding Binding, locally bool) (interface{}, EvaluationInfo, bool) {
@@ -49,7 +55,11 @@ func (e ReferenceExpr) Evaluate(binding Binding, locally bool) (interface{}, Eva
 			}
 			if len(e.Path) == 1 && e.Path[0] == "" {
 				if len(tags) == 1 || tags[0].Name() == e.Tag {
-					return tags[0].Node().Value(), info, true
+					value := tags[0].Node().Value()
+					if isEmptyValue(value) {
+						info.Warn("tag '%s' is empty", tags[0].Name())
+					}
+					return value, info, true
 				}
 				return info.Error("found multiple tags for '%s': %s", e.Tag, tagList(tags))
 			}
@@ -61,7 +71,7 @@ func (e ReferenceExpr) Evaluate(binding Binding, locally bool) (interface{}, Eva
 				if found != nil && found.Level() < t.Level() {
 					break
 				}
-				val1, info1, ok1 := e.find(sel, binding, locally)
+				val1, info1, ok1 := e.find(sel, nil, binding, locally)
 				if ok1 {
 					if tag.Name() == e.Tag {
 						return val1, info1, ok1
@@ -85,7 +95,7 @@ func (e ReferenceExpr) Evaluate(binding Binding, locally bool) (interface{}, Eva
 			fromRoot = true
 		}
 	}
-	return e.find(sel, binding, locally)
+	return e.find(sel, nil, binding, locally)
 }
 
 func (e ReferenceExpr) String() string {
@@ -96,22 +106,37 @@ func (e ReferenceExpr) String() string {
 	if len(e.Path) == 1 && e.Path[0] == "" {
 		return tag + "."
 	}
+	if e.Optional && e.Required+1 < len(e.Path) {
+		return tag + strings.Join(e.Path[:e.Required+1], ".") + "?." + strings.Join(e.Path[e.Required+1:], ".")
+	}
 	return tag + strings.Join(e.Path, ".")
 }
 
-func (e ReferenceExpr) find(f func(int, []string) (node yaml.Node, x bool), binding Binding, locally bool) (interface{}, EvaluationInfo, bool) {
+// find resolves the path of the reference step by step using the given
+// lookup function. root is the node the path is resolved in, if known.
+func (e ReferenceExpr) find(f func(int, []string) (node yaml.Node, x bool), root yaml.Node, binding Binding, locally bool) (interface{}, EvaluationInfo, bool) {
 	var step yaml.Node
 	var ok bool
 
 	info := DefaultInfo()
 	debug.Debug("resolving ref [%v]", e.Path)
+	parent := root
 	for i := 0; i < len(e.Path); i++ {
 		step, ok = f(i, e.Path)
 
 		debug.Debug("  %d: %v %+v\n", i, ok, step)
 		if !ok {
+			if e.Optional && i >= e.Required {
+				debug.Debug("  optional step %d not found\n", i)
+				info.Undefined = true
+				return nil, info, true
+			}
+			if msg := indexError(parent, e.Path[i]); msg != "" {
+				return info.Error("'%s': %s", strings.Join(e.Path[0:i+1], "."), msg)
+			}
 			return info.Error("'%s' not found", strings.Join(e.Path[0:i+1], "."))
 		}
+		parent = step
 
 		if !isLocallyResolved(step, binding) {
 			debug.Debug("  locally unresolved %T\n", step.Value())
@@ -120,6 +145,7 @@ func (e ReferenceExpr) find(f func(int, []string) (node yaml.Node, x bool), bind
 			} else {
 				info.Issue = yaml.NewIssue("'%s' not complete", strings.Join(e.Path[0:i+1], "."))
 			}
+			info.Issue.Reference = e.unresolvedReference(e.Path[0 : i+1])
 			info.Failed = step.Failed() || step.HasError()
 			return e, info, true
 		}
@@ -128,6 +154,7 @@ func (e ReferenceExpr) find(f func(int, []string) (node yaml.Node, x bool), bind
 	if !locally && !isResolvedValue(step.Value(), binding) {
 		debug.Debug("  unresolved\n")
 		info.Issue = yaml.NewIssue("'%s' unresolved", e.String())
+		info.Issue.Reference = e.unresolvedReference(e.Path)
 		info.Failed = step.Failed() || step.HasError()
 		return e, info, true
 	}
@@ -137,6 +164,32 @@ func (e ReferenceExpr) find(f func(int, []string) (node yaml.Node, x bool), bind
 	return value(yaml.ReferencedNode(step)), info, true
 }
 
+// unresolvedReference returns the path of an unresolved reference to be
+// recorded for the issue. Tagged references are not recorded.
+func (e ReferenceExpr) unresolvedReference(path []string) []string {
+	if e.Tag != "" {
+		return nil
+	}
+	return append(path[:0:0], path...)
+}
+
+// indexError describes an index path step outside of the bounds
+// of the given list node. For other steps an empty string is returned.
+func indexError(list yaml.Node, step string) string {
+	if list == nil {
+		return ""
+	}
+	l, ok := list.Value().([]yaml.Node)
+	if !ok {
+		return ""
+	}
+	index, ok := yaml.ListIndex(step)
+	if !ok || (index >= -len(l) && index < len(l)) {
+		return ""
+	}
+	return fmt.Sprintf("index %s out of range (len %d)", step[1:len(step)-1], len(l))
+}
+
 func tagList(list []*TagInfo) string {
 	s := ""
 	sep := ""
@@ -146,3 +199,16 @@ func tagList(list []*TagInfo) string {
 	}
 	return s
 }
+
+// isEmptyValue checks for nil values, empty lists and empty maps.
+func isEmptyValue(v interface{}) bool {
+	switch t := v.(type) {
+	case nil:
+		return true
+	case []yaml.Node:
+		return len(t) == 0
+	case map[string]yaml.Node:
+		return len(t) == 0
+	}
+	return false
+}
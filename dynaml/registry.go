@@ -2,6 +2,7 @@ package dynaml
 
 type Registry interface {
 	LookupFunction(name string) Function
+	LookupDeprecation(name string) (string, bool)
 
 	LookupControl(name string) (*Control, bool)
 	IsTemplateControlOption(name string) bool
@@ -42,6 +43,13 @@ func (r *registry) LookupFunction(name string) Function {
 	return r.functions.LookupFunction(name)
 }
 
+func (r *registry) LookupDeprecation(name string) (string, bool) {
+	if r == nil || r.functions == nil {
+		return function_registry.LookupDeprecation(name)
+	}
+	return r.functions.LookupDeprecation(name)
+}
+
 func (r *registry) LookupControl(name string) (*Control, bool) {
 	if r == nil || r.controls == nil {
 		return control_registry.LookupControl(name)
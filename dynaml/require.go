@@ -1,9 +1,15 @@
 package dynaml
 
+import (
+	"fmt"
+
+	"github.com/mandelsoft/spiff/yaml"
+)
+
 func (e CallExpr) require(binding Binding) (interface{}, EvaluationInfo, bool) {
 	info := DefaultInfo()
-	if len(e.Arguments) != 1 {
-		return info.Error("one argument expected for 'require'")
+	if len(e.Arguments) < 1 || len(e.Arguments) > 2 {
+		return info.Error("one or two arguments expected for 'require'")
 	}
 	pushed := e.Arguments[0]
 	ok := true
@@ -15,8 +21,97 @@ func (e CallExpr) require(binding Binding) (interface{}, EvaluationInfo, bool) {
 	}
 
 	if !ok || val == nil {
-		return info.Error("required expression %q undefined", e.Arguments[0])
+		return e.requireFailed("require", binding, func() string {
+			return fmt.Sprintf("required expression %q undefined", e.Arguments[0])
+		})
 	}
 
 	return val, info, val != nil
 }
+
+func (e CallExpr) require_all(binding Binding) (interface{}, EvaluationInfo, bool) {
+	info := DefaultInfo()
+	if len(e.Arguments) < 1 || len(e.Arguments) > 2 {
+		return info.Error("one or two arguments expected for 'require_all'")
+	}
+
+	orig := []Expression{e.Arguments[0]}
+	literal, isLiteral := e.Arguments[0].(ListExpr)
+	if isLiteral {
+		// check every expression separately to detect undefined references
+		orig = literal.Contents
+	}
+	exprs := make([]Expression, len(orig))
+	copy(exprs, orig)
+
+	resolved := true
+	values := make([]interface{}, len(exprs))
+	failed := -1
+	for i := range exprs {
+		val, _, ok := ResolveExpressionOrPushEvaluation(&exprs[i], &resolved, nil, binding, true)
+		if !ok && failed < 0 {
+			failed = i
+		}
+		values[i] = val
+	}
+	if !resolved {
+		return e, info, true
+	}
+
+	if !isLiteral && failed < 0 {
+		list, ok := values[0].([]yaml.Node)
+		if !ok {
+			return info.Error("require_all: list expected, but found %s", ExpressionType(values[0]))
+		}
+		values = make([]interface{}, len(list))
+		for i, n := range list {
+			values[i] = n.Value()
+		}
+		orig = nil
+	}
+
+	if failed < 0 {
+		for i, v := range values {
+			if v == nil {
+				failed = i
+				break
+			}
+		}
+	}
+	if failed >= 0 {
+		return e.requireFailed("require_all", binding, func() string {
+			if orig != nil {
+				return fmt.Sprintf("required expression %q undefined", orig[failed])
+			}
+			return fmt.Sprintf("required entry %d undefined", failed)
+		})
+	}
+	result := make([]yaml.Node, len(values))
+	for i, v := range values {
+		result[i] = NewNode(v, binding)
+	}
+	return result, info, true
+}
+
+// requireFailed reports a failed requirement with the message given
+// as second argument or with the default message.
+func (e CallExpr) requireFailed(name string, binding Binding, msg func() string) (interface{}, EvaluationInfo, bool) {
+	info := DefaultInfo()
+	if len(e.Arguments) < 2 {
+		return info.Error("%s", msg())
+	}
+	resolved := true
+	pushed := e.Arguments[1]
+	val, infom, ok := ResolveExpressionOrPushEvaluation(&pushed, &resolved, nil, binding, false)
+	if !ok {
+		return nil, infom, false
+	}
+	if !resolved {
+		return e, info, true
+	}
+	s, ok := val.(string)
+	if !ok {
+		return info.Error("%s: message must be a string, but found %s", name, ExpressionType(val))
+	}
+	return info.Error("%s", s)
+}
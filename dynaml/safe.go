@@ -0,0 +1,115 @@
+package dynaml
+
+import (
+	"fmt"
+	"strings"
+	"unicode/utf8"
+)
+
+// MaxSafeNesting is the maximal nesting depth of brackets accepted by
+// SafeParse. The parser backtracks on nested brackets, so that deeply
+// nested malformed expressions would require exponential parse time.
+// Brackets following a name (like `map[` or `lambda (`) may be parsed up to
+// four times, therefore they count as two levels.
+const MaxSafeNesting = 12
+
+// InternalError describes a panic caught while parsing or evaluating
+// a dynaml expression.
+type InternalError struct {
+	Source string
+	Cause  interface{}
+}
+
+func (e *InternalError) Error() string {
+	return fmt.Sprintf("internal error for %q: %v", e.Source, e.Cause)
+}
+
+// SafeParse parses a dynaml expression like Parse, but never panics.
+// A panic caused by the given source is reported as InternalError.
+// Additionally the nesting depth of the expression is limited to
+// MaxSafeNesting to bound the parse time to a few milliseconds.
+// It should be used for expressions taken from untrusted sources.
+func SafeParse(source string, path []string, stubPath []string) (expr Expression, err error) {
+	defer func() {
+		if r := recover(); r != nil {
+			expr, err = nil, &InternalError{Source: source, Cause: r}
+		}
+	}()
+	if err := checkNesting(source, MaxSafeNesting); err != nil {
+		return nil, err
+	}
+	return Parse(source, path, stubPath)
+}
+
+// SafeEvaluate evaluates an expression, but never panics. Evaluation errors
+// raised by RaiseEvaluationError are reported as regular evaluation result,
+// any other panic is reported as failed evaluation with an internal error.
+func SafeEvaluate(expr Expression, binding Binding, locally bool) (result interface{}, info EvaluationInfo, ok bool) {
+	defer func() {
+		if r := recover(); r != nil {
+			if eerr, my := r.(EvaluationError); my {
+				result, info, ok = nil, eerr.EvaluationInfo, eerr.ok
+				return
+			}
+			info = DefaultInfo()
+			result, info, ok = info.Error("%s", &InternalError{Source: fmt.Sprintf("%s", expr), Cause: r})
+		}
+	}()
+	return expr.Evaluate(binding, locally)
+}
+
+// checkNesting checks the weighted nesting depth of brackets outside of
+// string literals.
+func checkNesting(source string, max int) error {
+	depth := 0
+	var levels []int
+	var quote rune
+	escaped := false
+	for i, c := range source {
+		if quote != 0 {
+			switch {
+			case escaped:
+				escaped = false
+			case c == '\\' && quote == '"':
+				escaped = true
+			case c == quote:
+				quote = 0
+			}
+			continue
+		}
+		switch c {
+		case '"', '`':
+			quote = c
+		case '(', '[', '{':
+			weight := 1
+			if c != '{' && followsName(source[:i], c == '[') {
+				weight = 2
+			}
+			levels = append(levels, weight)
+			depth += weight
+			if depth > max {
+				line := strings.Count(source[:i], "\n") + 1
+				symbol := utf8.RuneCountInString(source[strings.LastIndex(source[:i], "\n")+1 : i])
+				return &ParseError{Line: line, Symbol: symbol, message: fmt.Sprintf("expression nesting exceeds %d levels", max)}
+			}
+		case ')', ']', '}':
+			if len(levels) > 0 {
+				depth -= levels[len(levels)-1]
+				levels = levels[:len(levels)-1]
+			}
+		}
+	}
+	return nil
+}
+
+// followsName checks whether the given prefix of an expression ends with
+// a name followed by whitespace. If direct is set, the whitespace is
+// optional.
+func followsName(prefix string, direct bool) bool {
+	trimmed := strings.TrimRight(prefix, " \t\n\r")
+	if trimmed == prefix && !direct || trimmed == "" {
+		return false
+	}
+	c := trimmed[len(trimmed)-1]
+	return c == '_' || '0' <= c && c <= '9' || 'a' <= c && c <= 'z' || 'A' <= c && c <= 'Z'
+}
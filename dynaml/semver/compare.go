@@ -8,6 +8,7 @@ const F_Compare = "semvercmp"
 
 func init() {
 	RegisterFunction(F_Compare, func_compare)
+	DescribeFunction(F_Compare, "2", "compare two semantic versions")
 }
 
 func func_compare(args []interface{}, binding Binding) (interface{}, EvaluationInfo, bool) {
@@ -8,6 +8,7 @@ const F_Match = "semvermatch"
 
 func init() {
 	RegisterFunction(F_Match, func_match)
+	DescribeFunction(F_Match, "1+", "match a semantic version against constraints")
 }
 
 func func_match(args []interface{}, binding Binding) (interface{}, EvaluationInfo, bool) {
@@ -12,8 +12,11 @@ const F_IncPatch = "semverincpatch"
 
 func init() {
 	RegisterFunction(F_IncMajor, func_incmajor)
+	DescribeFunction(F_IncMajor, "1", "increment the major version of a semantic version")
 	RegisterFunction(F_IncMinor, func_incminor)
+	DescribeFunction(F_IncMinor, "1", "increment the minor version of a semantic version")
 	RegisterFunction(F_IncPatch, func_incpatch)
+	DescribeFunction(F_IncPatch, "1", "increment the patch version of a semantic version")
 }
 
 func func_incmajor(arguments []interface{}, binding Binding) (interface{}, EvaluationInfo, bool) {
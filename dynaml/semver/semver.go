@@ -16,12 +16,19 @@ const F_Normalize = "semver"
 
 func init() {
 	RegisterFunction(F_Major, func_major)
+	DescribeFunction(F_Major, "1", "get the major version of a semantic version")
 	RegisterFunction(F_Minor, func_minor)
+	DescribeFunction(F_Minor, "1", "get the minor version of a semantic version")
 	RegisterFunction(F_Patch, func_patch)
+	DescribeFunction(F_Patch, "1", "get the patch version of a semantic version")
 	RegisterFunction(F_Prerelease, func_prerelease)
+	DescribeFunction(F_Prerelease, "1-2", "get or set the prerelease of a semantic version")
 	RegisterFunction(F_Metadata, func_metadata)
+	DescribeFunction(F_Metadata, "1-2", "get or set the metadata of a semantic version")
 	RegisterFunction(F_Release, func_release)
+	DescribeFunction(F_Release, "1", "get the release part of a semantic version")
 	RegisterFunction(F_Normalize, func_normalize)
+	DescribeFunction(F_Normalize, "1", "normalize a semantic version")
 }
 
 func parse(name string, arg interface{}) (*semver.Version, EvaluationInfo) {
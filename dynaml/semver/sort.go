@@ -13,6 +13,7 @@ const F_Sort = "semversort"
 
 func init() {
 	RegisterFunction(F_Sort, func_sort)
+	DescribeFunction(F_Sort, "0+", "sort semantic versions")
 }
 
 func func_sort(args []interface{}, binding Binding) (interface{}, EvaluationInfo, bool) {
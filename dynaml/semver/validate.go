@@ -11,6 +11,7 @@ const V_Validate = "semver"
 
 func init() {
 	RegisterFunction(F_Validate, func_validate)
+	DescribeFunction(F_Validate, "1", "validate a semantic version")
 	RegisterValidator(V_Validate, validate_semver)
 }
 
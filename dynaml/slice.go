@@ -38,32 +38,30 @@ func (e SliceExpr) Evaluate(binding Binding, locally bool) (interface{}, Evaluat
 	if !resolved {
 		return e, info, ok
 	}
-	if start > end {
-		return []yaml.Node{}, info, ok
+	// negative indices count from the end of the list
+	size := int64(len(array))
+	from, to := start, end
+	if from < 0 {
+		from += size
 	}
-
-	if start < 0 {
-		if end >= 0 {
-			return info.Error("mixed negative and non-negative range not possible for slice")
-		}
-		if start < -int64(len(array)) {
-			return info.Error("slice out of range (%d < -length %d)", start, len(array))
-		}
-		result := make([]yaml.Node, end-start+1)
-		for i := start; i <= end; i++ {
-			result[i-start] = array[i+int64(len(array))]
-		}
-		return result, info, true
-	} else {
-		if end >= int64(len(array)) {
-			return info.Error("slice out of range (%d >= length %d)", end, len(array))
-		}
-		result := make([]yaml.Node, end-start+1)
-		for i := start; i <= end; i++ {
-			result[i-start] = array[i]
+	if to < 0 {
+		to += size
+	}
+	if from > to {
+		if from > to+1 && e.Range.Start != nil && e.Range.End != nil {
+			return info.Error("slice end %d before start %d", end, start)
 		}
-		return result, info, true
+		return []yaml.Node{}, info, ok
+	}
+	if from < 0 {
+		return info.Error("slice out of range (%d < -length %d)", start, len(array))
+	}
+	if to >= size {
+		return info.Error("slice out of range (%d >= length %d)", end, len(array))
 	}
+	result := make([]yaml.Node, to-from+1)
+	copy(result, array[from:to+1])
+	return result, info, true
 }
 
 func (e SliceExpr) String() string {
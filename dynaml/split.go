@@ -7,6 +7,11 @@ import (
 	"github.com/mandelsoft/spiff/yaml"
 )
 
+func init() {
+	RegisterFunction("split_n", func_split_n)
+	RegisterFunction("split_regex", func_split_regex)
+}
+
 func func_split(arguments []interface{}, binding Binding) (interface{}, EvaluationInfo, bool) {
 	info := DefaultInfo()
 
@@ -31,11 +36,7 @@ func func_split(arguments []interface{}, binding Binding) (interface{}, Evaluati
 	var result []yaml.Node
 	sep, ok := arguments[0].(string)
 	if ok {
-		array := strings.SplitN(str, sep, n)
-		result = make([]yaml.Node, len(array))
-		for i, e := range array {
-			result[i] = NewNode(e, binding)
-		}
+		result = splitString(str, sep, n, binding)
 	} else {
 		max, ok := arguments[0].(int64)
 		if !ok {
@@ -65,6 +66,37 @@ func func_split(arguments []interface{}, binding Binding) (interface{}, Evaluati
 	return result, info, true
 }
 
+func func_split_n(arguments []interface{}, binding Binding) (interface{}, EvaluationInfo, bool) {
+	info := DefaultInfo()
+
+	if len(arguments) != 3 {
+		return info.Error("split_n takes exactly 3 arguments")
+	}
+
+	str, ok := arguments[0].(string)
+	if !ok {
+		return info.Error("first argument for split_n must be a string")
+	}
+	sep, ok := arguments[1].(string)
+	if !ok {
+		return info.Error("second argument for split_n must be a string")
+	}
+	n, ok := arguments[2].(int64)
+	if !ok {
+		return info.Error("third argument for split_n must be an integer")
+	}
+	return splitString(str, sep, int(n), binding), info, true
+}
+
+func splitString(str, sep string, n int, binding Binding) []yaml.Node {
+	array := strings.SplitN(str, sep, n)
+	result := make([]yaml.Node, len(array))
+	for i, e := range array {
+		result[i] = NewNode(e, binding)
+	}
+	return result
+}
+
 func func_splitMatch(arguments []interface{}, binding Binding) (interface{}, EvaluationInfo, bool) {
 	info := DefaultInfo()
 
@@ -80,19 +112,42 @@ func func_splitMatch(arguments []interface{}, binding Binding) (interface{}, Eva
 	if !ok {
 		return info.Error("second argument for split_match must be a string")
 	}
+	return splitRegex("split_match", str, sep, arguments[2:], binding)
+}
+
+func func_split_regex(arguments []interface{}, binding Binding) (interface{}, EvaluationInfo, bool) {
+	info := DefaultInfo()
+
+	if len(arguments) < 2 || len(arguments) > 3 {
+		return info.Error("split_regex takes 2 or 3 arguments")
+	}
+
+	str, ok := arguments[0].(string)
+	if !ok {
+		return info.Error("first argument for split_regex must be a string")
+	}
+	sep, ok := arguments[1].(string)
+	if !ok {
+		return info.Error("second argument for split_regex must be a string")
+	}
+	return splitRegex("split_regex", str, sep, arguments[2:], binding)
+}
+
+func splitRegex(name string, str, sep string, limit []interface{}, binding Binding) (interface{}, EvaluationInfo, bool) {
+	info := DefaultInfo()
 
 	n := -1
-	if len(arguments) > 2 {
-		m, ok := arguments[2].(int64)
+	if len(limit) > 0 {
+		m, ok := limit[0].(int64)
 		if !ok {
-			return info.Error("third argument for split must be an integer")
+			return info.Error("third argument for %s must be an integer", name)
 		}
 		n = int(m)
 	}
 
 	exp, err := regexp.Compile(sep)
 	if err != nil {
-		return info.Error("split_match: %s", err)
+		return info.Error("%s: %s", name, err)
 	}
 	array := exp.Split(str, n)
 
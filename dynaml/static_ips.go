@@ -120,7 +120,7 @@ func findStaticIPRanges(binding Binding) ([]string, EvaluationInfo, bool) {
 		return nil, info, false
 	}
 
-	subnetsRef := ReferenceExpr{"", []string{"", "networks", networkName, "subnets"}}
+	subnetsRef := NewReferenceExpr("", "networks", networkName, "subnets")
 	subnets, info, found := subnetsRef.Evaluate(binding, false)
 
 	if !found {
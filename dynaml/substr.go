@@ -13,33 +13,34 @@ func func_substr(arguments []interface{}, binding Binding) (interface{}, Evaluat
 	if !ok {
 		return info.Error("first argument for substr must be a string")
 	}
+	runes := []rune(str)
 	start, ok := arguments[1].(int64)
 	if !ok {
 		return info.Error("second argument for substr must be an integer")
 	}
 	if start < 0 {
-		start = int64(len(str)) + start
+		start = int64(len(runes)) + start
 	}
-	var end int64 = int64(len(str))
+	var end int64 = int64(len(runes))
 	if len(arguments) >= 3 {
 		end, ok = arguments[2].(int64)
 		if !ok {
 			return info.Error("third argument for substr must be an integer")
 		}
 		if end < 0 {
-			end = int64(len(str)) + end
+			end = int64(len(runes)) + end
 		}
 	}
 
-	if int64(len(str)) < end {
-		return info.Error("substr effective end index (%d) exceeds string length (%d)", end, len(str))
+	if int64(len(runes)) < end {
+		return info.Error("substr effective end index (%d) exceeds string length (%d)", end, len(runes))
 	}
 	if start < 0 {
 		return info.Error("negative substr effective start index (%d)", start)
 	}
 	if start > end {
-		return info.Error("substr start index (%d) aftsre end index (%d) ", start, end)
+		return info.Error("substr start index (%d) after end index (%d)", start, end)
 	}
 
-	return str[start:end], info, true
+	return string(runes[start:end]), info, true
 }
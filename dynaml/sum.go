@@ -15,6 +15,7 @@ type SumExpr struct {
 
 func (e SumExpr) Evaluate(binding Binding, locally bool) (interface{}, EvaluationInfo, bool) {
 	resolved := true
+	defer profileFunction(binding, "sum[]")()
 
 	debug.Debug("evaluate sum")
 	value, info, ok := ResolveExpressionOrPushEvaluation(&e.A, &resolved, nil, binding, true)
@@ -0,0 +1,144 @@
+package dynaml
+
+import (
+	"fmt"
+
+	"github.com/mandelsoft/spiff/yaml"
+)
+
+func (e CallExpr) switchcase(binding Binding) (interface{}, EvaluationInfo, bool) {
+	info := DefaultInfo()
+	if len(e.Arguments) < 2 || len(e.Arguments) > 3 {
+		return info.Error("switch requires two or three arguments")
+	}
+
+	resolved := true
+	pushed := make([]Expression, len(e.Arguments))
+	copy(pushed, e.Arguments)
+
+	value, info, ok := ResolveExpressionOrPushEvaluation(&pushed[0], &resolved, nil, binding, false)
+	if !ok {
+		return nil, info, false
+	}
+	if !resolved {
+		return e, info, true
+	}
+
+	var key string
+	switch v := value.(type) {
+	case string:
+		key = v
+	case int64, bool:
+		key = fmt.Sprintf("%v", v)
+	default:
+		return info.Error("switch value must be a string, integer or boolean")
+	}
+
+	if m, ok := pushed[1].(CreateMapExpr); ok {
+		// literal case map: only evaluate the selected value
+		for _, a := range m.Assignments {
+			k, infok, ok := ResolveExpressionOrPushEvaluation(&a.Key, &resolved, nil, binding, false)
+			if !ok {
+				return nil, infok, false
+			}
+			if !resolved {
+				return e, infok, true
+			}
+			kstr, ok := k.(string)
+			if !ok {
+				return info.Error("switch case key must evaluate to string")
+			}
+			if kstr == key {
+				return e.selectCase(a.Value, binding)
+			}
+		}
+	} else {
+		cases, infoc, ok := ResolveExpressionOrPushEvaluation(&pushed[1], &resolved, nil, binding, false)
+		if !ok {
+			return nil, infoc, false
+		}
+		if !resolved {
+			return e, infoc, true
+		}
+		m, ok := cases.(map[string]yaml.Node)
+		if !ok {
+			return info.Error("switch cases must be given by a map")
+		}
+		if n, ok := m[key]; ok {
+			return n.Value(), info, true
+		}
+	}
+
+	if len(e.Arguments) == 3 {
+		return e.selectCase(e.Arguments[2], binding)
+	}
+	return info.Error("no switch case found for %q", key)
+}
+
+func (e CallExpr) cond(binding Binding) (interface{}, EvaluationInfo, bool) {
+	info := DefaultInfo()
+	if len(e.Arguments) < 1 || len(e.Arguments) > 2 {
+		return info.Error("cond requires one or two arguments")
+	}
+
+	resolved := true
+	if l, ok := e.Arguments[0].(ListExpr); ok {
+		// literal condition list: only evaluate the selected value
+		for i, c := range l.Contents {
+			pair, ok := c.(ListExpr)
+			if !ok || len(pair.Contents) != 2 {
+				return info.Error("cond entry %d must be a list with a condition and a value", i)
+			}
+			cond := pair.Contents[0]
+			v, infoc, ok := ResolveExpressionOrPushEvaluation(&cond, &resolved, nil, binding, false)
+			if !ok {
+				return nil, infoc, false
+			}
+			if !resolved {
+				return e, infoc, true
+			}
+			if toBool(v) {
+				return e.selectCase(pair.Contents[1], binding)
+			}
+		}
+	} else {
+		pushed := e.Arguments[0]
+		v, infoc, ok := ResolveExpressionOrPushEvaluation(&pushed, &resolved, nil, binding, false)
+		if !ok {
+			return nil, infoc, false
+		}
+		if !resolved {
+			return e, infoc, true
+		}
+		list, ok := v.([]yaml.Node)
+		if !ok {
+			return info.Error("cond requires a list of conditions")
+		}
+		for i, c := range list {
+			pair, ok := c.Value().([]yaml.Node)
+			if !ok || len(pair) != 2 {
+				return info.Error("cond entry %d must be a list with a condition and a value", i)
+			}
+			if toBool(pair[0].Value()) {
+				return pair[1].Value(), info, true
+			}
+		}
+	}
+
+	if len(e.Arguments) == 2 {
+		return e.selectCase(e.Arguments[1], binding)
+	}
+	return info.Error("no cond condition met")
+}
+
+func (e CallExpr) selectCase(expr Expression, binding Binding) (interface{}, EvaluationInfo, bool) {
+	resolved := true
+	value, info, ok := ResolveExpressionOrPushEvaluation(&expr, &resolved, nil, binding, false)
+	if !ok {
+		return nil, info, false
+	}
+	if !resolved {
+		return e, info, true
+	}
+	return value, info, true
+}
@@ -28,7 +28,11 @@ func (e SubstitutionExpr) Evaluate(binding Binding, locally bool) (interface{},
 	inp[yaml.SELF] = yaml.ResolverNode(NewNode(n, binding), template.resolver)
 
 	debug.Debug("resolving template '%s' %s\n", strings.Join(template.Path, "."), binding)
+	if err := pushNesting(binding, "template "+strings.Join(template.Path, ".")); err != nil {
+		return info.Error("%s", err)
+	}
 	result, state := binding.WithLocalScope(inp).Flow(prepared, false)
+	popNesting(binding)
 	info = DefaultInfo()
 	if result != nil && result.Undefined() {
 		info.Undefined = true
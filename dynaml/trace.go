@@ -0,0 +1,94 @@
+package dynaml
+
+import (
+	"fmt"
+	"io"
+	"strings"
+	"sync"
+
+	"github.com/mandelsoft/spiff/yaml"
+)
+
+// Tracer is notified about the evaluation of dynaml expressions.
+// Evaluations triggered while evaluating an expression (for example
+// by templates, lambda functions or includes) are reported between
+// the Enter and Leave calls of the outer expression.
+type Tracer interface {
+	Enter(path []string, expr Expression)
+	Leave(path []string, expr Expression, value interface{}, info EvaluationInfo, ok bool)
+}
+
+type traceWriter struct {
+	lock     sync.Mutex
+	writer   io.Writer
+	prefixes []string
+	depth    int
+}
+
+// NewTracer provides a tracer writing a line for every evaluated expression
+// to the given writer. Nested evaluations are reported before, and
+// indented relative to, the outer expression. If path prefixes are given,
+// only expressions for nodes matching one of those prefixes are reported.
+func NewTracer(w io.Writer, prefixes ...string) Tracer {
+	return &traceWriter{writer: w, prefixes: prefixes}
+}
+
+func (t *traceWriter) Enter(path []string, expr Expression) {
+	t.lock.Lock()
+	defer t.lock.Unlock()
+	t.depth++
+}
+
+func (t *traceWriter) Leave(path []string, expr Expression, value interface{}, info EvaluationInfo, ok bool) {
+	t.lock.Lock()
+	defer t.lock.Unlock()
+	t.depth--
+	p := strings.Join(path, ".")
+	if !t.matches(p) {
+		return
+	}
+	result := ""
+	switch {
+	case !ok:
+		result = "failed"
+		if info.Issue.Issue != "" {
+			result += ": " + info.Issue.Issue
+		}
+	case IsExpression(value):
+		result = "unresolved"
+	default:
+		result = "= " + traceValue(value)
+	}
+	fmt.Fprintf(t.writer, "%s%s\t(( %s ))\t%s\n", strings.Repeat("  ", t.depth), p, expr, result)
+}
+
+func (t *traceWriter) matches(path string) bool {
+	if len(t.prefixes) == 0 {
+		return true
+	}
+	for _, prefix := range t.prefixes {
+		if path == prefix || strings.HasPrefix(path, prefix+".") || prefix == "" {
+			return true
+		}
+	}
+	return false
+}
+
+func traceValue(value interface{}) string {
+	switch v := value.(type) {
+	case nil:
+		return "~"
+	case map[string]yaml.Node:
+		return "<map>"
+	case []yaml.Node:
+		return "<list>"
+	case TemplateValue:
+		return "<template>"
+	case LambdaValue:
+		return v.String()
+	case string:
+		return fmt.Sprintf("%q", v)
+	default:
+		return fmt.Sprintf("%v", v)
+	}
+}
@@ -6,25 +6,68 @@ import (
 	"github.com/mandelsoft/spiff/yaml"
 )
 
+func init() {
+	RegisterFunction("trim_prefix", func_trim_prefix)
+	RegisterFunction("trim_suffix", func_trim_suffix)
+	RegisterFunction("trim_space", func_trim_space)
+}
+
 func func_trim(arguments []interface{}, binding Binding) (interface{}, EvaluationInfo, bool) {
 	info := DefaultInfo()
 	ok := true
 
-	if len(arguments) > 2 {
-		return info.Error("split takes one or two arguments")
+	if len(arguments) < 1 || len(arguments) > 2 {
+		return info.Error("trim takes one or two arguments")
 	}
 
 	cutset := " \t"
 	if len(arguments) == 2 {
 		cutset, ok = arguments[1].(string)
 		if !ok {
-			return info.Error("second argument of split must be a string")
+			return info.Error("second argument of trim must be a string")
 		}
 	}
+	return _trim("trim", func(s string) string { return strings.Trim(s, cutset) }, arguments[0], binding)
+}
+
+func func_trim_prefix(arguments []interface{}, binding Binding) (interface{}, EvaluationInfo, bool) {
+	return _trimaffix("trim_prefix", strings.TrimPrefix, arguments, binding)
+}
+
+func func_trim_suffix(arguments []interface{}, binding Binding) (interface{}, EvaluationInfo, bool) {
+	return _trimaffix("trim_suffix", strings.TrimSuffix, arguments, binding)
+}
+
+func func_trim_space(arguments []interface{}, binding Binding) (interface{}, EvaluationInfo, bool) {
+	info := DefaultInfo()
+
+	if len(arguments) != 1 {
+		return info.Error("trim_space takes exactly one argument")
+	}
+	return _trim("trim_space", strings.TrimSpace, arguments[0], binding)
+}
+
+func _trimaffix(name string, mod func(string, string) string, arguments []interface{}, binding Binding) (interface{}, EvaluationInfo, bool) {
+	info := DefaultInfo()
+
+	if len(arguments) != 2 {
+		return info.Error("%s takes exactly two arguments", name)
+	}
+
+	affix, ok := arguments[1].(string)
+	if !ok {
+		return info.Error("second argument of %s must be a string", name)
+	}
+	return _trim(name, func(s string) string { return mod(s, affix) }, arguments[0], binding)
+}
+
+func _trim(name string, mod func(string) string, arg interface{}, binding Binding) (interface{}, EvaluationInfo, bool) {
+	info := DefaultInfo()
+
 	var result interface{}
-	switch v := arguments[0].(type) {
+	switch v := arg.(type) {
 	case string:
-		result = strings.Trim(v, cutset)
+		result = mod(v)
 	case []yaml.Node:
 		list := make([]yaml.Node, len(v))
 		for i, e := range v {
@@ -32,12 +75,11 @@ func func_trim(arguments []interface{}, binding Binding) (interface{}, Evaluatio
 			if !ok {
 				return info.Error("list elements must be strings to be trimmed")
 			}
-			t = strings.Trim(t, cutset)
-			list[i] = NewNode(t, binding)
+			list[i] = NewNode(mod(t), binding)
 		}
 		result = list
 	default:
-		return info.Error("trim accepts only a string or list")
+		return info.Error("%s accepts only a string or list", name)
 	}
 
 	return result, info, true
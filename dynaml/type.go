@@ -4,11 +4,42 @@ import (
 	"github.com/mandelsoft/spiff/yaml"
 )
 
+func init() {
+	registerTypePredicate("is_string", "string")
+	registerTypePredicate("is_int", "int")
+	registerTypePredicate("is_float", "float")
+	registerTypePredicate("is_number", "int", "float")
+	registerTypePredicate("is_bool", "bool")
+	registerTypePredicate("is_list", "list")
+	registerTypePredicate("is_map", "map")
+	registerTypePredicate("is_nil", "nil")
+	registerTypePredicate("is_lambda", "lambda")
+	registerTypePredicate("is_template", "template")
+}
+
+// registerTypePredicate registers a function checking whether
+// the type of its argument is one of the given types.
+func registerTypePredicate(name string, types ...string) {
+	RegisterFunction(name, func(arguments []interface{}, binding Binding) (interface{}, EvaluationInfo, bool) {
+		info := DefaultInfo()
+		if len(arguments) != 1 {
+			return info.Error("%s requires one argument", name)
+		}
+		tn := ExpressionType(arguments[0])
+		for _, t := range types {
+			if tn == t {
+				return true, info, true
+			}
+		}
+		return false, info, true
+	})
+}
+
 func func_type(arguments []interface{}, binding Binding) (interface{}, EvaluationInfo, bool) {
 	info := DefaultInfo()
 
 	if len(arguments) != 1 {
-		info.Error("exactly one argument required for function 'type'")
+		return info.Error("exactly one argument required for function 'type'")
 	}
 
 	tn := ExpressionType(arguments[0])
@@ -40,7 +40,7 @@ func (e UnresolvedNodes) Issue(msgfmt string, args ...interface{}) (result yaml.
 		issue := node.Issue()
 		msg := issue.Issue
 		if msg != "" {
-			msg = tag(node) + msg
+			msg = tag(node) + msg + e.chainInfo(node)
 		}
 		if node.HasError() {
 			localError = true
@@ -58,7 +58,7 @@ func (e UnresolvedNodes) Issue(msgfmt string, args ...interface{}) (result yaml.
 		message := fmt.Sprintf(
 			format,
 			nv,
-			node.SourceName(),
+			yaml.Location(node),
 			strings.Join(node.Context, "."),
 			strings.Join(node.Path, "."),
 			msg,
@@ -88,7 +88,7 @@ func (e UnresolvedNodes) Error() string {
 		issue := node.Issue()
 		msg := issue.Issue
 		if msg != "" {
-			msg = "\t" + tag(node) + msg
+			msg = "\t" + tag(node) + msg + e.chainInfo(node)
 		}
 		nv := PrintableNodeValue(node)
 		switch nv.(type) {
@@ -102,7 +102,7 @@ func (e UnresolvedNodes) Error() string {
 			format,
 			message,
 			val,
-			node.SourceName(),
+			yaml.Location(node),
 			strings.Join(node.Context, "."),
 			strings.Join(node.Path, "."),
 			msg,
@@ -113,6 +113,73 @@ func (e UnresolvedNodes) Error() string {
 	return message
 }
 
+// ReferenceChain returns the chain of node paths traversed by following
+// the unresolved references starting at the given node, if this chain
+// leads into a reference cycle. Otherwise nil is returned.
+func (e UnresolvedNodes) ReferenceChain(node UnresolvedNode) []string {
+	visited := map[string]bool{}
+	chain := []string{}
+	for cur := &node; cur != nil; cur = e.referencedNode(*cur) {
+		p := strings.Join(cur.Context, ".")
+		chain = append(chain, p)
+		if visited[p] {
+			return chain
+		}
+		visited[p] = true
+	}
+	return nil
+}
+
+func (e UnresolvedNodes) chainInfo(node UnresolvedNode) string {
+	chain := e.ReferenceChain(node)
+	if chain == nil {
+		return ""
+	}
+	return " (reference chain: " + strings.Join(chain, " -> ") + ")"
+}
+
+// referencedNode determines the unresolved node a node waiting for an
+// unresolved reference depends on. The reference recorded for the issue
+// during the evaluation is looked up from the innermost scope of the node
+// to the document root.
+func (e UnresolvedNodes) referencedNode(node UnresolvedNode) *UnresolvedNode {
+	if node.HasError() || node.Failed() {
+		return nil
+	}
+	ref := node.Issue().Reference
+	if len(ref) == 0 {
+		return nil
+	}
+	if ref[0] == "" {
+		return e.findNode(ref[1:])
+	}
+	for i := len(node.Context) - 1; i >= 0; i-- {
+		if n := e.findNode(append(node.Context[:i:i], ref...)); n != nil {
+			return n
+		}
+	}
+	return nil
+}
+
+func (e UnresolvedNodes) findNode(path []string) *UnresolvedNode {
+	for i, n := range e.Nodes {
+		if reflect.DeepEqual(n.Context, path) {
+			return &e.Nodes[i]
+		}
+	}
+	for i, n := range e.Nodes {
+		if len(n.Context) > len(path) && reflect.DeepEqual(n.Context[:len(path)], path) {
+			return &e.Nodes[i]
+		}
+	}
+	for i, n := range e.Nodes {
+		if len(n.Context) < len(path) && reflect.DeepEqual(n.Context, path[:len(n.Context)]) {
+			return &e.Nodes[i]
+		}
+	}
+	return nil
+}
+
 func tag(node yaml.Node) string {
 	tag := " "
 	if !node.Failed() {
@@ -0,0 +1,60 @@
+package dynaml
+
+import (
+	"net/url"
+
+	"github.com/mandelsoft/spiff/yaml"
+)
+
+func init() {
+	RegisterFunction("urlencode", func_urlencode)
+	RegisterFunction("urldecode", func_urldecode)
+	RegisterFunction("query_encode", func_query_encode)
+}
+
+func func_urlencode(arguments []interface{}, binding Binding) (interface{}, EvaluationInfo, bool) {
+	info := DefaultInfo()
+
+	if len(arguments) != 1 {
+		return info.Error("urlencode takes exactly one argument")
+	}
+	str, ok := arguments[0].(string)
+	if !ok {
+		return info.Error("argument for urlencode must be a string")
+	}
+	return url.PathEscape(str), info, true
+}
+
+func func_urldecode(arguments []interface{}, binding Binding) (interface{}, EvaluationInfo, bool) {
+	info := DefaultInfo()
+
+	if len(arguments) != 1 {
+		return info.Error("urldecode takes exactly one argument")
+	}
+	str, ok := arguments[0].(string)
+	if !ok {
+		return info.Error("argument for urldecode must be a string")
+	}
+	result, err := url.PathUnescape(str)
+	if err != nil {
+		return info.Error("urldecode: %s", err)
+	}
+	return result, info, true
+}
+
+func func_query_encode(arguments []interface{}, binding Binding) (interface{}, EvaluationInfo, bool) {
+	info := DefaultInfo()
+
+	if len(arguments) != 1 {
+		return info.Error("query_encode takes exactly one argument")
+	}
+	m, ok := arguments[0].(map[string]yaml.Node)
+	if !ok {
+		return info.Error("argument for query_encode must be a map")
+	}
+	values, err := queryValues(m)
+	if err != nil {
+		return info.Error("query_encode: %s", err)
+	}
+	return values.Encode(), info, true
+}
@@ -8,6 +8,7 @@ const F_GenKey = "wggenkey"
 
 func init() {
 	RegisterFunction(F_GenKey, func_genkey)
+	DescribeFunction(F_GenKey, "0", "generate a wireguard private key")
 }
 
 func func_genkey(arguments []interface{}, binding Binding) (interface{}, EvaluationInfo, bool) {
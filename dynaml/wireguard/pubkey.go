@@ -8,6 +8,7 @@ const F_PubKey = "wgpublickey"
 
 func init() {
 	RegisterFunction(F_PubKey, func_pubkey)
+	DescribeFunction(F_PubKey, "1", "get the public key of a wireguard private key")
 }
 
 func func_pubkey(arguments []interface{}, binding Binding) (interface{}, EvaluationInfo, bool) {
@@ -0,0 +1,46 @@
+package dynaml
+
+import (
+	"github.com/mandelsoft/spiff/debug"
+	"github.com/mandelsoft/spiff/yaml"
+)
+
+func (e CallExpr) with(binding Binding) (interface{}, EvaluationInfo, bool) {
+	info := DefaultInfo()
+	if len(e.Arguments) != 2 {
+		return info.Error("with requires two arguments (defaults map and expression)")
+	}
+
+	resolved := true
+	value, info, ok := ResolveExpressionOrPushEvaluation(&e.Arguments[0], &resolved, nil, binding, false)
+	if !ok {
+		return nil, info, false
+	}
+	if !resolved {
+		return e, info, true
+	}
+
+	defaults, ok := value.(map[string]yaml.Node)
+	if !ok {
+		return info.Error("with: defaults must be a map, but found %s", ExpressionType(value))
+	}
+
+	// only names not resolvable in the actual binding are taken from
+	// the defaults, so the local scope must not shadow existing ones
+	local := map[string]yaml.Node{}
+	for k, v := range defaults {
+		if _, found := binding.FindReference([]string{k}); !found {
+			local[k] = v
+		}
+	}
+	debug.Debug("with: defaults %v\n", local)
+
+	result, infoe, ok := e.Arguments[1].Evaluate(binding.WithLocalScope(local), false)
+	if !ok {
+		return nil, infoe, false
+	}
+	if IsExpression(result) {
+		return e, info.Join(infoe), true
+	}
+	return result, info.Join(infoe), true
+}
@@ -19,6 +19,7 @@ const F_Cert = "x509cert"
 
 func init() {
 	RegisterFunction(F_Cert, func_x509cert)
+	DescribeFunction(F_Cert, "1", "create an x509 certificate")
 }
 
 //  one map argument with fields
@@ -17,6 +17,7 @@ const F_GenKey = "x509genkey"
 
 func init() {
 	RegisterFunction(F_GenKey, func_x509genkey)
+	DescribeFunction(F_GenKey, "0-1", "generate a private key")
 }
 
 // one optional argument
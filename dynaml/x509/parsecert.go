@@ -11,6 +11,7 @@ const F_ParseCert = "x509parsecert"
 
 func init() {
 	RegisterFunction(F_ParseCert, func_x509parsecert)
+	DescribeFunction(F_ParseCert, "1", "parse an x509 certificate")
 }
 
 func func_x509parsecert(arguments []interface{}, binding Binding) (interface{}, EvaluationInfo, bool) {
@@ -16,6 +16,7 @@ const F_PublicKey = "x509publickey"
 
 func init() {
 	RegisterFunction(F_PublicKey, func_x509publickey)
+	DescribeFunction(F_PublicKey, "1-2", "get the public key of a private key")
 }
 
 // one argument
@@ -13,24 +13,111 @@ type Options struct {
 	PreserveTemporary bool
 	// Partial will not treat unevaluated dynaml expressions as error, but keep it in the output.
 	Partial bool
+	// Provenance, if set, is filled with the source names of the leaf values of the
+	// final output by their field paths (see Provenance).
+	Provenance map[string]string
+	// MaxDepth limits the nesting depth of lambda calls and template instantiations,
+	// if the binding uses a State. Zero keeps the limit of the state (by default
+	// DefaultMaxDepth).
+	MaxDepth int
+	// CarryStream keeps the stream scoped tags defined by stubs for the
+	// processing of the subsequent stubs and the template. By default they
+	// are reset after every stub.
+	CarryStream bool
+	// Progress, if set, is called after every fixpoint iteration and
+	// after every processed stub (see Progress).
+	Progress func(Progress)
+}
+
+// Progress describes the processing progress reported to the Progress
+// callback of the Options.
+type Progress struct {
+	// Stub is the number of the stub in processing order (starting with 1),
+	// or 0 for the template.
+	Stub int
+	// Stubs is the total number of stubs to prepare.
+	Stubs int
+	// Iteration is the number of the last fixpoint iteration for the
+	// actual document, or 0 if the document is completely processed.
+	Iteration int
+	// Resolved is the number of resolved nodes after the iteration.
+	Resolved int
+	// Unresolved is the number of unresolved nodes after the iteration.
+	Unresolved int
+}
+
+// iterated provides the function reporting the fixpoint iterations
+// for the Progress callback.
+func (opts Options) iterated(stub, stubs int) func(int, yaml.Node) {
+	if opts.Progress == nil {
+		return nil
+	}
+	return func(iteration int, node yaml.Node) {
+		total := countNodes(node)
+		unresolved := len(dynaml.FindUnresolvedNodes(node))
+		opts.Progress(Progress{stub, stubs, iteration, total - unresolved, unresolved})
+	}
+}
+
+// countNodes counts the value nodes of a document.
+func countNodes(node yaml.Node) int {
+	if node == nil {
+		return 0
+	}
+	count := 1
+	switch v := node.Value().(type) {
+	case []yaml.Node:
+		for _, e := range v {
+			count += countNodes(e)
+		}
+	case map[string]yaml.Node:
+		for _, e := range v {
+			count += countNodes(e)
+		}
+	}
+	return count
 }
 
 func PrepareStubs(outer dynaml.Binding, partial bool, stubs ...yaml.Node) ([]yaml.Node, error) {
+	return PrepareStubsWithOptions(outer, Options{Partial: partial}, stubs...)
+}
+
+// PrepareStubsWithOptions processes the stubs from the last to the first one.
+// Only the options Partial, CarryStream and Progress are used.
+func PrepareStubsWithOptions(outer dynaml.Binding, opts Options, stubs ...yaml.Node) ([]yaml.Node, error) {
 	for i := len(stubs) - 1; i >= 0; i-- {
-		ResetStream(outer)
-		flowed, err := NestedFlow(outer, stubs[i], stubs[i+1:]...)
-		if !partial && err != nil {
-			return nil, err
+		if !opts.CarryStream {
+			ResetStream(outer)
+		}
+		flowed, err := nestedFlow(outer, opts.iterated(len(stubs)-i, len(stubs)), stubs[i], stubs[i+1:]...)
+		if !opts.Partial && err != nil {
+			return nil, NewErrors(err)
 		}
 
 		stubs[i] = Cleanup(flowed, discardLocal)
+		if opts.Progress != nil {
+			opts.Progress(Progress{Stub: len(stubs) - i, Stubs: len(stubs)})
+		}
+	}
+	if !opts.CarryStream {
+		ResetStream(outer)
 	}
-	ResetStream(outer)
 	return stubs, nil
 }
 
 func Apply(outer dynaml.Binding, template yaml.Node, prepared []yaml.Node, opts Options) (yaml.Node, error) {
-	result, err := NestedFlow(outer, template, prepared...)
+	if outer != nil {
+		if s, ok := outer.GetState().(*State); ok {
+			if opts.MaxDepth > 0 {
+				s.SetMaxDepth(opts.MaxDepth)
+			}
+			defer s.pushValueCache()()
+		}
+	}
+	result, err := nestedFlow(outer, opts.iterated(0, 0), template, prepared...)
+	if opts.Progress != nil {
+		opts.Progress(Progress{})
+	}
 	if err == nil {
 		if !opts.PreserveTemporary {
 			result = Cleanup(result, discardTemporary)
@@ -39,12 +126,17 @@ func Apply(outer dynaml.Binding, template yaml.Node, prepared []yaml.Node, opts
 			result = Cleanup(result, unescapeDynamlFunc(outer))
 		}
 		PushDocument(outer, result)
+		if opts.Provenance != nil {
+			for p, s := range Provenance(result) {
+				opts.Provenance[p] = s
+			}
+		}
 	}
-	return result, err
+	return result, NewErrors(err)
 }
 
 func Cascade(outer dynaml.Binding, template yaml.Node, opts Options, stubs ...yaml.Node) (yaml.Node, error) {
-	prepared, err := PrepareStubs(outer, opts.Partial, stubs...)
+	prepared, err := PrepareStubsWithOptions(outer, opts, stubs...)
 	if err != nil {
 		return nil, err
 	}
@@ -68,9 +160,13 @@ func discardTags(node yaml.Node) (yaml.Node, CleanupFunction) {
 
 func unescapeDynamlFunc(binding dynaml.Binding) CleanupFunction {
 	interpol := binding != nil && binding.GetState().InterpolationEnabled()
+	delims := yaml.DefaultDelimiters
+	if interpol {
+		delims = binding.GetState().InterpolationDelimiters()
+	}
 	var f CleanupFunction
 	f = func(node yaml.Node) (yaml.Node, CleanupFunction) {
-		return yaml.UnescapeDynaml(node, interpol), f
+		return yaml.UnescapeDynaml(node, interpol, delims), f
 	}
 	return f
 }
@@ -4,7 +4,9 @@ import (
 	. "github.com/onsi/ginkgo"
 	. "github.com/onsi/gomega"
 
+	"github.com/mandelsoft/spiff/dynaml"
 	"github.com/mandelsoft/spiff/features"
+	"github.com/mandelsoft/spiff/yaml"
 )
 
 var _ = Describe("Cascading YAML templates", func() {
@@ -1102,6 +1104,43 @@ types:
   string: string
   template: template
   undef: undef
+`)
+			Expect(source).To(CascadeAs(resolved))
+		})
+
+		It("checks types with predicates", func() {
+			source := parseYAML(`
+---
+temp:
+  <<: (( &template &temporary ))
+
+lambda: (( &temporary(|x|->x) ))
+
+checks:
+   type_of: (( type_of(1.0) ))
+   template: (( [is_template(.temp), is_map(.temp)] ))
+   lambda: (( [is_lambda(.lambda), is_lambda("x")] ))
+   int: (( [is_int(1), is_int(1.0), is_number(1)] ))
+   float: (( [is_float(1.0), is_float(1), is_number(1.0)] ))
+   string: (( [is_string("1"), is_string(1)] ))
+   bool: (( [is_bool(false), is_bool("true")] ))
+   list: (( [is_list([]), is_list({})] ))
+   map: (( [is_map({}), is_map([])] ))
+   nil: (( [is_nil(~), is_nil("")] ))
+`)
+			resolved := parseYAML(`
+---
+checks:
+  type_of: float
+  template: [true, false]
+  lambda: [true, false]
+  int: [true, false, true]
+  float: [true, false, true]
+  string: [true, false]
+  bool: [true, false]
+  list: [true, false]
+  map: [true, false]
+  nil: [true, false]
 `)
 			Expect(source).To(CascadeAs(resolved))
 		})
@@ -1373,4 +1412,95 @@ foo:
 			})
 		})
 	})
+
+	Describe("progress", func() {
+		It("reports the processing progress", func() {
+			source := parseYAML(`
+---
+foo: (( merge ))
+bar: (( foo ))
+`)
+			stub1 := parseYAML(`
+---
+foo: (( alice ))
+alice: 1
+`)
+			stub2 := parseYAML(`
+---
+alice: 2
+`)
+			var events []Progress
+			result, err := Cascade(nil, source, Options{Progress: func(p Progress) { events = append(events, p) }}, stub1, stub2)
+			Expect(err).To(Succeed())
+			Expect(result.Value().(map[string]yaml.Node)["bar"].Value()).To(Equal(int64(2)))
+
+			var stubs []Progress
+			iterations := 0
+			for _, e := range events {
+				if e.Iteration == 0 {
+					stubs = append(stubs, e)
+				} else {
+					iterations++
+				}
+			}
+			Expect(stubs).To(Equal([]Progress{{Stub: 1, Stubs: 2}, {Stub: 2, Stubs: 2}, {}}))
+			Expect(iterations).To(BeNumerically(">=", 3))
+			last := events[len(events)-2]
+			Expect(last.Stub).To(Equal(0))
+			Expect(last.Unresolved).To(Equal(0))
+			Expect(last.Resolved).To(Equal(3))
+		})
+	})
+
+	Describe("warnings", func() {
+		It("records non-fatal issues in the state", func() {
+			source, err := yaml.ParseWithPositions("test", []byte(`
+---
+foo: (( merge || "default" ))
+bar: (( merge || "other" ))
+hash: (( md5("alice") ))
+`))
+			Expect(err).To(Succeed())
+			stub := parseYAML(`
+---
+bar: stub
+`)
+			state := NewDefaultState()
+			result, err := Cascade(NewEnvironment(nil, "context", state), source, Options{}, stub)
+			Expect(err).To(Succeed())
+			Expect(result.Value().(map[string]yaml.Node)["foo"].Value()).To(Equal("default"))
+			Expect(state.Warnings()).To(Equal([]Warning{
+				{Path: "foo", Source: "test", Line: 3, Message: "no override found for 'foo', using default"},
+				{Path: "hash", Source: "test", Line: 5, Message: "function 'md5' is deprecated; use 'hash'"},
+			}))
+			Expect(state.ResetWarnings().Warnings()).To(BeEmpty())
+		})
+
+		It("reports deprecated functions once", func() {
+			source := parseYAML(`
+---
+a: (( old_func(1) ))
+b: (( old_func(2) + 1 ))
+c: (( md5("alice") ))
+`)
+			funcs := dynaml.NewFunctions()
+			funcs.RegisterFunction("old_func", func(arguments []interface{}, binding dynaml.Binding) (interface{}, dynaml.EvaluationInfo, bool) {
+				return arguments[0], dynaml.DefaultInfo(), true
+			})
+			funcs.DeprecateFunction("old_func", "new_func")
+			state := NewDefaultState().SetRegistry(dynaml.DefaultRegistry().WithFunctions(funcs))
+			result, err := Cascade(NewEnvironment(nil, "context", state), source, Options{})
+			Expect(err).To(Succeed())
+			Expect(result.Value().(map[string]yaml.Node)["b"].Value()).To(Equal(int64(3)))
+
+			messages := map[string]int{}
+			for _, w := range state.Warnings() {
+				messages[w.Message]++
+			}
+			Expect(messages).To(Equal(map[string]int{
+				"function 'old_func' is deprecated; use 'new_func'": 1,
+				"function 'md5' is deprecated; use 'hash'":          1,
+			}))
+		})
+	})
 })
@@ -0,0 +1,75 @@
+package flow
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+
+	"github.com/mandelsoft/spiff/dynaml"
+	"github.com/mandelsoft/spiff/yaml"
+)
+
+// SyntaxError describes a dynaml expression with a syntax error
+// found in a document. Node is the node containing the expression,
+// its position describes the location in the source document.
+type SyntaxError struct {
+	File       string
+	Node       yaml.Node
+	Path       []string
+	Expression string
+	Err        error
+}
+
+func (e *SyntaxError) Error() string {
+	location := e.File
+	if e.Node != nil {
+		location = yaml.Location(e.Node)
+	}
+	return fmt.Sprintf("%s: %s: (( %s )): %s", location, PathString(e.Path), e.Expression, e.Err)
+}
+
+// PathString formats a node path as used for error reporting.
+func PathString(path []string) string {
+	return strings.Replace(strings.Join(path, "."), ".[", "[", -1)
+}
+
+// CheckSyntax parses all dynaml expressions found in the given document
+// without evaluating them. It returns an error for every expression with
+// a syntax error. Expressions embedded in strings are detected by
+// the optionally given delimiters if interpolation is enabled.
+func CheckSyntax(root yaml.Node, interpolation bool, delims ...yaml.Delimiters) []error {
+	d := yaml.DefaultDelimiters
+	if len(delims) > 0 {
+		d = delims[0]
+	}
+	return checkSyntax(root, []string{}, interpolation, d, nil)
+}
+
+func checkSyntax(root yaml.Node, path []string, interpolation bool, delims yaml.Delimiters, errs []error) []error {
+	if root == nil {
+		return errs
+	}
+	switch v := root.Value().(type) {
+	case map[string]yaml.Node:
+		keys := make([]string, 0, len(v))
+		for k := range v {
+			keys = append(keys, k)
+		}
+		sort.Strings(keys)
+		for _, k := range keys {
+			errs = checkSyntax(v[k], append(path[:len(path):len(path)], k), interpolation, delims, errs)
+		}
+	case []yaml.Node:
+		for i, e := range v {
+			errs = checkSyntax(e, append(path[:len(path):len(path)], fmt.Sprintf("[%d]", i)), interpolation, delims, errs)
+		}
+	case string:
+		sub := yaml.EmbeddedDynaml(root, interpolation, delims)
+		if sub != nil {
+			if _, err := dynaml.Parse(*sub, path, path); err != nil {
+				errs = append(errs, &SyntaxError{root.SourceName(), root, path, strings.TrimSpace(*sub), err})
+			}
+		}
+	}
+	return errs
+}
@@ -0,0 +1,56 @@
+package flow
+
+import (
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+
+	"github.com/mandelsoft/spiff/yaml"
+)
+
+var _ = Describe("Checking syntax", func() {
+	It("accepts valid expressions", func() {
+		source := parseYAML(`
+---
+a: (( 1 + 2 ))
+b:
+  - (( a ))
+  - c: (( "x" ))
+`)
+		Expect(CheckSyntax(source, false)).To(BeEmpty())
+	})
+
+	It("reports syntax errors with path", func() {
+		source := parseYAML(`
+---
+a: (( 1 + ))
+b:
+  - (( a ))
+  - c: (( foo( ))
+`)
+		errs := CheckSyntax(source, false)
+		Expect(errs).To(HaveLen(2))
+		Expect(errs[0].Error()).To(Equal("test: a: (( 1 + )): parse error near symbol 5 - symbol 6: ' '"))
+		Expect(errs[1].Error()).To(Equal("test: b[1].c: (( foo( )): parse error near symbol 6 - symbol 7: ' '"))
+	})
+
+	It("reports the source location", func() {
+		source, err := yaml.ParseWithPositions("test.yml", []byte(`
+---
+a: 1
+b:
+  c: (( 1 + ))
+`))
+		Expect(err).NotTo(HaveOccurred())
+		errs := CheckSyntax(source, false)
+		Expect(errs).To(HaveLen(1))
+		Expect(errs[0].Error()).To(Equal("test.yml:5: b.c: (( 1 + )): parse error near symbol 5 - symbol 6: ' '"))
+	})
+
+	It("ignores escaped expressions", func() {
+		source := parseYAML(`
+---
+a: ((! 1 + ))
+`)
+		Expect(CheckSyntax(source, false)).To(BeEmpty())
+	})
+})
@@ -316,9 +316,15 @@ func (e *DefaultEnvironment) RedirectOverwrite(path []string) dynaml.Binding {
 }
 
 func (e *DefaultEnvironment) Flow(source yaml.Node, shouldOverride bool) (yaml.Node, dynaml.Status) {
+	return e.iterate(source, shouldOverride, nil)
+}
+
+// iterate flows the source until a fixpoint is reached. If given, the
+// iterated function is called with the result of every iteration.
+func (e *DefaultEnvironment) iterate(source yaml.Node, shouldOverride bool, iterated func(int, yaml.Node)) (yaml.Node, dynaml.Status) {
 	result := source
 
-	for {
+	for iteration := 1; ; iteration++ {
 		debug.Debug("@@{ loop:  %+v\n", result)
 		var env dynaml.Binding = e
 		if list, ok := source.Value().([]yaml.Node); ok {
@@ -332,6 +338,9 @@ func (e *DefaultEnvironment) Flow(source yaml.Node, shouldOverride bool) (yaml.N
 		debug.Debug("@@} --->   %+v\n", next)
 
 		next = Cleanup(next, updateBinding(next, env))
+		if iterated != nil {
+			iterated(iteration, next)
+		}
 		b := reflect.DeepEqual(result, next)
 		//b,r:=yaml.Equals(result, next,[]string{})
 		if b {
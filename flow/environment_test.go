@@ -1,6 +1,8 @@
 package flow
 
 import (
+	"os"
+
 	. "github.com/onsi/ginkgo"
 	. "github.com/onsi/gomega"
 
@@ -207,4 +209,29 @@ c: 4
 			})
 		})
 	})
+
+	Describe("environment bindings", func() {
+		BeforeEach(func() {
+			os.Setenv("SPIFF_TEST_ALICE", "25")
+			os.Setenv("SPIFF_TEST_BOB", "builder")
+		})
+
+		AfterEach(func() {
+			os.Unsetenv("SPIFF_TEST_ALICE")
+			os.Unsetenv("SPIFF_TEST_BOB")
+		})
+
+		It("provides the prefixed variables", func() {
+			bindings, err := NewDefaultState().EnvironmentBindings("SPIFF_TEST_")
+			Expect(err).To(Succeed())
+			Expect(bindings).To(HaveLen(2))
+			Expect(bindings["ALICE"].Value()).To(Equal("25"))
+			Expect(bindings["BOB"].Value()).To(Equal("builder"))
+		})
+
+		It("requires OS access", func() {
+			_, err := NewState("", MODE_FILE_ACCESS).EnvironmentBindings("SPIFF_TEST_")
+			Expect(err).To(MatchError("environment bindings require OS access"))
+		})
+	})
 })
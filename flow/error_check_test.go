@@ -3,6 +3,8 @@ package flow
 import (
 	. "github.com/onsi/ginkgo"
 	. "github.com/onsi/gomega"
+
+	"github.com/mandelsoft/spiff/yaml"
 )
 
 var _ = Describe("Reporting issues for unresolved nodes", func() {
@@ -146,7 +148,7 @@ node: (( "." a ))
 node: (( length( 5 ) ))
 `)
 		Expect(source).To(FlowToErr(
-			`	(( length(5) ))	in test	node	()	*invalid type for function length`,
+			`	(( length(5) ))	in test	node	()	*invalid type int for function length`,
 		))
 	})
 
@@ -219,4 +221,76 @@ node:
 	))	in test	node.<<	()	*parse error near line 2 symbol 6 - line 2 symbol 7: ' '`,
 		))
 	})
+
+	It("reports the reference chain for self references", func() {
+		source := parseYAML(`
+---
+node: (( node ))
+`)
+		Expect(source).To(FlowToErr(
+			`	(( node ))	in test	node	()	@'node' unresolved (reference chain: node -> node)`,
+		))
+	})
+
+	It("reports the reference chain for reference cycles", func() {
+		source := parseYAML(`
+---
+a:
+  b: (( c.d ))
+c:
+  d: (( x ))
+  x: (( a.b ))
+ok: (( a.b ))
+`)
+		_, err := Flow(source)
+		Expect(err).To(HaveOccurred())
+		Expect(err.Error()).To(ContainSubstring(`@'c.d' unresolved (reference chain: a.b -> c.d -> c.x -> a.b)`))
+		Expect(err.Error()).To(ContainSubstring(`@'x' unresolved (reference chain: c.d -> c.x -> a.b -> c.d)`))
+		Expect(err.Error()).To(ContainSubstring(`@'a.b' unresolved (reference chain: ok -> a.b -> c.d -> c.x -> a.b)`))
+	})
+
+	It("reports the reference chain for root references", func() {
+		source := parseYAML(`
+---
+a:
+  b: (( .c ))
+c: (( a.b ))
+`)
+		_, err := Flow(source)
+		Expect(err).To(HaveOccurred())
+		Expect(err.Error()).To(ContainSubstring(`@'.c' unresolved (reference chain: a.b -> c -> a.b)`))
+	})
+
+	It("reports no reference chain for failed dependencies", func() {
+		source := parseYAML(`
+---
+a: (( b ))
+b: (( c ))
+`)
+		Expect(source).To(FlowToErr(
+			`	(( c ))	in test	b	()	*'c' not found
+	(( b ))	in test	a	()	-'b' unresolved`,
+		))
+	})
+
+	It("reports source positions of the defining stub", func() {
+		source, err := yaml.ParseWithPositions("template.yml", []byte(`
+---
+foo:
+  bar: (( merge ))
+other: (( 1 + 1 ))
+`))
+		Expect(err).NotTo(HaveOccurred())
+		stub, err := yaml.ParseWithPositions("stub.yml", []byte(`
+---
+
+foo:
+  bar: (( nothere ))
+`))
+		Expect(err).NotTo(HaveOccurred())
+		Expect(source).To(FlowToErr(
+			`	(( nothere ))	in stub.yml:5	foo.bar	()	*'nothere' not found`,
+			stub,
+		))
+	})
 })
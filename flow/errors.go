@@ -0,0 +1,146 @@
+package flow
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/mandelsoft/spiff/dynaml"
+	"github.com/mandelsoft/spiff/yaml"
+)
+
+// NodeError is implemented by the typed errors describing a node
+// of a processed document that could not be resolved.
+type NodeError interface {
+	error
+	// Path returns the field path of the node in the processed document.
+	Path() string
+	// Source returns the name of the source document of the node.
+	Source() string
+	// Line returns the line of the node in its source, or 0 if unknown.
+	Line() int
+}
+
+type nodeError struct {
+	Node dynaml.UnresolvedNode
+}
+
+func (e *nodeError) Path() string {
+	return PathString(e.Node.Context)
+}
+
+func (e *nodeError) Source() string {
+	return e.Node.SourceName()
+}
+
+func (e *nodeError) Line() int {
+	return e.Node.Position().Line
+}
+
+func (e *nodeError) location() string {
+	return fmt.Sprintf("%s: %s", yaml.Location(e.Node), e.Path())
+}
+
+// ParseError describes a node with a dynaml expression
+// that cannot be parsed.
+type ParseError struct {
+	nodeError
+	Expression string
+	Err        error
+}
+
+func (e *ParseError) Error() string {
+	return fmt.Sprintf("%s: (( %s )): %s", e.location(), e.Expression, e.Err)
+}
+
+// EvaluationError describes a node whose evaluation failed
+// or stays unresolved.
+type EvaluationError struct {
+	nodeError
+	Message string
+}
+
+func (e *EvaluationError) Error() string {
+	return fmt.Sprintf("%s: %s", e.location(), e.Message)
+}
+
+// CycleError describes a node waiting for a reference cycle.
+// Chain contains the paths of the nodes traversed by following
+// the references, starting at the node and ending with the first
+// path repeated.
+type CycleError struct {
+	nodeError
+	Chain []string
+}
+
+func (e *CycleError) Error() string {
+	return fmt.Sprintf("%s: reference cycle: %s", e.location(), strings.Join(e.Chain, " -> "))
+}
+
+// Warning describes a non-fatal issue detected while evaluating
+// a node of a processed document, for example the usage of
+// a deprecated function.
+type Warning struct {
+	// Path is the field path of the node in the processed document.
+	Path string
+	// Source is the name of the source document of the node.
+	Source string
+	// Line is the line of the node in its source, or 0 if unknown.
+	Line int
+	// Message describes the issue.
+	Message string
+}
+
+func (w Warning) String() string {
+	if w.Line > 0 {
+		return fmt.Sprintf("%s:%d: %s: %s", w.Source, w.Line, w.Path, w.Message)
+	}
+	return fmt.Sprintf("%s: %s: %s", w.Source, w.Path, w.Message)
+}
+
+// Errors is the error returned by Apply and Cascade for documents
+// with unresolved nodes. Besides the unresolved nodes it provides a
+// typed error for every node (see ParseError, EvaluationError and
+// CycleError).
+type Errors struct {
+	dynaml.UnresolvedNodes
+	Errors []NodeError
+}
+
+// Unwrap returns the original dynaml.UnresolvedNodes error, so that
+// callers may still check for it with errors.As.
+func (e Errors) Unwrap() error {
+	return e.UnresolvedNodes
+}
+
+// NewErrors returns the typed errors for a processing error.
+// Errors other than dynaml.UnresolvedNodes are returned unchanged.
+func NewErrors(err error) error {
+	unresolved, ok := err.(dynaml.UnresolvedNodes)
+	if !ok {
+		return err
+	}
+	errs := Errors{UnresolvedNodes: unresolved}
+	for _, n := range unresolved.Nodes {
+		errs.Errors = append(errs.Errors, newNodeError(unresolved, n))
+	}
+	return errs
+}
+
+func newNodeError(unresolved dynaml.UnresolvedNodes, n dynaml.UnresolvedNode) NodeError {
+	base := nodeError{n}
+	if _, ok := n.Value().(string); ok {
+		if sub := yaml.EmbeddedDynaml(n, true); sub != nil {
+			if _, err := dynaml.Parse(*sub, nil, nil); err != nil {
+				return &ParseError{base, strings.TrimSpace(*sub), err}
+			}
+		}
+	}
+	if chain := unresolved.ReferenceChain(n); chain != nil {
+		return &CycleError{base, chain}
+	}
+	msg := n.Issue().Issue
+	if msg == "" {
+		msg = "unresolved"
+	}
+	return &EvaluationError{base, msg}
+}
@@ -0,0 +1,143 @@
+package flow
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/mandelsoft/spiff/dynaml"
+	"github.com/mandelsoft/spiff/features"
+	"github.com/mandelsoft/spiff/yaml"
+)
+
+// Explanation describes the provenance of a field of a processed document.
+type Explanation struct {
+	Path       []string
+	Value      yaml.Node
+	Origin     string
+	Expression string
+	References []ExplainedReference
+}
+
+// ExplainedReference describes the value of a node referenced by
+// the expression of an explained field.
+type ExplainedReference struct {
+	Reference []string
+	Path      []string
+	Value     yaml.Node
+}
+
+// Explain determines how the field with the given path in the processed
+// document result was computed from the template and the stubs it was
+// processed with.
+func Explain(result yaml.Node, template yaml.Node, stubs []yaml.Node, path []string) (*Explanation, error) {
+	value, ok := yaml.FindR(true, result, features.FeatureFlags{}, path...)
+	if !ok {
+		return nil, fmt.Errorf("field %q not found", PathString(path))
+	}
+	e := &Explanation{Path: path, Value: value}
+
+	var expr *string
+	orig, found := yaml.FindR(true, template, features.FeatureFlags{}, path...)
+	if found {
+		expr = yaml.EmbeddedDynaml(orig, false)
+	}
+	source := value.SourceName()
+	switch {
+	case source == template.SourceName() || source == "":
+		if expr != nil {
+			e.Origin = "template expression"
+		} else {
+			e.Origin = "template value"
+		}
+	case found && expr != nil:
+		e.Origin = fmt.Sprintf("merged from stub %s by template expression", source)
+	case found:
+		e.Origin = fmt.Sprintf("stub %s overrides template value", source)
+	default:
+		e.Origin = fmt.Sprintf("taken from stub %s", source)
+	}
+	if source != template.SourceName() {
+		for _, stub := range stubs {
+			if stub != nil && stub.SourceName() == source {
+				if n, ok := yaml.FindR(true, stub, features.FeatureFlags{}, path...); ok {
+					if sub := yaml.EmbeddedDynaml(n, false); sub != nil {
+						expr = sub
+					}
+				}
+				break
+			}
+		}
+	}
+
+	if expr != nil {
+		e.Expression = "(( " + strings.TrimSpace(*expr) + " ))"
+		parsed, err := dynaml.Parse(*expr, path, path)
+		if err == nil {
+			for _, ref := range dynaml.Dependencies(parsed) {
+				if ref[len(ref)-1] == dynaml.DynamicDependency {
+					ref = ref[:len(ref)-1]
+				}
+				e.References = append(e.References, explainReference(result, path, ref))
+			}
+		}
+	}
+	return e, nil
+}
+
+// explainReference looks up a reference from the innermost scope
+// of the referencing node to the document root.
+func explainReference(result yaml.Node, path []string, ref []string) ExplainedReference {
+	r := ExplainedReference{Reference: ref}
+	switch ref[0] {
+	case yaml.ROOT, yaml.DOCNODE:
+		ref = ref[1:]
+		if n, ok := yaml.FindR(true, result, features.FeatureFlags{}, ref...); ok {
+			r.Path, r.Value = ref, n
+		}
+		return r
+	}
+	for i := len(path) - 1; i >= 0; i-- {
+		p := append(path[:i:i], ref...)
+		if n, ok := yaml.FindR(true, result, features.FeatureFlags{}, p...); ok {
+			r.Path, r.Value = p, n
+			break
+		}
+	}
+	return r
+}
+
+func (e *Explanation) String() string {
+	s := fmt.Sprintf("explanation for %s:\n", PathString(e.Path))
+	s += fmt.Sprintf("  value:      %s\n", explainValue(e.Value))
+	s += fmt.Sprintf("  defined in: %s\n", yaml.Location(e.Value))
+	s += fmt.Sprintf("  origin:     %s\n", e.Origin)
+	if e.Expression != "" {
+		s += fmt.Sprintf("  expression: %s\n", e.Expression)
+	}
+	if len(e.References) > 0 {
+		s += "  references:\n"
+		for _, r := range e.References {
+			if r.Value == nil {
+				s += fmt.Sprintf("    %s: not found\n", PathString(r.Reference))
+			} else {
+				s += fmt.Sprintf("    %s: %s (%s in %s)\n", PathString(r.Reference), explainValue(r.Value), PathString(r.Path), yaml.Location(r.Value))
+			}
+		}
+	}
+	return s
+}
+
+func explainValue(node yaml.Node) string {
+	switch v := node.Value().(type) {
+	case map[string]yaml.Node:
+		return "<map>"
+	case []yaml.Node:
+		return "<list>"
+	case string:
+		return fmt.Sprintf("%q", v)
+	case nil:
+		return "~"
+	default:
+		return fmt.Sprintf("%v", v)
+	}
+}
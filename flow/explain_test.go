@@ -0,0 +1,71 @@
+package flow
+
+import (
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+
+	"github.com/mandelsoft/spiff/yaml"
+)
+
+var _ = Describe("Explaining fields", func() {
+	var template, stub yaml.Node
+	var result yaml.Node
+
+	BeforeEach(func() {
+		var err error
+		template, err = yaml.ParseWithPositions("template.yml", []byte(`
+---
+values:
+  base: 2
+spec:
+  factor: 3
+  replicas: (( values.base * factor ))
+  image: nginx
+  name: (( merge ))
+`))
+		Expect(err).NotTo(HaveOccurred())
+		stub, err = yaml.ParseWithPositions("stub.yml", []byte(`
+---
+spec:
+  image: custom
+  name: web
+`))
+		Expect(err).NotTo(HaveOccurred())
+		result, err = Flow(template, stub)
+		Expect(err).NotTo(HaveOccurred())
+	})
+
+	It("explains template expressions with their references", func() {
+		e, err := Explain(result, template, []yaml.Node{stub}, []string{"spec", "replicas"})
+		Expect(err).NotTo(HaveOccurred())
+		Expect(e.String()).To(Equal(`explanation for spec.replicas:
+  value:      6
+  defined in: template.yml:7
+  origin:     template expression
+  expression: (( values.base * factor ))
+  references:
+    values.base: 2 (values.base in template.yml:4)
+    factor: 3 (spec.factor in template.yml:6)
+`))
+	})
+
+	It("explains stub overrides", func() {
+		e, err := Explain(result, template, []yaml.Node{stub}, []string{"spec", "image"})
+		Expect(err).NotTo(HaveOccurred())
+		Expect(e.Origin).To(Equal("stub stub.yml overrides template value"))
+		Expect(yaml.Location(e.Value)).To(Equal("stub.yml:4"))
+	})
+
+	It("explains merges", func() {
+		e, err := Explain(result, template, []yaml.Node{stub}, []string{"spec", "name"})
+		Expect(err).NotTo(HaveOccurred())
+		Expect(e.Origin).To(Equal("merged from stub stub.yml by template expression"))
+		Expect(e.Expression).To(Equal("(( merge ))"))
+		Expect(yaml.Location(e.Value)).To(Equal("stub.yml:5"))
+	})
+
+	It("fails for unknown fields", func() {
+		_, err := Explain(result, template, []yaml.Node{stub}, []string{"spec", "unknown"})
+		Expect(err).To(MatchError(`field "spec.unknown" not found`))
+	})
+})
@@ -3,11 +3,13 @@ package flow
 import (
 	"fmt"
 	"strings"
+	"time"
 
 	"github.com/mandelsoft/spiff/debug"
 	"github.com/mandelsoft/spiff/dynaml"
 	"github.com/mandelsoft/spiff/yaml"
 
+	_ "github.com/mandelsoft/spiff/dynaml/jwt"
 	_ "github.com/mandelsoft/spiff/dynaml/passwd"
 	_ "github.com/mandelsoft/spiff/dynaml/semver"
 	_ "github.com/mandelsoft/spiff/dynaml/wireguard"
@@ -19,9 +21,15 @@ func Flow(source yaml.Node, stubs ...yaml.Node) (yaml.Node, error) {
 }
 
 func NestedFlow(outer dynaml.Binding, source yaml.Node, stubs ...yaml.Node) (yaml.Node, error) {
-	env := NewNestedEnvironment(stubs, source.SourceName(), outer)
+	return nestedFlow(outer, nil, source, stubs...)
+}
+
+// nestedFlow works like NestedFlow, but reports the result of every
+// fixpoint iteration to the given function.
+func nestedFlow(outer dynaml.Binding, iterated func(int, yaml.Node), source yaml.Node, stubs ...yaml.Node) (yaml.Node, error) {
+	env := NewNestedEnvironment(stubs, source.SourceName(), outer).(*DefaultEnvironment)
 	defer CleanupEnvironment(env)
-	return env.Flow(source, true)
+	return env.iterate(source, true, iterated)
 }
 
 func get_inherited_flags(env dynaml.Binding) (yaml.NodeFlags, yaml.Node) {
@@ -133,7 +141,7 @@ func _flow(root yaml.Node, env dynaml.Binding, shouldOverride, enforceTemplate b
 				}
 				flags |= m.GetFlags()
 			} else {
-				eval, info, ok = val.Evaluate(env, false)
+				eval, info, ok = evaluate(val, env)
 				if err := info.Cleanup(); err != nil {
 					info.SetError("%s", err)
 					eval = nil
@@ -166,8 +174,10 @@ func _flow(root yaml.Node, env dynaml.Binding, shouldOverride, enforceTemplate b
 					return root
 				}
 			} else {
+				position := root.Position()
 				if info.SourceName() != "" {
 					source = info.SourceName()
+					position = info.Position
 				}
 				tag := root.GetAnnotation().Tag()
 				var result yaml.Node
@@ -176,12 +186,16 @@ func _flow(root yaml.Node, env dynaml.Binding, shouldOverride, enforceTemplate b
 				} else {
 					result = yaml.NewNode(eval, source)
 				}
+				result = yaml.PositionedNode(result, position)
 				_, ok = eval.(string)
 				if ok {
 					// map result to potential expression
 					result, _ = FlowString(result, env)
 				}
 				_, expr := result.Value().(dynaml.Expression)
+				if !expr {
+					addWarnings(env, root, info)
+				}
 
 				if len(info.Issue.Issue) != 0 {
 					result = dynaml.IssueNode(env, true, result, false, info.Failed, info.Issue)
@@ -382,7 +396,7 @@ func flowMap(root yaml.Node, env dynaml.Binding, shouldOverride, template bool)
 			}
 			// still ignore non dynaml value (might be strange but compatible)
 			replace = base.ReplaceFlag()
-			parseError := yaml.EmbeddedDynaml(base, env.GetState().InterpolationEnabled()) != nil
+			parseError := embeddedDynaml(base, env) != nil
 			if !ok && base.Value() != nil && !parseError {
 				err = fmt.Errorf("require map value for '<<' insert, found '%s'", dynaml.ExpressionType(base.Value()))
 			}
@@ -563,9 +577,16 @@ func flowList(root yaml.Node, env dynaml.Binding, template bool) yaml.Node {
 	return root
 }
 
+// embeddedDynaml returns the dynaml expression of a node according to
+// the interpolation settings of the processing state.
+func embeddedDynaml(root yaml.Node, env dynaml.Binding) *string {
+	state := env.GetState()
+	return yaml.EmbeddedDynaml(root, state.InterpolationEnabled(), state.InterpolationDelimiters())
+}
+
 func FlowString(root yaml.Node, env dynaml.Binding) (yaml.Node, error) {
 
-	sub := yaml.EmbeddedDynaml(root, env.GetState().InterpolationEnabled())
+	sub := embeddedDynaml(root, env)
 	if sub == nil {
 		return root, nil
 	}
@@ -681,7 +702,7 @@ func processMerges(orig yaml.Node, root []yaml.Node, env dynaml.Binding, templat
 						spliced = append(spliced, inlineNew...)
 					}
 				}
-				if ok || result.Value() == nil || yaml.EmbeddedDynaml(result, env.GetState().InterpolationEnabled()) == nil {
+				if ok || result.Value() == nil || embeddedDynaml(result, env) == nil {
 					// still ignore non dynaml value (might be strange but compatible)
 					redirectPath = result.RedirectPath()
 					if result.Merged() {
@@ -800,6 +821,53 @@ func newEntries(a []yaml.Node, b []yaml.Node, keyName string) []yaml.Node {
 	return added
 }
 
+func evaluate(expr dynaml.Expression, env dynaml.Binding) (interface{}, dynaml.EvaluationInfo, bool) {
+	tracer := env.GetState().GetTracer()
+	profiler := env.GetState().GetProfiler()
+	if tracer == nil && profiler == nil {
+		return expr.Evaluate(env, false)
+	}
+	if tracer != nil {
+		tracer.Enter(env.Path(), expr)
+	}
+	if profiler != nil {
+		profiler.Enter()
+		defer profiler.Leave(env.Path(), time.Now())
+	}
+	eval, info, ok := expr.Evaluate(env, false)
+	if tracer != nil {
+		tracer.Leave(env.Path(), expr, eval, info, ok)
+	}
+	return eval, info, ok
+}
+
+// addWarnings records the warnings and the usage of deprecated
+// functions of a successfully evaluated node in the state of the binding.
+func addWarnings(env dynaml.Binding, node yaml.Node, info dynaml.EvaluationInfo) {
+	if len(info.Warnings) == 0 && len(info.Deprecated) == 0 {
+		return
+	}
+	state, ok := env.GetState().(*State)
+	if !ok {
+		return
+	}
+	warning := func(msg string) Warning {
+		return Warning{
+			Path:    PathString(env.Path()),
+			Source:  node.SourceName(),
+			Line:    node.Position().Line,
+			Message: msg,
+		}
+	}
+	for _, w := range info.Warnings {
+		state.AddWarning(warning(w.Issue))
+	}
+	for _, name := range info.Deprecated {
+		replacement, _ := state.GetRegistry().LookupDeprecation(name)
+		state.AddDeprecation(name, warning(fmt.Sprintf("function '%s' is deprecated; use '%s'", name, replacement)))
+	}
+}
+
 func updateNode(node yaml.Node, flags yaml.NodeFlags, tag string) yaml.Node {
 	if (flags | node.Flags()) != node.Flags() {
 		node = yaml.AddFlags(node, flags)
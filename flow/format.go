@@ -0,0 +1,89 @@
+package flow
+
+import (
+	"fmt"
+	"reflect"
+	"strings"
+
+	"github.com/mandelsoft/spiff/dynaml"
+	"github.com/mandelsoft/spiff/yaml"
+)
+
+// FormatExpression returns the canonical representation of the given
+// dynaml expression (without the surrounding brackets). Only the spacing
+// is normalized, literals are kept as written. If the expression
+// cannot be parsed, or its canonical representation does not parse to
+// the same expression, the original text is returned unchanged together
+// with false.
+func FormatExpression(expr string, path []string) (string, bool) {
+	parsed, err := dynaml.Parse(expr, path, path)
+	if err != nil {
+		return expr, false
+	}
+	formatted, ok := keepLiterals(expr, strings.TrimSpace(fmt.Sprintf("%s", parsed)))
+	if !ok {
+		return expr, false
+	}
+	check, err := dynaml.Parse(formatted, path, path)
+	if err != nil || !reflect.DeepEqual(parsed, check) {
+		return expr, false
+	}
+	return formatted, true
+}
+
+// keepLiterals replaces the literals of the formatted expression by the
+// literals as written in the original expression.
+func keepLiterals(expr, formatted string) (string, bool) {
+	orig, err := dynaml.Literals(expr)
+	if err != nil {
+		return "", false
+	}
+	canon, err := dynaml.Literals(formatted)
+	if err != nil || len(orig) != len(canon) {
+		return "", false
+	}
+	runes := []rune(formatted)
+	result := ""
+	last := 0
+	for i, l := range canon {
+		result += string(runes[last:l.Begin]) + orig[i].Text
+		last = l.End
+	}
+	return result + string(runes[last:]), true
+}
+
+// Format returns the given document with all dynaml expressions
+// rendered in their canonical form. The document is not evaluated,
+// escaped expressions and interpolations are kept as they are.
+func Format(root yaml.Node) yaml.Node {
+	return format(root, []string{})
+}
+
+func format(root yaml.Node, path []string) yaml.Node {
+	if root == nil {
+		return root
+	}
+	switch v := root.Value().(type) {
+	case map[string]yaml.Node:
+		result := map[string]yaml.Node{}
+		for k, e := range v {
+			result[k] = format(e, append(path[:len(path):len(path)], k))
+		}
+		return yaml.SubstituteNode(result, root)
+	case []yaml.Node:
+		result := make([]yaml.Node, len(v))
+		for i, e := range v {
+			result[i] = format(e, append(path[:len(path):len(path)], fmt.Sprintf("[%d]", i)))
+		}
+		return yaml.SubstituteNode(result, root)
+	case string:
+		if sub := yaml.EmbeddedDynaml(root, false); sub != nil {
+			if formatted, ok := FormatExpression(*sub, path); ok {
+				if formatted = "(( " + formatted + " ))"; formatted != v {
+					return yaml.SubstituteNode(formatted, root)
+				}
+			}
+		}
+	}
+	return root
+}
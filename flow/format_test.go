@@ -0,0 +1,58 @@
+package flow
+
+import (
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+
+	"github.com/mandelsoft/spiff/legacy/candiedyaml"
+)
+
+var _ = Describe("Formatting", func() {
+	It("renders expressions with canonical spacing", func() {
+		source := parseYAML(`
+---
+a: ((  x  ||  y ))
+b:
+  - (( [1,2]   [3] ))
+  - (( {"a"=1} ))
+`)
+		data, err := candiedyaml.Marshal(Format(source))
+		Expect(err).NotTo(HaveOccurred())
+		Expect(string(data)).To(Equal(`a: (( x || y ))
+b:
+- (( [1, 2] [3] ))
+- (( { "a" = 1 } ))
+`))
+	})
+
+	It("keeps literals as written", func() {
+		source := parseYAML(`
+---
+a: ((  0o755  +  1_000 ))
+b: (( ` + "`raw\\n`" + `   "esc\"aped" ))
+c: (( 1.5e3 ~ nil  ~~ 0x1F ))
+`)
+		data, err := candiedyaml.Marshal(Format(source))
+		Expect(err).NotTo(HaveOccurred())
+		Expect(string(data)).To(Equal(`a: (( 0o755 + 1_000 ))
+b: (( ` + "`raw\\n`" + ` "esc\"aped" ))
+c: (( 1.5e3 ~ nil ~~ 0x1F ))
+`))
+	})
+
+	It("keeps expressions without equivalent canonical form", func() {
+		formatted, ok := FormatExpression("merge replace", nil)
+		Expect(ok).To(BeFalse())
+		Expect(formatted).To(Equal("merge replace"))
+	})
+
+	It("keeps unparseable and escaped expressions", func() {
+		source := parseYAML(`
+---
+a: ((broken(
+b: ((! keep  this ))
+c: plain
+`)
+		Expect(Format(source)).To(Equal(source))
+	})
+})
@@ -0,0 +1,34 @@
+package flow
+
+import (
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+
+	"github.com/mandelsoft/spiff/dynaml"
+)
+
+var _ = Describe("Function list", func() {
+	It("describes all functions", func() {
+		for _, i := range dynaml.ListFunctions(nil) {
+			Expect(i.Arity).NotTo(Equal("?"), i.Name)
+			Expect(i.Description).NotTo(BeEmpty(), i.Name)
+		}
+	})
+
+	It("lists additional functions", func() {
+		funcs := dynaml.NewFunctions()
+		funcs.RegisterFunction("my_func", nil)
+		infos := dynaml.ListFunctions(funcs)
+		Expect(infos).To(ContainElement(dynaml.FunctionInfo{Name: "my_func", Arity: "?"}))
+		Expect(infos).To(ContainElement(dynaml.FunctionInfo{Name: "join", Arity: "1+", Description: "join strings and lists with a separator"}))
+	})
+
+	It("lists deprecated functions", func() {
+		funcs := dynaml.NewFunctions()
+		funcs.RegisterFunction("old_func", nil)
+		funcs.DeprecateFunction("old_func", "new_func")
+		infos := dynaml.ListFunctions(funcs)
+		Expect(infos).To(ContainElement(dynaml.FunctionInfo{Name: "old_func", Arity: "?", Deprecated: "new_func"}))
+		Expect(infos).To(ContainElement(dynaml.FunctionInfo{Name: "md5", Arity: "1", Description: "calculate the md5 hash of a string", Deprecated: "hash"}))
+	})
+})
@@ -0,0 +1,194 @@
+package flow
+
+import (
+	"fmt"
+	"io"
+	"sort"
+	"strings"
+
+	"github.com/mandelsoft/spiff/dynaml"
+	"github.com/mandelsoft/spiff/yaml"
+)
+
+// GraphEdge describes a dependency of a field on another one.
+// Cycle is set, if the edge is part of a dependency cycle.
+type GraphEdge struct {
+	From  string
+	To    string
+	Cycle bool
+}
+
+// Graph is the dependency graph of the dynaml expressions of a document.
+// Nodes are the (dot separated) paths of the fields, edges lead from a
+// field to the fields it depends on.
+type Graph struct {
+	Nodes  []string
+	Edges  []GraphEdge
+	Cycles map[string]bool
+}
+
+// DependencyGraph determines the dependency graph of the given template
+// merged with the given stubs. Fields defined by a stub are taken as plain
+// values and therefore have no dependencies. Template definitions are not
+// analysed, because their expressions are only evaluated in the context
+// of an instantiation. References are resolved according to the lexical
+// scoping of dynaml references.
+func DependencyGraph(template yaml.Node, stubs ...yaml.Node) *Graph {
+	deps := map[string][]string{}
+	nodes := map[string]bool{}
+
+	yaml.Walk(template, func(path []string, n yaml.Node) error {
+		if n == nil {
+			return nil
+		}
+		if m, ok := n.Value().(map[string]yaml.Node); ok {
+			if isTemplateDefinition(m) {
+				return yaml.SkipNode
+			}
+			return nil
+		}
+		sub := yaml.EmbeddedDynaml(n, false)
+		if sub == nil || len(path) == 0 || definedByStub(path, stubs) {
+			return nil
+		}
+		expr, err := dynaml.Parse(*sub, path, path)
+		if err != nil {
+			return nil
+		}
+		from := strings.Join(path, ".")
+		nodes[from] = true
+		for _, ref := range dynaml.Dependencies(expr) {
+			to := strings.Join(resolveDependency(template, path, ref), ".")
+			nodes[to] = true
+			deps[from] = append(deps[from], to)
+		}
+		return nil
+	})
+
+	g := &Graph{Cycles: cycles(deps)}
+	for n := range nodes {
+		g.Nodes = append(g.Nodes, n)
+	}
+	sort.Strings(g.Nodes)
+	for _, from := range g.Nodes {
+		for _, to := range deps[from] {
+			g.Edges = append(g.Edges, GraphEdge{from, to, reaches(deps, to, from)})
+		}
+	}
+	return g
+}
+
+func isTemplateDefinition(m map[string]yaml.Node) bool {
+	for _, k := range []string{"<<", "<<<"} {
+		if n := m[k]; n != nil {
+			if sub := yaml.EmbeddedDynaml(n, false); sub != nil && strings.Contains(*sub, "&template") {
+				return true
+			}
+		}
+	}
+	return false
+}
+
+func definedByStub(path []string, stubs []yaml.Node) bool {
+	for _, stub := range stubs {
+		if _, ok := yaml.FindR(true, stub, nil, path...); ok {
+			return true
+		}
+	}
+	return false
+}
+
+// resolveDependency resolves a reference used at the given path by
+// searching the enclosing scopes from the innermost one.
+func resolveDependency(root yaml.Node, path []string, ref []string) []string {
+	for i := len(path) - 1; i >= 0; i-- {
+		candidate := append(path[:i:i], ref...)
+		if _, ok := yaml.FindR(true, root, nil, stripDynamic(candidate)...); ok {
+			return candidate
+		}
+	}
+	return ref
+}
+
+func stripDynamic(path []string) []string {
+	if len(path) > 0 && path[len(path)-1] == dynaml.DynamicDependency {
+		return path[:len(path)-1]
+	}
+	return path
+}
+
+// cycles determines all nodes being part of a dependency cycle.
+func cycles(deps map[string][]string) map[string]bool {
+	result := map[string]bool{}
+	for n := range deps {
+		if reaches(deps, n, n) {
+			result[n] = true
+		}
+	}
+	return result
+}
+
+// reaches checks whether the node to is reachable from the node from
+// by following at least one edge.
+func reaches(deps map[string][]string, from, to string) bool {
+	visited := map[string]bool{}
+	stack := append([]string{}, deps[from]...)
+	for len(stack) > 0 {
+		n := stack[len(stack)-1]
+		stack = stack[:len(stack)-1]
+		if n == to {
+			return true
+		}
+		if !visited[n] {
+			visited[n] = true
+			stack = append(stack, deps[n]...)
+		}
+	}
+	return false
+}
+
+// WriteDot writes the graph in Graphviz DOT format. Nodes and edges
+// involved in cycles are colored red.
+func (g *Graph) WriteDot(w io.Writer) {
+	fmt.Fprintln(w, "digraph dependencies {")
+	fmt.Fprintln(w, "  rankdir=LR;")
+	for _, n := range g.Nodes {
+		if g.Cycles[n] {
+			fmt.Fprintf(w, "  %q [color=red];\n", n)
+		} else {
+			fmt.Fprintf(w, "  %q;\n", n)
+		}
+	}
+	for _, e := range g.Edges {
+		if e.Cycle {
+			fmt.Fprintf(w, "  %q -> %q [color=red];\n", e.From, e.To)
+		} else {
+			fmt.Fprintf(w, "  %q -> %q;\n", e.From, e.To)
+		}
+	}
+	fmt.Fprintln(w, "}")
+}
+
+// WriteMermaid writes the graph as Mermaid flowchart. Nodes and edges
+// involved in cycles are colored red.
+func (g *Graph) WriteMermaid(w io.Writer) {
+	ids := map[string]string{}
+	fmt.Fprintln(w, "graph LR")
+	for i, n := range g.Nodes {
+		ids[n] = fmt.Sprintf("n%d", i)
+		fmt.Fprintf(w, "  %s[\"%s\"]\n", ids[n], strings.ReplaceAll(n, `"`, "#quot;"))
+	}
+	for _, e := range g.Edges {
+		fmt.Fprintf(w, "  %s --> %s\n", ids[e.From], ids[e.To])
+	}
+	for _, n := range g.Nodes {
+		if g.Cycles[n] {
+			fmt.Fprintf(w, "  style %s stroke:red\n", ids[n])
+		}
+	}
+	for i, e := range g.Edges {
+		if e.Cycle {
+			fmt.Fprintf(w, "  linkStyle %d stroke:red\n", i)
+		}
+	}
+}
@@ -0,0 +1,62 @@
+package flow
+
+import (
+	"bytes"
+
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("Dependency graph", func() {
+	template := parseYAML(`
+---
+values:
+  a: (( b + 1 ))
+  b: (( c ))
+  c: 1
+loop1: (( loop2 ))
+loop2: (( loop1 ))
+tmpl:
+  <<: (( &template ))
+  x: (( y ))
+`)
+
+	It("resolves references lexically and marks cycles", func() {
+		g := DependencyGraph(template)
+		Expect(g.Nodes).To(Equal([]string{"loop1", "loop2", "values.a", "values.b", "values.c"}))
+		Expect(g.Edges).To(Equal([]GraphEdge{
+			{"loop1", "loop2", true},
+			{"loop2", "loop1", true},
+			{"values.a", "values.b", false},
+			{"values.b", "values.c", false},
+		}))
+	})
+
+	It("omits dependencies of fields defined by stubs", func() {
+		stub := parseYAML(`
+---
+values:
+  b: 2
+`)
+		g := DependencyGraph(template, stub)
+		Expect(g.Edges).To(ContainElement(GraphEdge{"values.a", "values.b", false}))
+		Expect(g.Edges).NotTo(ContainElement(GraphEdge{"values.b", "values.c", false}))
+	})
+
+	It("renders dot format", func() {
+		buf := &bytes.Buffer{}
+		DependencyGraph(parseYAML(`
+---
+a: (( b ))
+b: (( a ))
+`)).WriteDot(buf)
+		Expect(buf.String()).To(Equal(`digraph dependencies {
+  rankdir=LR;
+  "a" [color=red];
+  "b" [color=red];
+  "a" -> "b" [color=red];
+  "b" -> "a" [color=red];
+}
+`))
+	})
+})
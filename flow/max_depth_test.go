@@ -0,0 +1,61 @@
+package flow
+
+import (
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+
+	"github.com/mandelsoft/spiff/yaml"
+)
+
+var _ = Describe("Maximum nesting depth", func() {
+	apply := func(source string, depth int) (yaml.Node, error) {
+		template, err := yaml.Parse("test", []byte(source))
+		Expect(err).NotTo(HaveOccurred())
+		env := NewEnvironment(nil, "test", NewDefaultState())
+		return Apply(env, template, nil, Options{MaxDepth: depth})
+	}
+
+	It("allows recursion below the limit", func() {
+		result, err := apply(`
+fac: (( |x|->x <= 1 ? 1 :x * _(x - 1) ))
+v: (( .fac(5) ))
+`, 10)
+		Expect(err).NotTo(HaveOccurred())
+		Expect(result.Value().(map[string]yaml.Node)["v"].Value()).To(Equal(int64(120)))
+	})
+
+	It("reports endless lambda recursion", func() {
+		_, err := apply(`
+f: (( |x|->.f(x) ))
+v: (( .f(1) ))
+`, 5)
+		Expect(err).To(HaveOccurred())
+		Expect(err.Error()).To(Equal(`unresolved nodes:
+	(( .f(1) ))	in test	v	()	*maximum nesting depth exceeded (5): cycle lambda|x|->.f(x) -> lambda|x|->.f(x)`))
+	})
+
+	It("reports endless template recursion", func() {
+		_, err := apply(`
+t:
+  <<: (( &template ))
+  sub: (( *t ))
+v: (( *t ))
+`, 5)
+		Expect(err).To(HaveOccurred())
+		Expect(err.Error()).To(Equal(`unresolved nodes:
+	(( *(t) ))	in test	v	()	*resolution of template 't' failed
+			(( *(t) ))	in test	sub	(v.sub)*maximum nesting depth exceeded (5): cycle template t -> template t`))
+	})
+
+	It("does not fail unrelated lambdas after an exceeded recursion", func() {
+		result, err := apply(`
+f: (( |x|->.f(x) ))
+g: (( |x|->x + 1 ))
+v: (( .f(1) || "fallback" ))
+w: (( .g(1) ))
+`, 5)
+		Expect(err).NotTo(HaveOccurred())
+		Expect(result.Value().(map[string]yaml.Node)["v"].Value()).To(Equal("fallback"))
+		Expect(result.Value().(map[string]yaml.Node)["w"].Value()).To(Equal(int64(2)))
+	})
+})
@@ -0,0 +1,26 @@
+package flow
+
+import (
+	"github.com/mandelsoft/spiff/yaml"
+)
+
+// Provenance returns the source names of all leaf values of a processed
+// document by their (dot separated) field paths. Like for the flat output
+// (see yaml.FlatKey), list indices are appended in the form `[<index>]`.
+// Values taken from a stub carry the name of the stub, values provided or
+// calculated by the template the name of the template.
+func Provenance(node yaml.Node) map[string]string {
+	result := map[string]string{}
+	yaml.Walk(node, func(path []string, n yaml.Node) error {
+		if n == nil || len(path) == 0 {
+			return nil
+		}
+		switch n.Value().(type) {
+		case map[string]yaml.Node, []yaml.Node:
+			return nil
+		}
+		result[yaml.FlatKey(path, ".")] = n.SourceName()
+		return nil
+	})
+	return result
+}
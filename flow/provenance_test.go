@@ -0,0 +1,59 @@
+package flow
+
+import (
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+
+	"github.com/mandelsoft/spiff/yaml"
+)
+
+var _ = Describe("Provenance", func() {
+	parse := func(name, source string) yaml.Node {
+		node, err := yaml.Parse(name, []byte(source))
+		Expect(err).NotTo(HaveOccurred())
+		return node
+	}
+
+	It("reports the source of every leaf", func() {
+		template := parse("template.yml", `
+plain: template
+overridden: template
+merged: (( merge ))
+calculated: (( merged ".suffix" ))
+list:
+  - (( merge || "default" ))
+`)
+		stub1 := parse("stub1.yml", `
+overridden: stub1
+merged: stub1
+`)
+		stub2 := parse("stub2.yml", `
+merged: stub2
+`)
+		provenance := map[string]string{}
+		result, err := Cascade(nil, template, Options{Provenance: provenance}, stub1, stub2)
+		Expect(err).NotTo(HaveOccurred())
+		Expect(provenance).To(Equal(map[string]string{
+			"plain":      "template.yml",
+			"overridden": "stub1.yml",
+			"merged":     "stub2.yml",
+			"calculated": "template.yml",
+			"list[0]":    "template.yml",
+		}))
+		Expect(Provenance(result)).To(Equal(provenance))
+	})
+
+	It("does not change the output", func() {
+		template := parse("template.yml", `
+value: (( merge ))
+`)
+		stub := parse("stub.yml", `
+value: stub
+`)
+		plain, err := Cascade(nil, template, Options{}, stub)
+		Expect(err).NotTo(HaveOccurred())
+		tracked, err := Cascade(nil, template, Options{Provenance: map[string]string{}}, stub)
+		Expect(err).NotTo(HaveOccurred())
+		Expect(tracked).To(Equal(plain))
+	})
+})
@@ -3,15 +3,17 @@ package flow
 import (
 	"crypto/sha512"
 	"encoding/base64"
+	"encoding/binary"
 	"fmt"
-	"io/ioutil"
-	"net/http"
+	"math/rand"
+	"os"
 	"path"
 	"reflect"
 	"sort"
 	"strconv"
 	"strings"
 	"sync"
+	"time"
 
 	"github.com/mandelsoft/vfs/pkg/osfs"
 	"github.com/mandelsoft/vfs/pkg/vfs"
@@ -24,6 +26,19 @@ import (
 
 const MODE_FILE_ACCESS = 1 // support file system access
 const MODE_OS_ACCESS = 2   // support os commands like pipe and exec
+const MODE_NETWORK = 4     // support network access like http_get
+
+// DefaultHTTPTimeout is the default timeout for http requests of the
+// http_get functions.
+const DefaultHTTPTimeout = 30 * time.Second
+
+// DefaultHTTPMaxSize is the default maximum size of response bodies
+// accepted by the http_get functions.
+const DefaultHTTPMaxSize = 1024 * 1024
+
+// DefaultMaxDepth is the default maximum nesting depth of lambda calls
+// and template instantiations.
+const DefaultMaxDepth = 1000
 
 type execCache struct {
 	cache map[string][]byte
@@ -52,21 +67,51 @@ func (c *execCache) Set(key string, content []byte) {
 
 var _ dynaml.ExecCache = &execCache{}
 
+type valueCache struct {
+	cache map[string]interface{}
+	lock  sync.Mutex
+}
+
+// FileResolver provides the content of a file used by the file based
+// dynaml functions.
+type FileResolver func(file string) ([]byte, error)
+
 type State struct {
 	files      map[string]string // content hash to temp file name
 	fileCache  map[string][]byte // file content cache
 	key        string            // default encryption key
+	method     string            // default encryption method
 	mode       int
 	exec_cache dynaml.ExecCache // execution cache
+	values     *valueCache      // cached results of expressions marked with &cached
 	fileSystem vfs.VFS          // virtual filesystem to use for filesystem based operations
 	registry   dynaml.Registry
 	features   features.FeatureFlags
 	tags       map[string]*dynaml.TagInfo
-	docno      int // document number
+	docno      int        // document number
+	random     *rand.Rand // random number generator
+	seed       *int64     // seed of the pseudo random number generator, if configured
+	includes   []string   // include search path
+	included   []string   // files currently being included
+	maxDepth   int        // maximum nesting depth of lambda calls and template instantiations
+	netLimits  httpLimits // limits for http requests
+	nesting    []string   // lambda calls and template instantiations currently evaluated
+	exceeded   error      // error of the actual recursion that exceeded the maximum nesting depth
+	tracer     dynaml.Tracer
+	profiler   *dynaml.Profiler
+	resolver   FileResolver    // optional resolver replacing the filesystem for file reads
+	warnings   []Warning       // non-fatal issues of the evaluated nodes
+	deprecated map[string]bool // deprecated functions already reported
+	delimiters yaml.Delimiters // delimiters of expressions embedded in strings
 }
 
 var _ dynaml.State = &State{}
 
+type httpLimits struct {
+	timeout time.Duration
+	maxSize int64
+}
+
 func NewState(key string, mode int, optfs ...vfs.FileSystem) *State {
 	var fs vfs.FileSystem
 	if len(optfs) > 0 {
@@ -84,10 +129,14 @@ func NewState(key string, mode int, optfs ...vfs.FileSystem) *State {
 		key:        key,
 		mode:       mode,
 		exec_cache: &execCache{cache: make(map[string][]byte)},
+		values:     &valueCache{cache: map[string]interface{}{}},
 		fileSystem: vfs.New(fs),
 		docno:      1,
 		features:   features.Features(),
 		registry:   dynaml.DefaultRegistry(),
+		random:     dynaml.NewCryptoRandom(),
+		maxDepth:   DefaultMaxDepth,
+		netLimits:  httpLimits{DefaultHTTPTimeout, DefaultHTTPMaxSize},
 	}
 }
 
@@ -107,6 +156,123 @@ func (s *State) SetFeatures(f features.FeatureFlags) *State {
 	return s
 }
 
+// SetRandomSeed uses a pseudo random number generator with the given seed
+// instead of the crypto random source to provide reproducible random values.
+func (s *State) SetRandomSeed(seed int64) *State {
+	s.seed = &seed
+	s.random = rand.New(rand.NewSource(seed))
+	return s
+}
+
+// ResetRandom restarts the pseudo random number generator with the
+// configured seed, so that every processing yields the same random values.
+// Without a configured seed the crypto random source is kept.
+func (s *State) ResetRandom() *State {
+	if s.seed != nil {
+		s.random = rand.New(rand.NewSource(*s.seed))
+	}
+	return s
+}
+
+// ParseRandomSeed determines a random seed from the content of a seed file.
+// A decimal integer is used as it is, any other content is hashed to
+// a seed value.
+func ParseRandomSeed(data []byte) (int64, error) {
+	text := strings.TrimSpace(string(data))
+	if text == "" {
+		return 0, fmt.Errorf("empty random seed")
+	}
+	if seed, err := strconv.ParseInt(text, 10, 64); err == nil {
+		return seed, nil
+	}
+	sum := sha512.Sum512([]byte(text))
+	return int64(binary.BigEndian.Uint64(sum[:8])), nil
+}
+
+// EnvironmentBindings determines binding values from the environment
+// variables starting with the given prefix. The binding name is the
+// remainder of the variable name, the values are kept as strings.
+// It requires OS access (see MODE_OS_ACCESS).
+func (s *State) EnvironmentBindings(prefix string) (map[string]yaml.Node, error) {
+	if !s.OSAccessAllowed() {
+		return nil, fmt.Errorf("environment bindings require OS access")
+	}
+	result := map[string]yaml.Node{}
+	for _, e := range os.Environ() {
+		i := strings.Index(e, "=")
+		if i < 0 || !strings.HasPrefix(e[:i], prefix) || i == len(prefix) {
+			continue
+		}
+		result[e[len(prefix):i]] = yaml.NewNode(e[i+1:], "<env "+e[:i]+">")
+	}
+	return result, nil
+}
+
+// SetIncludeDirs sets the search path used to resolve relative
+// file names for the include function.
+func (s *State) SetIncludeDirs(dirs ...string) *State {
+	s.includes = append([]string{}, dirs...)
+	return s
+}
+
+// SetTracer sets a tracer notified about every evaluated
+// dynaml expression.
+func (s *State) SetTracer(t dynaml.Tracer) *State {
+	s.tracer = t
+	return s
+}
+
+// SetProfiler sets a profiler recording the evaluation times
+// of functions and document paths.
+func (s *State) SetProfiler(p *dynaml.Profiler) *State {
+	s.profiler = p
+	return s
+}
+
+// SetFileResolver sets a resolver used to read the content of files
+// instead of the filesystem. File access must still be enabled by the
+// processing mode.
+func (s *State) SetFileResolver(r FileResolver) *State {
+	s.resolver = r
+	return s
+}
+
+// AddWarning records a non-fatal issue. Identical warnings for the
+// same node are recorded only once.
+func (s *State) AddWarning(w Warning) {
+	for _, o := range s.warnings {
+		if o == w {
+			return
+		}
+	}
+	s.warnings = append(s.warnings, w)
+}
+
+// AddDeprecation records a warning for the usage of a deprecated function.
+// Every function is reported only once per state.
+func (s *State) AddDeprecation(name string, w Warning) {
+	if s.deprecated[name] {
+		return
+	}
+	if s.deprecated == nil {
+		s.deprecated = map[string]bool{}
+	}
+	s.deprecated[name] = true
+	s.AddWarning(w)
+}
+
+// Warnings returns the recorded warnings in the order of their detection.
+func (s *State) Warnings() []Warning {
+	return append([]Warning{}, s.warnings...)
+}
+
+// ResetWarnings discards the recorded warnings.
+func (s *State) ResetWarnings() *State {
+	s.warnings = nil
+	s.deprecated = nil
+	return s
+}
+
 func (s *State) SetTags(tags ...*dynaml.Tag) *State {
 	s.tags = map[string]*dynaml.TagInfo{}
 	for _, v := range tags {
@@ -124,6 +290,20 @@ func (s *State) InterpolationEnabled() bool {
 	return s.features.InterpolationEnabled()
 }
 
+// SetInterpolationDelimiters sets the delimiters used to detect
+// expressions embedded in strings if interpolation is enabled.
+func (s *State) SetInterpolationDelimiters(d yaml.Delimiters) *State {
+	s.delimiters = d
+	return s
+}
+
+func (s *State) InterpolationDelimiters() yaml.Delimiters {
+	if s.delimiters.Open == "" {
+		return yaml.DefaultDelimiters
+	}
+	return s.delimiters
+}
+
 func (s *State) SetControl(b bool) *State {
 	s.features.SetControl(b)
 	return s
@@ -141,6 +321,41 @@ func (s *State) FileAccessAllowed() bool {
 	return s.mode&MODE_FILE_ACCESS != 0
 }
 
+// OnlineAllowed checks whether network access is enabled
+// (see MODE_NETWORK).
+func (s *State) OnlineAllowed() bool {
+	return s.mode&MODE_NETWORK != 0
+}
+
+// SetNetworkAccess enables or disables the network access
+// (see MODE_NETWORK).
+func (s *State) SetNetworkAccess(b bool) *State {
+	if b {
+		s.mode |= MODE_NETWORK
+	} else {
+		s.mode &= ^MODE_NETWORK
+	}
+	return s
+}
+
+// SetHTTPLimits sets the timeout and the maximum response size used
+// by the http_get functions. Values less than or equal to zero select
+// the defaults.
+func (s *State) SetHTTPLimits(timeout time.Duration, maxSize int64) *State {
+	if timeout <= 0 {
+		timeout = DefaultHTTPTimeout
+	}
+	if maxSize <= 0 {
+		maxSize = DefaultHTTPMaxSize
+	}
+	s.netLimits = httpLimits{timeout, maxSize}
+	return s
+}
+
+func (s *State) GetHTTPLimits() (time.Duration, int64) {
+	return s.netLimits.timeout, s.netLimits.maxSize
+}
+
 func (s *State) FileSystem() vfs.VFS {
 	return s.fileSystem
 }
@@ -163,10 +378,132 @@ func (s *State) GetEncryptionKey() string {
 	return s.key
 }
 
+// SetEncryptionMethod sets the encryption method used by the encrypt
+// function if no explicit method is given. An empty method selects the
+// default method.
+func (s *State) SetEncryptionMethod(method string) *State {
+	s.method = method
+	return s
+}
+
+func (s *State) GetEncryptionMethod() string {
+	return s.method
+}
+
 func (s *State) GetExecCache() dynaml.ExecCache {
 	return s.exec_cache
 }
 
+// GetCachedValue returns the cached result of an expression marked
+// with &cached for the given key.
+func (s *State) GetCachedValue(key string) (interface{}, bool) {
+	s.values.lock.Lock()
+	defer s.values.lock.Unlock()
+	v, ok := s.values.cache[key]
+	return v, ok
+}
+
+// SetCachedValue caches the result of an expression marked with &cached.
+func (s *State) SetCachedValue(key string, value interface{}) {
+	s.values.lock.Lock()
+	defer s.values.lock.Unlock()
+	s.values.cache[key] = value
+}
+
+// pushValueCache starts a new scope for the results of expressions marked
+// with &cached. The returned function restores the previous scope.
+func (s *State) pushValueCache() func() {
+	s.values.lock.Lock()
+	defer s.values.lock.Unlock()
+	old := s.values.cache
+	s.values.cache = map[string]interface{}{}
+	return func() {
+		s.values.lock.Lock()
+		defer s.values.lock.Unlock()
+		s.values.cache = old
+	}
+}
+
+func (s *State) GetRandom() *rand.Rand {
+	return s.random
+}
+
+func (s *State) GetTracer() dynaml.Tracer {
+	return s.tracer
+}
+
+func (s *State) GetProfiler() *dynaml.Profiler {
+	return s.profiler
+}
+
+func (s *State) GetIncludeDirs() []string {
+	return s.includes
+}
+
+func (s *State) GetIncludeStack() []string {
+	return s.included
+}
+
+func (s *State) PushInclude(file string) error {
+	file = path.Clean(file)
+	for i, f := range s.included {
+		if f == file {
+			cycle := append(append([]string{}, s.included[i:]...), file)
+			return fmt.Errorf("include cycle detected: %s", strings.Join(cycle, " -> "))
+		}
+	}
+	s.included = append(s.included, file)
+	return nil
+}
+
+func (s *State) PopInclude() {
+	if len(s.included) > 0 {
+		s.included = s.included[:len(s.included)-1]
+	}
+}
+
+// SetMaxDepth sets the maximum nesting depth of lambda calls and template
+// instantiations. A value less than or equal to zero selects the default
+// depth.
+func (s *State) SetMaxDepth(depth int) *State {
+	if depth <= 0 {
+		depth = DefaultMaxDepth
+	}
+	s.maxDepth = depth
+	return s
+}
+
+func (s *State) PushNesting(name string) error {
+	if s.exceeded != nil {
+		// once exceeded, fail fast to avoid re-evaluating the recursion again
+		// and again for every retry of the enclosing flows until the
+		// recursion is completely unwound.
+		return s.exceeded
+	}
+	if len(s.nesting) >= s.maxDepth {
+		s.exceeded = fmt.Errorf("%s (%d)", dynaml.MaxDepthExceeded, s.maxDepth)
+		for i := len(s.nesting) - 1; i >= 0; i-- {
+			if s.nesting[i] == name {
+				cycle := append(append([]string{}, s.nesting[i:]...), name)
+				s.exceeded = fmt.Errorf("%s (%d): cycle %s", dynaml.MaxDepthExceeded, s.maxDepth, strings.Join(cycle, " -> "))
+				break
+			}
+		}
+		return s.exceeded
+	}
+	s.nesting = append(s.nesting, name)
+	return nil
+}
+
+func (s *State) PopNesting() {
+	if len(s.nesting) > 0 {
+		s.nesting = s.nesting[:len(s.nesting)-1]
+	}
+	if len(s.nesting) == 0 {
+		s.exceeded = nil
+	}
+}
+
 func (s *State) GetTempName(data []byte) (string, error) {
 	if !s.FileAccessAllowed() {
 		return "", fmt.Errorf("tempname: no OS operations supported in this execution environment")
@@ -294,6 +631,21 @@ func (s *State) Cleanup() {
 	s.files = map[string]string{}
 }
 
+// FileExists checks whether a file or directory exists. Files are
+// checked with the file resolver, if configured. Directories are always
+// checked with the filesystem.
+func (s *State) FileExists(file string, directory bool) bool {
+	if s.resolver != nil && !directory {
+		_, err := s.resolver(dynaml.FilePath(file))
+		return err == nil
+	}
+	fi, err := s.fileSystem.Stat(file)
+	if err != nil {
+		return false
+	}
+	return fi.IsDir() == directory
+}
+
 func (s *State) GetFileContent(file string, cached bool) ([]byte, error) {
 	var err error
 
@@ -301,17 +653,18 @@ func (s *State) GetFileContent(file string, cached bool) ([]byte, error) {
 	data := s.fileCache[file]
 	if !cached || data == nil {
 		debug.Debug("reading file %s\n", file)
-		if strings.HasPrefix(file, "http:") || strings.HasPrefix(file, "https:") {
-			response, err := http.Get(file)
+		if s.resolver != nil {
+			data, err = s.resolver(file)
+			if err != nil {
+				return nil, fmt.Errorf("error reading [%s]: %s", file, err)
+			}
+		} else if strings.HasPrefix(file, "http:") || strings.HasPrefix(file, "https:") {
+			if !s.OnlineAllowed() {
+				return nil, fmt.Errorf("no network operations supported in this execution environment, cannot get [%s]", file)
+			}
+			data, err = dynaml.HTTPGet(file, s)
 			if err != nil {
-				return nil, fmt.Errorf("error getting [%s]: %s", file, err)
-			} else {
-				defer response.Body.Close()
-				contents, err := ioutil.ReadAll(response.Body)
-				if err != nil {
-					return nil, fmt.Errorf("error getting body [%s]: %s", file, err)
-				}
-				data = contents
+				return nil, err
 			}
 		} else {
 			data, err = s.fileSystem.ReadFile(file)
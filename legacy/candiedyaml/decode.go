@@ -50,6 +50,7 @@ type Decoder struct {
 	event         yaml_event_t
 	replay_events []yaml_event_t
 	useNumber     bool
+	positions     bool
 
 	anchors          map[string][]yaml_event_t
 	tracking_anchors [][]yaml_event_t
@@ -136,6 +137,20 @@ func (d *Decoder) Decode(v interface{}) (err error) {
 
 func (d *Decoder) UseNumber() { d.useNumber = true }
 
+// TrackPositions causes values decoded into an interface{} to be wrapped
+// into Positioned values recording their location in the source.
+func (d *Decoder) TrackPositions() { d.positions = true }
+
+// Positioned is a decoded value together with the (1-based) line and
+// column of its start in the source document.
+// For block scalars the style is recorded, too.
+type Positioned struct {
+	Value  interface{}
+	Line   int
+	Column int
+	Style  ScalarStyle
+}
+
 func (d *Decoder) error(err error) {
 	panic(err)
 }
@@ -234,16 +249,17 @@ func (d *Decoder) begin_anchor(anchor string) {
 
 func (d *Decoder) end_anchor(anchor string) {
 	if anchor != "" {
-		events := d.tracking_anchors[len(d.tracking_anchors)-1]
+		tracked := d.tracking_anchors[len(d.tracking_anchors)-1]
 		d.tracking_anchors = d.tracking_anchors[0 : len(d.tracking_anchors)-1]
+		// if nested, the enclosing anchor already got the start event,
+		// but missed all the other ones, including the extra event
+		if last := len(d.tracking_anchors); last > 0 {
+			d.tracking_anchors[last-1] = append(d.tracking_anchors[last-1], tracked[1:]...)
+		}
+		// we went one too many, remove the extra event
+		events := append([]yaml_event_t{}, tracked[:len(tracked)-1]...)
 		// remove the anchor, replaying events shouldn't have anchors
 		events[0].anchor = nil
-		// we went one too many, remove the extra event
-		events = events[:len(events)-1]
-		// if nested, append to all the other anchors
-		for i, e := range d.tracking_anchors {
-			d.tracking_anchors[i] = append(e, events...)
-		}
 		d.anchors[anchor] = events
 	}
 }
@@ -541,6 +557,16 @@ func (d *Decoder) alias(rv reflect.Value) {
 func (d *Decoder) valueInterface() interface{} {
 	var v interface{}
 
+	mark := d.event.start_mark
+	style := DefaultStyle
+	if d.event.event_type == yaml_SCALAR_EVENT {
+		switch yaml_scalar_style_t(d.event.style) {
+		case yaml_LITERAL_SCALAR_STYLE:
+			style = LiteralStyle
+		case yaml_FOLDED_SCALAR_STYLE:
+			style = FoldedStyle
+		}
+	}
 	anchor := string(d.event.anchor)
 	switch d.event.event_type {
 	case yaml_SEQUENCE_START_EVENT:
@@ -566,6 +592,9 @@ func (d *Decoder) valueInterface() interface{} {
 	}
 	d.end_anchor(anchor)
 
+	if d.positions {
+		return Positioned{v, mark.line + 1, mark.column + 1, style}
+	}
 	return v
 }
 
@@ -613,6 +642,9 @@ done:
 		}
 
 		key := d.valueInterface()
+		if p, ok := key.(Positioned); ok {
+			key = p.Value
+		}
 
 		// Read value.
 		m[key] = d.valueInterface()
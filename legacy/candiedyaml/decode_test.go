@@ -785,6 +785,26 @@ b:
 			err := d.Decode(&v)
 			Expect(err).NotTo(HaveOccurred())
 		})
+
+		It("aliases anchors containing anchors", func() {
+			d := NewDecoder(strings.NewReader(`
+---
+a: &a
+  l: &l
+  - 1
+  m: 2
+b: *a
+c: *l
+`))
+			v := make(map[string]interface{})
+			err := d.Decode(&v)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(v).To(Equal(map[string]interface{}{
+				"a": map[interface{}]interface{}{"l": []interface{}{int64(1)}, "m": int64(2)},
+				"b": map[interface{}]interface{}{"l": []interface{}{int64(1)}, "m": int64(2)},
+				"c": []interface{}{int64(1)},
+			}))
+		})
 	})
 
 	Context("When decoding fails", func() {
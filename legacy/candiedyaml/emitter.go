@@ -1948,6 +1948,7 @@ func yaml_emitter_write_block_scalar_hints(emitter *yaml_emitter_t, value []byte
 			chomp_hint[0] = '+'
 			emitter.open_ended = true
 		} else {
+			i--
 			for value[i]&0xC0 == 0x80 {
 				i--
 			}
@@ -2031,10 +2032,10 @@ func yaml_emitter_write_folded_scalar(emitter *yaml_emitter_t, value []byte) boo
 		if is_break_at(value, i) {
 			if !breaks && !leading_spaces && value[i] == '\n' {
 				k := i
-				for is_break_at(value, k) {
+				for k < len(value) && is_break_at(value, k) {
 					k += width(value[k])
 				}
-				if !is_blankz_at(value, k) {
+				if k < len(value) && !is_blankz_at(value, k) {
 					if !put_break(emitter) {
 						return false
 					}
@@ -2052,7 +2053,7 @@ func yaml_emitter_write_folded_scalar(emitter *yaml_emitter_t, value []byte) boo
 				}
 				leading_spaces = is_blank(value[i])
 			}
-			if !breaks && is_space(value[i]) && !is_space(value[i+1]) &&
+			if !breaks && is_space(value[i]) && i+1 < len(value) && !is_space(value[i+1]) &&
 				emitter.column > emitter.best_width {
 				if !yaml_emitter_write_indent(emitter) {
 					return false
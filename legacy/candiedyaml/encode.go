@@ -29,6 +29,9 @@ import (
 var (
 	timeTimeType  = reflect.TypeOf(time.Time{})
 	marshalerType = reflect.TypeOf(new(Marshaler)).Elem()
+	anchorType    = reflect.TypeOf(Anchor{})
+	aliasType     = reflect.TypeOf(Alias(""))
+	styledType    = reflect.TypeOf(Styled{})
 	numberType    = reflect.TypeOf(Number(""))
 	nonPrintable  = regexp.MustCompile("[^\t\n\r\u0020-\u007E\u0085\u00A0-\uD7FF\uE000-\uFFFD]")
 	multiline     = regexp.MustCompile("\n|\u0085|\u2028|\u2029")
@@ -50,13 +53,54 @@ type Marshaler interface {
 	MarshalYAML() (tag string, value interface{}, err error)
 }
 
+// Anchor is marshalled as its value marked with the given anchor name.
+type Anchor struct {
+	Name  string
+	Value interface{}
+}
+
+// Alias is marshalled as alias referring to the anchor with this name.
+type Alias string
+
+// ScalarStyle selects the presentation style of multi-line strings.
+type ScalarStyle int
+
+const (
+	DefaultStyle ScalarStyle = iota
+	LiteralStyle
+	FoldedStyle
+)
+
+// QuoteStyle selects the quoting of single-line string values.
+type QuoteStyle int
+
+const (
+	// AutoQuote uses plain scalars, if possible.
+	AutoQuote QuoteStyle = iota
+	DoubleQuote
+	SingleQuote
+)
+
+// Styled is marshalled as its string value using the given style,
+// if it spans multiple lines.
+type Styled struct {
+	Value string
+	Style ScalarStyle
+}
+
 // An Encoder writes JSON objects to an output stream.
 type Encoder struct {
 	w       io.Writer
 	emitter yaml_emitter_t
 	event   yaml_event_t
 	flow    bool
-	err     error
+	anchor  string
+	style   ScalarStyle
+	// multiline is the default style for multi-line strings
+	multiline ScalarStyle
+	quote     QuoteStyle
+	key       bool
+	err       error
 }
 
 func Marshal(v interface{}) ([]byte, error) {
@@ -79,6 +123,18 @@ func NewEncoder(w io.Writer) *Encoder {
 	return e
 }
 
+// SetMultilineStyle sets the style used for multi-line strings without
+// an explicit style. The default is the literal style.
+func (e *Encoder) SetMultilineStyle(style ScalarStyle) {
+	e.multiline = style
+}
+
+// SetQuoteStyle sets the quoting used for single-line string values.
+// Map keys are not affected.
+func (e *Encoder) SetQuoteStyle(style QuoteStyle) {
+	e.quote = style
+}
+
 func (e *Encoder) Encode(v interface{}) (err error) {
 	defer recovery(&err)
 
@@ -106,6 +162,23 @@ func (e *Encoder) emit() {
 func (e *Encoder) marshal(tag string, v reflect.Value, allowAddr bool) {
 	vt := v.Type()
 
+	switch vt {
+	case anchorType:
+		a := v.Interface().(Anchor)
+		e.anchor = a.Name
+		e.marshal(tag, reflect.ValueOf(a.Value), true)
+		return
+	case aliasType:
+		yaml_alias_event_initialize(&e.event, []byte(v.String()))
+		e.emit()
+		return
+	case styledType:
+		st := v.Interface().(Styled)
+		e.style = st.Style
+		e.marshal(tag, reflect.ValueOf(st.Value), true)
+		return
+	}
+
 	if vt.Implements(marshalerType) {
 		e.emitMarshaler(tag, v)
 		return
@@ -157,7 +230,9 @@ func (e *Encoder) emitMap(tag string, v reflect.Value) {
 		var keys stringValues = v.MapKeys()
 		sort.Sort(keys)
 		for _, k := range keys {
+			e.key = true
 			e.marshal("", k, true)
+			e.key = false
 			e.marshal("", v.MapIndex(k), true)
 		}
 	})
@@ -178,7 +253,9 @@ func (e *Encoder) emitStruct(tag string, v reflect.Value) {
 				continue
 			}
 
+			e.key = true
 			e.marshal("", reflect.ValueOf(f.name), true)
+			e.key = false
 			e.flow = f.flow
 			e.marshal("", fv, true)
 		}
@@ -216,7 +293,7 @@ func (e *Encoder) mapping(tag string, f func()) {
 		e.flow = false
 		style = yaml_FLOW_MAPPING_STYLE
 	}
-	yaml_mapping_start_event_initialize(&e.event, nil, []byte(tag), implicit, style)
+	yaml_mapping_start_event_initialize(&e.event, e.takeAnchor(), []byte(tag), implicit, style)
 	e.emit()
 
 	f()
@@ -237,7 +314,7 @@ func (e *Encoder) emitSlice(tag string, v reflect.Value) {
 		e.flow = false
 		style = yaml_FLOW_SEQUENCE_STYLE
 	}
-	yaml_sequence_start_event_initialize(&e.event, nil, []byte(tag), implicit, style)
+	yaml_sequence_start_event_initialize(&e.event, e.takeAnchor(), []byte(tag), implicit, style)
 	e.emit()
 
 	n := v.Len()
@@ -266,6 +343,7 @@ func (e *Encoder) emitBase64(tag string, v reflect.Value) {
 func (e *Encoder) emitString(tag string, v reflect.Value) {
 	var style yaml_scalar_style_t
 	s := v.String()
+	defer func() { e.style = DefaultStyle }()
 
 	if nonPrintable.MatchString(s) {
 		e.emitBase64(tag, v)
@@ -285,9 +363,20 @@ func (e *Encoder) emitString(tag string, v reflect.Value) {
 			style = yaml_DOUBLE_QUOTED_SCALAR_STYLE
 		} else if multiline.MatchString(s) {
 			style = yaml_LITERAL_SCALAR_STYLE
+			if e.style == FoldedStyle || e.style == DefaultStyle && e.multiline == FoldedStyle {
+				style = yaml_FOLDED_SCALAR_STYLE
+			}
 		} else {
 			style = yaml_PLAIN_SCALAR_STYLE
 		}
+		if style != yaml_LITERAL_SCALAR_STYLE && style != yaml_FOLDED_SCALAR_STYLE && !e.key {
+			switch e.quote {
+			case DoubleQuote:
+				style = yaml_DOUBLE_QUOTED_SCALAR_STYLE
+			case SingleQuote:
+				style = yaml_SINGLE_QUOTED_SCALAR_STYLE
+			}
+		}
 	}
 
 	e.emitScalar(s, "", tag, style)
@@ -341,10 +430,23 @@ func (e *Encoder) emitScalar(value, anchor, tag string, style yaml_scalar_style_
 		stag = tag
 	}
 
+	if anchor == "" {
+		anchor = string(e.takeAnchor())
+	}
 	yaml_scalar_event_initialize(&e.event, []byte(anchor), []byte(stag), []byte(value), implicit, implicit, style)
 	e.emit()
 }
 
+// takeAnchor returns the anchor for the next node, if any, and resets it.
+func (e *Encoder) takeAnchor() []byte {
+	if e.anchor == "" {
+		return nil
+	}
+	anchor := []byte(e.anchor)
+	e.anchor = ""
+	return anchor
+}
+
 func (e *Encoder) emitMarshaler(tag string, v reflect.Value) {
 	if v.Kind() == reflect.Ptr && v.IsNil() {
 		e.emitNil()
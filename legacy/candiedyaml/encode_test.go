@@ -60,6 +60,34 @@ var _ = Describe("Encode", func() {
 
 		})
 
+		It("quotes string values", func() {
+			enc.SetQuoteStyle(SingleQuote)
+			err := enc.Encode(map[string]interface{}{"a": "it's", "b": []interface{}{"c", 1, "d\ne"}})
+			Expect(err).NotTo(HaveOccurred())
+			Expect(buf.String()).To(Equal(`a: 'it''s'
+b:
+- 'c'
+- 1
+- |-
+  d
+  e
+`))
+		})
+
+		It("encodes strings with multilines in folded style", func() {
+			enc.SetMultilineStyle(FoldedStyle)
+			err := enc.Encode(map[string]interface{}{"a": "a\nc", "b": Styled{"d\ne", LiteralStyle}})
+			Expect(err).NotTo(HaveOccurred())
+			Expect(buf.String()).To(Equal(`a: >-
+  a
+
+  c
+b: |-
+  d
+  e
+`))
+		})
+
 		It("handles strings that match known scalars", func() {
 			err := enc.Encode("true")
 			Expect(err).NotTo(HaveOccurred())
@@ -609,6 +637,17 @@ Error: null
 			Expect(buf.String()).To(Equal("12345\n"))
 		})
 	})
+
+	Context("Anchors", func() {
+		It("encodes anchors and aliases", func() {
+			err := enc.Encode(map[string]interface{}{
+				"a": Anchor{"x", map[string]interface{}{"b": 1}},
+				"c": Alias("x"),
+			})
+			Expect(err).NotTo(HaveOccurred())
+			Expect(buf.String()).To(Equal("a: &x\n  b: 1\nc: *x\n"))
+		})
+	})
 })
 
 type hasMarshaler struct {
@@ -2,8 +2,13 @@ package main
 
 import (
 	"io/ioutil"
+	"net/http"
+	"net/http/httptest"
 	"os"
 	"os/exec"
+	"path/filepath"
+	"strings"
+	"time"
 
 	. "github.com/onsi/ginkgo"
 	. "github.com/onsi/gomega"
@@ -144,5 +149,912 @@ foo: (( values ))
 			})
 		})
 
+		Context("when given a stream", func() {
+			var streamFile *os.File
+
+			BeforeEach(func() {
+				var err error
+
+				streamFile, err = ioutil.TempFile(os.TempDir(), "stream.yml")
+				Expect(err).NotTo(HaveOccurred())
+				streamFile.Write([]byte(`
+---
+foo: (( bar ))
+bar: template
+---
+bar: first
+---
+bar: second
+`))
+			})
+
+			AfterEach(func() {
+				os.Remove(streamFile.Name())
+			})
+
+			It("uses the following documents as stubs", func() {
+				merge, err := Start(exec.Command(spiff, "merge", "--stream", streamFile.Name()), GinkgoWriter, GinkgoWriter)
+				Expect(err).NotTo(HaveOccurred())
+
+				Expect(merge.Wait()).To(Exit(0))
+				Expect(string(merge.Out.Contents())).To(Equal("bar: second\nfoo: second\n"))
+			})
+		})
+
+		Context("when given a stream with stream tags", func() {
+			var streamFile *os.File
+
+			BeforeEach(func() {
+				var err error
+
+				streamFile, err = ioutil.TempFile(os.TempDir(), "stream.yml")
+				Expect(err).NotTo(HaveOccurred())
+				streamFile.Write([]byte(`
+---
+foo: (( base::value ))
+bar: template
+---
+bar: (( base::value "-first" ))
+---
+data:
+  <<: (( &tag:base ))
+  value: second
+`))
+			})
+
+			AfterEach(func() {
+				os.Remove(streamFile.Name())
+			})
+
+			It("resets stream tags after every stub by default", func() {
+				merge, err := Start(exec.Command(spiff, "merge", "--stream", streamFile.Name()), GinkgoWriter, GinkgoWriter)
+				Expect(err).NotTo(HaveOccurred())
+
+				Expect(merge.Wait()).To(Exit(1))
+			})
+
+			It("carries stream tags with --carry-stream-state", func() {
+				merge, err := Start(exec.Command(spiff, "merge", "--stream", "--carry-stream-state", streamFile.Name()), GinkgoWriter, GinkgoWriter)
+				Expect(err).NotTo(HaveOccurred())
+
+				Expect(merge.Wait()).To(Exit(0))
+				Expect(string(merge.Out.Contents())).To(Equal("bar: second-first\nfoo: second\n"))
+			})
+		})
+
+		Context("when given tag files as URL", func() {
+			var templateFile *os.File
+			var server *httptest.Server
+
+			BeforeEach(func() {
+				var err error
+
+				templateFile, err = ioutil.TempFile(os.TempDir(), "template.yml")
+				Expect(err).NotTo(HaveOccurred())
+				templateFile.Write([]byte(`
+---
+foo: (( base::value ))
+`))
+				server = httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+					switch r.URL.Path {
+					case "/tag.yml":
+						w.Write([]byte("value: remote\n"))
+					case "/slow.yml":
+						time.Sleep(2 * time.Second)
+						w.Write([]byte("value: slow\n"))
+					default:
+						http.NotFound(w, r)
+					}
+				}))
+			})
+
+			AfterEach(func() {
+				server.Close()
+				os.Remove(templateFile.Name())
+			})
+
+			It("gets the tag file", func() {
+				merge, err := Start(exec.Command(spiff, "merge", "--allow-network", "--tag", "base:"+server.URL+"/tag.yml", templateFile.Name()), GinkgoWriter, GinkgoWriter)
+				Expect(err).NotTo(HaveOccurred())
+
+				Expect(merge.Wait()).To(Exit(0))
+				Expect(string(merge.Out.Contents())).To(Equal("foo: remote\n"))
+			})
+
+			It("reports failed requests", func() {
+				merge, err := Start(exec.Command(spiff, "merge", "--allow-network", "--tag", "base:"+server.URL+"/missing.yml", templateFile.Name()), GinkgoWriter, GinkgoWriter)
+				Expect(err).NotTo(HaveOccurred())
+
+				Expect(merge.Wait()).To(Exit(1))
+				Expect(merge.Err).To(Say("error reading tag file \\[" + server.URL + "/missing.yml\\]"))
+				Expect(merge.Err).To(Say("status 404 Not Found"))
+			})
+
+			It("reports timeouts", func() {
+				merge, err := Start(exec.Command(spiff, "merge", "--allow-network", "--http-timeout", "100ms", "--tag", "base:"+server.URL+"/slow.yml", templateFile.Name()), GinkgoWriter, GinkgoWriter)
+				Expect(err).NotTo(HaveOccurred())
+
+				Expect(merge.Wait()).To(Exit(1))
+				Expect(merge.Err).To(Say("Timeout exceeded"))
+			})
+
+			It("denies network access by default", func() {
+				merge, err := Start(exec.Command(spiff, "merge", "--tag", "base:"+server.URL+"/tag.yml", templateFile.Name()), GinkgoWriter, GinkgoWriter)
+				Expect(err).NotTo(HaveOccurred())
+
+				Expect(merge.Wait()).To(Exit(1))
+				Expect(merge.Err).To(Say(`network access disabled \(see --allow-network\)`))
+			})
+
+			It("denies network access with --offline", func() {
+				merge, err := Start(exec.Command(spiff, "merge", "--offline", "--tag", "base:"+server.URL+"/tag.yml", templateFile.Name()), GinkgoWriter, GinkgoWriter)
+				Expect(err).NotTo(HaveOccurred())
+
+				Expect(merge.Wait()).To(Exit(1))
+				Expect(merge.Err).To(Say(`network access disabled \(see --allow-network\)`))
+			})
+		})
+
+		Context("when accessing the network", func() {
+			var templateFile *os.File
+			var server *httptest.Server
+
+			BeforeEach(func() {
+				var err error
+
+				server = httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+					w.Write([]byte("remote"))
+				}))
+				templateFile, err = ioutil.TempFile(os.TempDir(), "template.yml")
+				Expect(err).NotTo(HaveOccurred())
+				templateFile.Write([]byte(`
+---
+foo: (( http_get("` + server.URL + `") ))
+`))
+			})
+
+			AfterEach(func() {
+				server.Close()
+				os.Remove(templateFile.Name())
+			})
+
+			It("denies network access by default", func() {
+				merge, err := Start(exec.Command(spiff, "merge", templateFile.Name()), GinkgoWriter, GinkgoWriter)
+				Expect(err).NotTo(HaveOccurred())
+
+				Expect(merge.Wait()).To(Exit(1))
+				Expect(merge.Err).To(Say("no network operations supported"))
+			})
+
+			It("allows network access with --allow-network", func() {
+				merge, err := Start(exec.Command(spiff, "merge", "--allow-network", templateFile.Name()), GinkgoWriter, GinkgoWriter)
+				Expect(err).NotTo(HaveOccurred())
+
+				Expect(merge.Wait()).To(Exit(0))
+				Expect(string(merge.Out.Contents())).To(Equal("foo: remote\n"))
+			})
+
+			It("rejects a combination with --offline", func() {
+				merge, err := Start(exec.Command(spiff, "merge", "--allow-network", "--offline", templateFile.Name()), GinkgoWriter, GinkgoWriter)
+				Expect(err).NotTo(HaveOccurred())
+
+				Expect(merge.Wait()).To(Exit(1))
+				Expect(merge.Err).To(Say("--allow-network and --offline cannot be combined"))
+			})
+		})
+
+		Context("when expanding paths", func() {
+			var templateFile *os.File
+			var stubFile *os.File
+
+			BeforeEach(func() {
+				var err error
+
+				templateFile, err = ioutil.TempFile(os.TempDir(), "template.yml")
+				Expect(err).NotTo(HaveOccurred())
+				templateFile.Write([]byte(`
+---
+foo: (( merge ))
+`))
+				stubFile, err = ioutil.TempFile(os.TempDir(), "stub.yml")
+				Expect(err).NotTo(HaveOccurred())
+				stubFile.Write([]byte(`
+---
+foo: stub
+`))
+			})
+
+			AfterEach(func() {
+				os.Remove(templateFile.Name())
+				os.Remove(stubFile.Name())
+			})
+
+			command := func(args ...string) *exec.Cmd {
+				cmd := exec.Command(spiff, args...)
+				cmd.Env = append(os.Environ(), "SPIFF_TEST_DIR="+filepath.Dir(stubFile.Name()))
+				return cmd
+			}
+
+			It("expands environment variables with --expand-paths", func() {
+				merge, err := Start(command("merge", "--expand-paths", templateFile.Name(), "$SPIFF_TEST_DIR/"+filepath.Base(stubFile.Name())), GinkgoWriter, GinkgoWriter)
+				Expect(err).NotTo(HaveOccurred())
+
+				Expect(merge.Wait()).To(Exit(0))
+				Expect(string(merge.Out.Contents())).To(Equal("foo: stub\n"))
+			})
+
+			It("does not expand paths by default", func() {
+				merge, err := Start(command("merge", templateFile.Name(), "$SPIFF_TEST_DIR/"+filepath.Base(stubFile.Name())), GinkgoWriter, GinkgoWriter)
+				Expect(err).NotTo(HaveOccurred())
+
+				Expect(merge.Wait()).To(Exit(1))
+				Expect(merge.Err).To(Say("SPIFF_TEST_DIR/.*: no such file or directory"))
+			})
+
+			It("expands undefined variables to empty strings", func() {
+				merge, err := Start(command("merge", "--expand-paths", templateFile.Name(), "${SPIFF_UNDEFINED_DIR}/"+filepath.Base(stubFile.Name())), GinkgoWriter, GinkgoWriter)
+				Expect(err).NotTo(HaveOccurred())
+
+				Expect(merge.Wait()).To(Exit(1))
+				Expect(merge.Err).To(Say("error reading stub \\[/" + filepath.Base(stubFile.Name()) + "\\]:.*no such file or directory"))
+			})
+		})
+
+		Context("when ordering stubs", func() {
+			var dir string
+			var templateFile string
+			var stubA string
+			var stubB string
+
+			BeforeEach(func() {
+				var err error
+
+				dir, err = ioutil.TempDir(os.TempDir(), "stubs")
+				Expect(err).NotTo(HaveOccurred())
+				templateFile = filepath.Join(dir, "template.yml")
+				stubA = filepath.Join(dir, "a.yml")
+				stubB = filepath.Join(dir, "b.yml")
+				Expect(ioutil.WriteFile(templateFile, []byte("foo: (( merge ))\n"), 0644)).To(Succeed())
+				Expect(ioutil.WriteFile(stubA, []byte("foo: a\n"), 0644)).To(Succeed())
+				Expect(ioutil.WriteFile(stubB, []byte("foo: b\n"), 0644)).To(Succeed())
+			})
+
+			AfterEach(func() {
+				os.RemoveAll(dir)
+			})
+
+			It("uses the command line order by default", func() {
+				merge, err := Start(exec.Command(spiff, "merge", templateFile, stubB, stubA), GinkgoWriter, GinkgoWriter)
+				Expect(err).NotTo(HaveOccurred())
+
+				Expect(merge.Wait()).To(Exit(0))
+				Expect(string(merge.Out.Contents())).To(Equal("foo: a\n"))
+			})
+
+			It("orders the stubs by name", func() {
+				merge, err := Start(exec.Command(spiff, "merge", "--stub-order", "name", templateFile, stubB, stubA), GinkgoWriter, GinkgoWriter)
+				Expect(err).NotTo(HaveOccurred())
+
+				Expect(merge.Wait()).To(Exit(0))
+				Expect(string(merge.Out.Contents())).To(Equal("foo: b\n"))
+			})
+
+			It("orders the stubs by modification time", func() {
+				now := time.Now()
+				Expect(os.Chtimes(stubA, now, now)).To(Succeed())
+				Expect(os.Chtimes(stubB, now.Add(-time.Hour), now.Add(-time.Hour))).To(Succeed())
+
+				merge, err := Start(exec.Command(spiff, "merge", "--stub-order", "mtime", templateFile, stubA, stubB), GinkgoWriter, GinkgoWriter)
+				Expect(err).NotTo(HaveOccurred())
+
+				Expect(merge.Wait()).To(Exit(0))
+				Expect(string(merge.Out.Contents())).To(Equal("foo: a\n"))
+			})
+
+			It("orders the stubs by an explicit list", func() {
+				merge, err := Start(exec.Command(spiff, "merge", "--stub-order", "b.yml", templateFile, stubA, stubB), GinkgoWriter, GinkgoWriter)
+				Expect(err).NotTo(HaveOccurred())
+
+				Expect(merge.Wait()).To(Exit(0))
+				Expect(string(merge.Out.Contents())).To(Equal("foo: a\n"))
+			})
+
+			It("fails for unknown stubs in the list", func() {
+				merge, err := Start(exec.Command(spiff, "merge", "--stub-order", "b.yml,c.yml", templateFile, stubA, stubB), GinkgoWriter, GinkgoWriter)
+				Expect(err).NotTo(HaveOccurred())
+
+				Expect(merge.Wait()).To(Exit(1))
+				Expect(merge.Err).To(Say(`invalid stub order: stub "c.yml" not found`))
+			})
+		})
+
+		Context("when using environment bindings", func() {
+			var templateFile *os.File
+
+			BeforeEach(func() {
+				var err error
+
+				templateFile, err = ioutil.TempFile(os.TempDir(), "template.yml")
+				Expect(err).NotTo(HaveOccurred())
+				templateFile.Write([]byte(`
+---
+a: (( FOO ))
+b: (( Bar ))
+`))
+			})
+
+			AfterEach(func() {
+				os.Remove(templateFile.Name())
+			})
+
+			command := func(args ...string) *exec.Cmd {
+				cmd := exec.Command(spiff, args...)
+				cmd.Env = append(os.Environ(), "SPIFF_FOO=env", "SPIFF_Bar=42")
+				return cmd
+			}
+
+			It("uses the prefixed environment variables", func() {
+				merge, err := Start(command("merge", "--env-prefix", "SPIFF_", templateFile.Name()), GinkgoWriter, GinkgoWriter)
+				Expect(err).NotTo(HaveOccurred())
+
+				Expect(merge.Wait()).To(Exit(0))
+				Expect(string(merge.Out.Contents())).To(Equal("a: env\nb: \"42\"\n"))
+			})
+
+			It("prefers explicit value definitions", func() {
+				merge, err := Start(command("merge", "--env-prefix", "SPIFF_", "-D", "FOO=explicit", templateFile.Name()), GinkgoWriter, GinkgoWriter)
+				Expect(err).NotTo(HaveOccurred())
+
+				Expect(merge.Wait()).To(Exit(0))
+				Expect(string(merge.Out.Contents())).To(Equal("a: explicit\nb: \"42\"\n"))
+			})
+		})
+
+		Context("when coloring the output", func() {
+			var templateFile *os.File
+
+			BeforeEach(func() {
+				var err error
+
+				templateFile, err = ioutil.TempFile(os.TempDir(), "template.yml")
+				Expect(err).NotTo(HaveOccurred())
+				templateFile.Write([]byte(`
+---
+foo: (( 1 + ))
+`))
+			})
+
+			AfterEach(func() {
+				os.Remove(templateFile.Name())
+			})
+
+			It("uses no colors for non-terminals by default", func() {
+				merge, err := Start(exec.Command(spiff, "merge", templateFile.Name()), GinkgoWriter, GinkgoWriter)
+				Expect(err).NotTo(HaveOccurred())
+
+				Expect(merge.Wait()).To(Exit(1))
+				Expect(string(merge.Err.Contents())).To(ContainSubstring("parse error near"))
+				Expect(string(merge.Err.Contents())).NotTo(ContainSubstring("\x1b["))
+			})
+
+			It("uses colors with --color always", func() {
+				merge, err := Start(exec.Command(spiff, "merge", "--color", "always", templateFile.Name()), GinkgoWriter, GinkgoWriter)
+				Expect(err).NotTo(HaveOccurred())
+
+				Expect(merge.Wait()).To(Exit(1))
+				Expect(string(merge.Err.Contents())).To(ContainSubstring("\x1b[31merror generating manifest:\x1b[0m"))
+				Expect(string(merge.Err.Contents())).To(ContainSubstring("'\x1B[34m \x1B[m'"))
+			})
+
+			It("prefers --no-color", func() {
+				merge, err := Start(exec.Command(spiff, "merge", "--color", "always", "--no-color", templateFile.Name()), GinkgoWriter, GinkgoWriter)
+				Expect(err).NotTo(HaveOccurred())
+
+				Expect(merge.Wait()).To(Exit(1))
+				Expect(string(merge.Err.Contents())).NotTo(ContainSubstring("\x1b["))
+			})
+
+			It("rejects invalid color modes", func() {
+				merge, err := Start(exec.Command(spiff, "merge", "--color", "sometimes", templateFile.Name()), GinkgoWriter, GinkgoWriter)
+				Expect(err).NotTo(HaveOccurred())
+
+				Expect(merge.Wait()).To(Exit(1))
+				Expect(merge.Err).To(Say(`invalid color mode "sometimes"`))
+			})
+		})
+
+		Context("when reporting the progress", func() {
+			var templateFile *os.File
+
+			BeforeEach(func() {
+				var err error
+
+				templateFile, err = ioutil.TempFile(os.TempDir(), "template.yml")
+				Expect(err).NotTo(HaveOccurred())
+				templateFile.Write([]byte(`
+---
+foo: (( bar ))
+bar: 1
+`))
+			})
+
+			AfterEach(func() {
+				os.Remove(templateFile.Name())
+			})
+
+			It("suppresses the progress for non-terminals", func() {
+				merge, err := Start(exec.Command(spiff, "merge", "--progress", templateFile.Name()), GinkgoWriter, GinkgoWriter)
+				Expect(err).NotTo(HaveOccurred())
+
+				Expect(merge.Wait()).To(Exit(0))
+				Expect(string(merge.Out.Contents())).To(Equal("bar: 1\nfoo: 1\n"))
+				Expect(string(merge.Err.Contents())).To(BeEmpty())
+			})
+		})
+
+		Context("when printing json", func() {
+			var templateFile *os.File
+
+			BeforeEach(func() {
+				var err error
+
+				templateFile, err = ioutil.TempFile(os.TempDir(), "template.yml")
+				Expect(err).NotTo(HaveOccurred())
+				templateFile.Write([]byte(`
+---
+- a: 1
+- b: [ 2 ]
+`))
+			})
+
+			AfterEach(func() {
+				os.Remove(templateFile.Name())
+			})
+
+			It("prints compact json by default", func() {
+				merge, err := Start(exec.Command(spiff, "merge", "--json", "--split", templateFile.Name()), GinkgoWriter, GinkgoWriter)
+				Expect(err).NotTo(HaveOccurred())
+
+				Expect(merge.Wait()).To(Exit(0))
+				Expect(string(merge.Out.Contents())).To(Equal("{\"a\":1}\n{\"b\":[2]}\n"))
+			})
+
+			It("indents json output", func() {
+				merge, err := Start(exec.Command(spiff, "merge", "--json", "--json-indent", "2", "--split", templateFile.Name()), GinkgoWriter, GinkgoWriter)
+				Expect(err).NotTo(HaveOccurred())
+
+				Expect(merge.Wait()).To(Exit(0))
+				Expect(string(merge.Out.Contents())).To(Equal("{\n  \"a\": 1\n}\n{\n  \"b\": [\n    2\n  ]\n}\n"))
+			})
+		})
+
+		Context("when reporting errors as json", func() {
+			var templateFile *os.File
+
+			BeforeEach(func() {
+				var err error
+
+				templateFile, err = ioutil.TempFile(os.TempDir(), "template.yml")
+				Expect(err).NotTo(HaveOccurred())
+				templateFile.Write([]byte(`---
+a: 1
+b: (( 1 + ))
+`))
+			})
+
+			AfterEach(func() {
+				os.Remove(templateFile.Name())
+			})
+
+			It("reports file and expression positions of syntax errors", func() {
+				merge, err := Start(exec.Command(spiff, "merge", "--check", "--error-format", "json", templateFile.Name()), GinkgoWriter, GinkgoWriter)
+				Expect(err).NotTo(HaveOccurred())
+
+				Expect(merge.Wait()).To(Exit(1))
+				Expect(merge.Err).To(Say(`"path":"b","expression":"\(\( 1 \+ \)\)","line":3,"column":4,"expr_line":1,"expr_column":5,`))
+			})
+		})
+
+		Context("when seeding from a file", func() {
+			var templateFile, seedFile *os.File
+
+			BeforeEach(func() {
+				var err error
+
+				templateFile, err = ioutil.TempFile(os.TempDir(), "template.yml")
+				Expect(err).NotTo(HaveOccurred())
+				templateFile.Write([]byte(`
+---
+value: (( random_string(16) "-" random_int(1, 1000) ))
+`))
+				seedFile, err = ioutil.TempFile(os.TempDir(), "seed")
+				Expect(err).NotTo(HaveOccurred())
+				seedFile.Write([]byte("42\n"))
+			})
+
+			AfterEach(func() {
+				os.Remove(templateFile.Name())
+				os.Remove(seedFile.Name())
+			})
+
+			It("uses the seed of the file", func() {
+				merge, err := Start(exec.Command(spiff, "merge", "--seed-from-file", seedFile.Name(), templateFile.Name()), GinkgoWriter, GinkgoWriter)
+				Expect(err).NotTo(HaveOccurred())
+				Expect(merge.Wait()).To(Exit(0))
+
+				seeded, err := Start(exec.Command(spiff, "merge", "--seed", "42", templateFile.Name()), GinkgoWriter, GinkgoWriter)
+				Expect(err).NotTo(HaveOccurred())
+				Expect(seeded.Wait()).To(Exit(0))
+				Expect(string(merge.Out.Contents())).To(Equal(string(seeded.Out.Contents())))
+			})
+
+			It("rejects a combination with --seed", func() {
+				merge, err := Start(exec.Command(spiff, "merge", "--seed", "42", "--seed-from-file", seedFile.Name(), templateFile.Name()), GinkgoWriter, GinkgoWriter)
+				Expect(err).NotTo(HaveOccurred())
+
+				Expect(merge.Wait()).To(Exit(1))
+				Expect(merge.Err).To(Say("--seed and --seed-from-file cannot be combined"))
+			})
+		})
+
+		Context("when evaluating expressions", func() {
+			var templateFile *os.File
+
+			BeforeEach(func() {
+				var err error
+
+				templateFile, err = ioutil.TempFile(os.TempDir(), "template.yml")
+				Expect(err).NotTo(HaveOccurred())
+				templateFile.Write([]byte(`
+---
+a: 1
+b: 2
+`))
+			})
+
+			AfterEach(func() {
+				os.Remove(templateFile.Name())
+			})
+
+			It("prints the raw result of a single expression", func() {
+				merge, err := Start(exec.Command(spiff, "merge", "--evaluate", "a + b", templateFile.Name()), GinkgoWriter, GinkgoWriter)
+				Expect(err).NotTo(HaveOccurred())
+
+				Expect(merge.Wait()).To(Exit(0))
+				Expect(string(merge.Out.Contents())).To(Equal("3\n"))
+			})
+
+			It("prints a map for multiple expressions", func() {
+				merge, err := Start(exec.Command(spiff, "merge", "--evaluate", "sum=a + b", "--evaluate", "a == b", templateFile.Name()), GinkgoWriter, GinkgoWriter)
+				Expect(err).NotTo(HaveOccurred())
+
+				Expect(merge.Wait()).To(Exit(0))
+				Expect(string(merge.Out.Contents())).To(Equal("a == b: false\nsum: 3\n"))
+			})
+
+			It("prints a map for a named expression", func() {
+				merge, err := Start(exec.Command(spiff, "merge", "--evaluate", "sum=a + b", templateFile.Name()), GinkgoWriter, GinkgoWriter)
+				Expect(err).NotTo(HaveOccurred())
+
+				Expect(merge.Wait()).To(Exit(0))
+				Expect(string(merge.Out.Contents())).To(Equal("sum: 3\n"))
+			})
+
+			It("rejects duplicate names", func() {
+				merge, err := Start(exec.Command(spiff, "merge", "--evaluate", "x=a", "--evaluate", "x=b", templateFile.Name()), GinkgoWriter, GinkgoWriter)
+				Expect(err).NotTo(HaveOccurred())
+
+				Expect(merge.Wait()).To(Exit(1))
+				Expect(merge.Err).To(Say(`duplicate evaluation name "x"`))
+			})
+		})
+
+		Context("when using custom expression delimiters", func() {
+			var templateFile *os.File
+
+			BeforeEach(func() {
+				var err error
+
+				templateFile, err = ioutil.TempFile(os.TempDir(), "template.yml")
+				Expect(err).NotTo(HaveOccurred())
+				templateFile.Write([]byte(`
+---
+name: alice
+greeting: hello <% name %>
+generated: value (( other )) for <% name %>
+escaped: <%! name %>
+`))
+			})
+
+			AfterEach(func() {
+				os.Remove(templateFile.Name())
+			})
+
+			It("evaluates embedded expressions with the given delimiters", func() {
+				merge, err := Start(exec.Command(spiff, "merge", "--expr-delimiters", "<% %>", templateFile.Name()), GinkgoWriter, GinkgoWriter)
+				Expect(err).NotTo(HaveOccurred())
+
+				Expect(merge.Wait()).To(Exit(0))
+				Expect(string(merge.Out.Contents())).To(Equal(`escaped: <% name %>
+generated: value (( other )) for alice
+greeting: hello alice
+name: alice
+`))
+			})
+
+			It("rejects ambiguous delimiters", func() {
+				merge, err := Start(exec.Command(spiff, "merge", "--expr-delimiters", "<< <", templateFile.Name()), GinkgoWriter, GinkgoWriter)
+				Expect(err).NotTo(HaveOccurred())
+
+				Expect(merge.Wait()).To(Exit(1))
+				Expect(merge.Err).To(Say(`must not contain each other`))
+			})
+		})
+
+		Context("when listing unresolved nodes", func() {
+			var templateFile *os.File
+
+			BeforeEach(func() {
+				var err error
+
+				templateFile, err = ioutil.TempFile(os.TempDir(), "template.yml")
+				Expect(err).NotTo(HaveOccurred())
+				templateFile.Write([]byte(`
+---
+foo: (( bar ))
+alice: 25
+list:
+  - (( missing.value ))
+`))
+			})
+
+			AfterEach(func() {
+				os.Remove(templateFile.Name())
+			})
+
+			It("prints the unresolved paths to stderr", func() {
+				merge, err := Start(exec.Command(spiff, "merge", "--partial", "--list-unresolved", templateFile.Name()), GinkgoWriter, GinkgoWriter)
+				Expect(err).NotTo(HaveOccurred())
+
+				Expect(merge.Wait()).To(Exit(0))
+				Expect(string(merge.Err.Contents())).To(Equal("- foo\n- list.[0]\n"))
+				Expect(merge.Out).To(Say(`alice: 25`))
+			})
+
+			It("prints the unresolved paths in json", func() {
+				merge, err := Start(exec.Command(spiff, "merge", "--partial", "--json", "--list-unresolved", templateFile.Name()), GinkgoWriter, GinkgoWriter)
+				Expect(err).NotTo(HaveOccurred())
+
+				Expect(merge.Wait()).To(Exit(0))
+				Expect(string(merge.Err.Contents())).To(Equal(`["foo","list.[0]"]` + "\n"))
+			})
+		})
+
+		Context("when given a state file", func() {
+			var templateFile *os.File
+			var stateDir string
+
+			BeforeEach(func() {
+				var err error
+
+				templateFile, err = ioutil.TempFile(os.TempDir(), "template.yml")
+				Expect(err).NotTo(HaveOccurred())
+				templateFile.Write([]byte(`
+---
+counter: (( &state(merge || 0) ))
+`))
+				stateDir, err = ioutil.TempDir(os.TempDir(), "state")
+				Expect(err).NotTo(HaveOccurred())
+			})
+
+			AfterEach(func() {
+				os.Remove(templateFile.Name())
+				os.RemoveAll(stateDir)
+			})
+
+			It("writes the state in the given format", func() {
+				stateFile := filepath.Join(stateDir, "state.yaml")
+				merge, err := Start(exec.Command(spiff, "merge", "--state", stateFile, "--state-format", "json", templateFile.Name()), GinkgoWriter, GinkgoWriter)
+				Expect(err).NotTo(HaveOccurred())
+
+				Expect(merge.Wait()).To(Exit(0))
+				data, err := ioutil.ReadFile(stateFile)
+				Expect(err).NotTo(HaveOccurred())
+				Expect(string(data)).To(Equal(`{"counter":0}`))
+			})
+
+			It("rejects invalid formats", func() {
+				merge, err := Start(exec.Command(spiff, "merge", "--state", filepath.Join(stateDir, "state"), "--state-format", "xml", templateFile.Name()), GinkgoWriter, GinkgoWriter)
+				Expect(err).NotTo(HaveOccurred())
+
+				Expect(merge.Wait()).To(Exit(1))
+				Expect(merge.Err).To(Say(`invalid state format "xml"`))
+			})
+		})
+	})
+
+	Context("repl", func() {
+		var stubFile *os.File
+
+		BeforeEach(func() {
+			var err error
+
+			stubFile, err = ioutil.TempFile(os.TempDir(), "stub.yml")
+			Expect(err).NotTo(HaveOccurred())
+			stubFile.Write([]byte(`
+---
+a: 1
+double: (( |x|->x * 2 ))
+`))
+		})
+
+		AfterEach(func() {
+			os.Remove(stubFile.Name())
+		})
+
+		repl := func(input string, args ...string) *Session {
+			cmd := exec.Command(spiff, append([]string{"repl"}, args...)...)
+			cmd.Stdin = strings.NewReader(input)
+			session, err := Start(cmd, GinkgoWriter, GinkgoWriter)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(session.Wait()).To(Exit(0))
+			return session
+		}
+
+		It("evaluates expressions in the context of the stubs and bindings", func() {
+			session := repl("a + b\n(( (double)(a) ))\n", "-D", "b=2", stubFile.Name())
+			Expect(string(session.Out.Contents())).To(Equal("3\n2\n"))
+		})
+
+		It("supports multi-line input", func() {
+			session := repl("join(\", \",\n  [ \"a\", \"b\" ])\n1 + \\\n2\n")
+			Expect(string(session.Out.Contents())).To(Equal("a, b\n3\n"))
+		})
+
+		It("adds bindings and stubs", func() {
+			session := repl(":set b (( a + 1 ))\n:load "+stubFile.Name()+"\n:set b 5\na + b\n:values\n:quit\n42\n", "-D", "a=10")
+			Expect(string(session.Out.Contents())).To(Equal("6\na\nb\ndouble\n"))
+			Expect(string(session.Err.Contents())).To(BeEmpty())
+		})
+
+		It("reports errors and continues", func() {
+			session := repl("unknown\n:foo\n1 +\n2\n")
+			Expect(session.Err).To(Say(`'unknown' not found`))
+			Expect(session.Err).To(Say(`unknown command ":foo"`))
+			Expect(session.Err).To(Say(`parse error`))
+			Expect(string(session.Out.Contents())).To(Equal("2\n"))
+		})
+	})
+	Context("merge with warnings", func() {
+		var templateFile *os.File
+
+		BeforeEach(func() {
+			var err error
+			templateFile, err = ioutil.TempFile(os.TempDir(), "template.yml")
+			Expect(err).NotTo(HaveOccurred())
+			templateFile.Write([]byte(`a: (( md5("x") ))
+b: (( merge || 1 ))
+`))
+			templateFile.Close()
+		})
+
+		AfterEach(func() {
+			os.Remove(templateFile.Name())
+		})
+
+		It("prints the warnings to stderr", func() {
+			session, err := Start(exec.Command(spiff, "merge", templateFile.Name()), GinkgoWriter, GinkgoWriter)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(session.Wait()).To(Exit(0))
+			Expect(string(session.Out.Contents())).To(Equal("a: 9dd4e461268c8034f5c8564e155c67a6\nb: 1\n"))
+			Expect(session.Err).To(Say(`warning: .*:1: a: function 'md5' is deprecated; use 'hash'`))
+			Expect(session.Err).To(Say(`warning: .*:2: b: no override found for 'b', using default`))
+		})
+	})
+
+	Context("merge with preserved comments", func() {
+		var templateFile *os.File
+		var stubFile *os.File
+
+		BeforeEach(func() {
+			var err error
+			templateFile, err = ioutil.TempFile(os.TempDir(), "template.yml")
+			Expect(err).NotTo(HaveOccurred())
+			templateFile.Write([]byte(`# template a
+a: 1 # template
+# template b
+b: 2
+list:
+  # first
+  - name: x # name x
+    # value x
+    value: 1
+  - z # entry z
+`))
+			templateFile.Close()
+
+			stubFile, err = ioutil.TempFile(os.TempDir(), "stub.yml")
+			Expect(err).NotTo(HaveOccurred())
+			stubFile.Write([]byte(`# stub a
+a: 3 # stub
+b: 4
+`))
+			stubFile.Close()
+		})
+
+		AfterEach(func() {
+			os.Remove(templateFile.Name())
+			os.Remove(stubFile.Name())
+		})
+
+		It("takes comments from the overriding stub and keeps comments of list entries", func() {
+			session, err := Start(exec.Command(spiff, "merge", "--preserve-comments", templateFile.Name(), stubFile.Name()), GinkgoWriter, GinkgoWriter)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(session.Wait()).To(Exit(0))
+			Expect(string(session.Out.Contents())).To(Equal(`# stub a
+a: 3 # stub
+# template b
+b: 4
+list:
+# first
+- name: x # name x
+  # value x
+  value: 1
+- z # entry z
+`))
+		})
+	})
+
+	Context("fmt", func() {
+		var templateFile *os.File
+
+		template := func(source string) {
+			var err error
+			templateFile, err = ioutil.TempFile(os.TempDir(), "template.yml")
+			Expect(err).NotTo(HaveOccurred())
+			templateFile.Write([]byte(source))
+			templateFile.Close()
+		}
+
+		AfterEach(func() {
+			os.Remove(templateFile.Name())
+		})
+
+		It("preserves comments of map keys", func() {
+			template(`# the alice section
+alice:
+  # the name
+  name: (( "bob"  ))   # not carol
+  text: |
+    some text
+`)
+			session, err := Start(exec.Command(spiff, "fmt", "--write", templateFile.Name()), GinkgoWriter, GinkgoWriter)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(session.Wait()).To(Exit(0))
+			data, err := ioutil.ReadFile(templateFile.Name())
+			Expect(err).NotTo(HaveOccurred())
+			Expect(string(data)).To(Equal(`# the alice section
+alice:
+  # the name
+  name: (( "bob" )) # not carol
+  text: |
+    some text
+`))
+		})
+
+		It("refuses to write a template with comments that would be lost", func() {
+			source := `list: [
+  a,   # not preserved
+  b ]
+`
+			template(source)
+			session, err := Start(exec.Command(spiff, "fmt", "--write", templateFile.Name()), GinkgoWriter, GinkgoWriter)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(session.Wait()).To(Exit(1))
+			Expect(session.Err).To(Say("comments not attached to map keys or list entries would be lost"))
+			data, err := ioutil.ReadFile(templateFile.Name())
+			Expect(err).NotTo(HaveOccurred())
+			Expect(string(data)).To(Equal(source))
+		})
 	})
 })
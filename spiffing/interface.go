@@ -1,6 +1,8 @@
 package spiffing
 
 import (
+	"time"
+
 	"github.com/mandelsoft/vfs/pkg/vfs"
 
 	"github.com/mandelsoft/spiff/dynaml"
@@ -17,6 +19,10 @@ const MODE_OS_ACCESS = flow.MODE_OS_ACCESS
 //  MODE_FILE_ACCESS allows file access to virtual filesystem
 const MODE_FILE_ACCESS = flow.MODE_FILE_ACCESS
 
+// MODE_NETWORK allows network access (http_get).
+// It is not part of MODE_DEFAULT and must be enabled explicitly.
+const MODE_NETWORK = flow.MODE_NETWORK
+
 // MODE_DEFAULT (default) enables all os related spiff functions
 const MODE_DEFAULT = MODE_OS_ACCESS | MODE_FILE_ACCESS
 
@@ -37,15 +43,58 @@ type Function = dynaml.Function
 // the standard control set
 type Controls = dynaml.Controls
 
+// Tracer is notified about the evaluation of dynaml expressions
+type Tracer = dynaml.Tracer
+
+// Profiler records evaluation times per dynaml function and document path
+type Profiler = dynaml.Profiler
+
+// ProfileEntry describes the evaluation times recorded for a function or path
+type ProfileEntry = dynaml.ProfileEntry
+
+// FileResolver provides the content of a file read during processing
+type FileResolver = flow.FileResolver
+
+// NodeError describes an unresolved node of a processed document
+// (see ParseError, EvaluationError and CycleError)
+type NodeError = flow.NodeError
+
+// ParseError describes a node with a dynaml expression that cannot be parsed
+type ParseError = flow.ParseError
+
+// EvaluationError describes a node whose evaluation failed or stays unresolved
+type EvaluationError = flow.EvaluationError
+
+// CycleError describes a node waiting for a reference cycle
+type CycleError = flow.CycleError
+
+// Errors is the error returned by a processing for documents with
+// unresolved nodes, providing a NodeError for every such node
+type Errors = flow.Errors
+
+// Warning describes a non-fatal issue detected during a processing
+type Warning = flow.Warning
+
+// PostProcessor transforms the result of a processing
+type PostProcessor func(Node) (Node, error)
+
 // Spiff is a configuration and execution context for
 // executing spiff operations
 type Spiff interface {
 	// WithEncryptionKey creates a new context with
 	// dedicated encryption key used for the spiff encryption feature
 	WithEncryptionKey(key string) Spiff
+	// WithEncryptionMethod creates a new context with
+	// a dedicated default encryption method used by the
+	// encrypt function (for example AES-GCM).
+	WithEncryptionMethod(method string) Spiff
 	// WithMode creates a new context with the given processing mode.
 	// (see MODE constants)
 	WithMode(mode int) Spiff
+	// WithHTTPLimits creates a new context with the given timeout and
+	// maximum response size for the http_get functions (enabled
+	// by MODE_NETWORK). Values <= 0 select the defaults.
+	WithHTTPLimits(timeout time.Duration, maxSize int64) Spiff
 	// WithFileSystem creates a new context with the given
 	// virtual filesystem used for filesystem functions during
 	// prcessing. Setting a filesystem disables the command
@@ -58,12 +107,51 @@ type Spiff interface {
 	// additional function definitions
 	WithControls(controls Controls) Spiff
 
+	// WithRandomSeed creates a new context using a pseudo random
+	// number generator with the given seed for the random functions.
+	// Every processing then yields reproducible random values.
+	WithRandomSeed(seed int64) Spiff
+	// WithRandomSeedFromFile creates a new context using a pseudo random
+	// number generator seeded by the content of the given file read from
+	// the configured filesystem (see flow.ParseRandomSeed).
+	WithRandomSeedFromFile(path string) (Spiff, error)
+
+	// WithIncludeDirs creates a new context using the given
+	// search path to resolve relative file names for the
+	// include function.
+	WithIncludeDirs(dirs ...string) Spiff
+
+	// WithTracer creates a new context notifying the given
+	// tracer about every evaluated dynaml expression.
+	WithTracer(tracer Tracer) Spiff
+
+	// WithProfiler creates a new context recording the evaluation
+	// times of functions and document paths in the given profiler.
+	WithProfiler(profiler *Profiler) Spiff
+
+	// WithFileResolver creates a new context using the given resolver
+	// to provide the content of files read by the file functions
+	// instead of the filesystem. File access must still be enabled
+	// by the processing mode.
+	WithFileResolver(resolver FileResolver) Spiff
+
+	// WithPostProcessor creates a new context additionally applying
+	// the given post processor to the results of Cascade and ApplyStubs.
+	// Post processors are applied in registration order.
+	WithPostProcessor(p PostProcessor) Spiff
+
 	// WithFeatures creates a new context with the given
 	// additional features enabled
 	WithFeatures(features ...string) Spiff
 	// WithInterpolation creates a new context with the interpolation
 	// feature enabled/disabled
 	WithInterpolation(b bool) Spiff
+	// WithInterpolationDelimiters creates a new context using the given
+	// opening and closing delimiters (instead of "((" and "))") to detect
+	// expressions embedded in strings if the interpolation feature is
+	// enabled. The delimiters must not be empty, must not contain white
+	// space, quotes, backslashes or "!", and must not contain each other.
+	WithInterpolationDelimiters(open, close string) (Spiff, error)
 	// WithControl creates a new context with the yaml based control structure
 	// feature enabled/disabled
 	WithControl(b bool) Spiff
@@ -75,6 +163,11 @@ type Spiff interface {
 	// value (like `values`) to minimize the blocked root
 	// elements in the processed documents.
 	WithValues(values map[string]interface{}) (Spiff, error)
+	// SetValue creates a new context with an additional value
+	// usable by path expressions during processing. In contrast
+	// to WithValues the value is given as node and the other
+	// values are kept.
+	SetValue(name string, value Node) Spiff
 
 	// SetTag sets/resets a tag for subsequent processings.
 	// This can be used to set implicit document tags
@@ -85,6 +178,16 @@ type Spiff interface {
 
 	// CleanupTags deletes tags of spiff context
 	CleanupTags() Spiff
+	// Clone creates an independent copy of the context with a
+	// reset binding state, which can be used concurrently to the
+	// original context and other clones.
+	// Values, tags, features and options are copied. The function and
+	// control registry, the filesystem, the tracer, the profiler, the
+	// file resolver and the post processors are shared. Therefore
+	// functions registered later for a Functions set already passed
+	// to WithFunctions are visible for all clones. Clone specific
+	// functions should be added with WithFunctions on the clone.
+	Clone() Spiff
 	// Reset flushes the binding state
 	Reset() Spiff
 	// ResetStream flushes the document history
@@ -124,6 +227,13 @@ type Spiff interface {
 	// The document stream history (implicit tags) is resetted prior
 	// to the execution.
 	Cascade(template Node, stubs []Node, states ...Node) (Node, error)
+	// MustCascade works like Cascade, but panics if the processing fails.
+	MustCascade(template Node, stubs []Node, states ...Node) Node
+	// ApplyWithState processes a template with a list of given stubs
+	// and an optional state document (nil for none) kept in memory.
+	// It returns the processing result and the new state extracted
+	// from it (see DetermineState).
+	ApplyWithState(template Node, stubs []Node, state Node) (Node, Node, error)
 	// PrepareStubs processes a list a stubs and returns a prepared
 	// represenation usable to process a template.
 	// The document stream history (implicit tags) is resetted prior
@@ -136,6 +246,11 @@ type Spiff interface {
 	// true. In this case every call add an entry to the document
 	// history.
 	ApplyStubs(template Node, preparedstubs []Node, stream ...bool) (Node, error)
+	// Warnings returns the non-fatal issues, like the usage of deprecated
+	// functions, fields defaulted because of missing overrides or references
+	// to empty tags, detected by the last Cascade, PrepareStubs or
+	// ApplyStubs call.
+	Warnings() []Warning
 }
 
 // Source is used to get access to a template or stub source data and name
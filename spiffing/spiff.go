@@ -5,6 +5,10 @@
 package spiffing
 
 import (
+	"fmt"
+	"io"
+	"time"
+
 	"github.com/mandelsoft/vfs/pkg/osfs"
 	"github.com/mandelsoft/vfs/pkg/vfs"
 
@@ -62,6 +66,7 @@ func (s *sourceData) Data() ([]byte, error) {
 
 type spiff struct {
 	key      string
+	method   string
 	mode     int
 	fs       vfs.FileSystem
 	opts     flow.Options
@@ -69,8 +74,18 @@ type spiff struct {
 	registry dynaml.Registry
 	tags     map[string]*dynaml.Tag
 	features features.FeatureFlags
+	seed     *int64
+	timeout  time.Duration
+	maxSize  int64
+	includes []string
+	tracer   Tracer
+	profiler *Profiler
+	resolver FileResolver
+	post     []PostProcessor
+	delims   yaml.Delimiters
 
-	binding dynaml.Binding
+	binding  dynaml.Binding
+	warnings []Warning
 }
 
 // NewFunctions provides a new registry for additional spiff functions
@@ -78,6 +93,17 @@ func NewFunctions() Functions {
 	return dynaml.NewFunctions()
 }
 
+// NewTracer provides a tracer writing the evaluated expressions
+// to the given writer, optionally restricted to the given path prefixes.
+func NewTracer(w io.Writer, prefixes ...string) Tracer {
+	return dynaml.NewTracer(w, prefixes...)
+}
+
+// NewProfiler provides a profiler to record evaluation times.
+func NewProfiler() *Profiler {
+	return dynaml.NewProfiler()
+}
+
 // NewControls provides a new registry for additional spiff controls
 func NewControls() Controls {
 	return dynaml.NewControls()
@@ -121,7 +147,25 @@ func (s *spiff) assureBinding() {
 	if s.binding == nil {
 		state := flow.NewState(s.key, s.mode, s.fs).
 			SetRegistry(s.registry).
-			SetFeatures(s.features)
+			SetFeatures(s.features).
+			SetEncryptionMethod(s.method).
+			SetHTTPLimits(s.timeout, s.maxSize).
+			SetInterpolationDelimiters(s.delims)
+		if s.seed != nil {
+			state.SetRandomSeed(*s.seed)
+		}
+		if len(s.includes) > 0 {
+			state.SetIncludeDirs(s.includes...)
+		}
+		if s.tracer != nil {
+			state.SetTracer(s.tracer)
+		}
+		if s.profiler != nil {
+			state.SetProfiler(s.profiler)
+		}
+		if s.resolver != nil {
+			state.SetFileResolver(s.resolver)
+		}
 		if len(s.tags) > 0 {
 			var tags []*dynaml.Tag
 			for _, t := range s.tags {
@@ -144,6 +188,17 @@ func (s spiff) WithInterpolation(b bool) Spiff {
 	return s.Reset()
 }
 
+// WithInterpolationDelimiters creates a new context using the given
+// delimiters for expressions embedded in strings.
+func (s spiff) WithInterpolationDelimiters(open, close string) (Spiff, error) {
+	d := yaml.Delimiters{Open: open, Close: close}
+	if err := d.Validate(); err != nil {
+		return nil, err
+	}
+	s.delims = d
+	return s.Reset(), nil
+}
+
 // WithControl creates a new context with
 // enabled/disabled yaml based control structure feature
 func (s spiff) WithControl(b bool) Spiff {
@@ -167,6 +222,76 @@ func (s spiff) WithEncryptionKey(key string) Spiff {
 	return s.Reset()
 }
 
+// WithEncryptionMethod creates a new context with
+// dedicated default encryption method used for the spiff encryption feature
+func (s spiff) WithEncryptionMethod(method string) Spiff {
+	s.method = method
+	return s.Reset()
+}
+
+// WithRandomSeed creates a new context using a pseudo random
+// number generator with the given seed for the random functions.
+func (s spiff) WithRandomSeed(seed int64) Spiff {
+	s.seed = &seed
+	return s.Reset()
+}
+
+// WithRandomSeedFromFile creates a new context using a pseudo random
+// number generator seeded by the content of the given file.
+func (s spiff) WithRandomSeedFromFile(path string) (Spiff, error) {
+	fs := s.fs
+	if fs == nil {
+		fs = osfs.New()
+	}
+	data, err := vfs.ReadFile(fs, path)
+	if err != nil {
+		return nil, err
+	}
+	seed, err := flow.ParseRandomSeed(data)
+	if err != nil {
+		return nil, fmt.Errorf("%s: %s", path, err)
+	}
+	return s.WithRandomSeed(seed), nil
+}
+
+// WithIncludeDirs creates a new context using the given
+// search path for the include function.
+func (s spiff) WithIncludeDirs(dirs ...string) Spiff {
+	s.includes = append([]string{}, dirs...)
+	return s.Reset()
+}
+
+// WithTracer creates a new context notifying the given
+// tracer about every evaluated dynaml expression.
+func (s spiff) WithTracer(tracer Tracer) Spiff {
+	s.tracer = tracer
+	return s.Reset()
+}
+
+// WithProfiler creates a new context recording the evaluation
+// times of functions and document paths in the given profiler.
+func (s spiff) WithProfiler(profiler *Profiler) Spiff {
+	s.profiler = profiler
+	return s.Reset()
+}
+
+// WithFileResolver creates a new context using the given resolver
+// to provide the content of files read by the file functions
+// instead of the filesystem. File access must still be enabled
+// by the processing mode.
+func (s spiff) WithFileResolver(resolver FileResolver) Spiff {
+	s.resolver = resolver
+	return s.Reset()
+}
+
+// WithPostProcessor creates a new context additionally applying
+// the given post processor to the results of Cascade and ApplyStubs.
+// Post processors are applied in registration order.
+func (s spiff) WithPostProcessor(p PostProcessor) Spiff {
+	s.post = append(s.post[:len(s.post):len(s.post)], p)
+	return s.Reset()
+}
+
 // WithMode creates a new context with the given processing mode.
 // (see MODE constants)
 func (s spiff) WithMode(mode int) Spiff {
@@ -177,6 +302,14 @@ func (s spiff) WithMode(mode int) Spiff {
 	return s.Reset()
 }
 
+// WithHTTPLimits creates a new context with the given timeout and
+// maximum response size for the http_get functions.
+func (s spiff) WithHTTPLimits(timeout time.Duration, maxSize int64) Spiff {
+	s.timeout = timeout
+	s.maxSize = maxSize
+	return s.Reset()
+}
+
 // WithFileSystem creates a new context with the given
 // virtual filesystem used for filesystem functions during
 // prcessing. Setting a filesystem disables the command
@@ -222,8 +355,23 @@ func (s spiff) WithValues(values map[string]interface{}) (Spiff, error) {
 	return s.Reset(), nil
 }
 
+// SetValue creates a new context with an additional value
+// usable by path expressions during processing.
+func (s spiff) SetValue(name string, value Node) Spiff {
+	values := map[string]yaml.Node{}
+	for k, v := range s.values {
+		values[k] = v
+	}
+	values[name] = value
+	s.values = values
+	return s.Reset()
+}
+
 // SetTag sets/resets a global tag for subsequent processings.
 func (s spiff) SetTag(tag string, node yaml.Node) Spiff {
+	if s.tags == nil {
+		s.tags = map[string]*dynaml.Tag{}
+	}
 	s.tags[tag] = dynaml.NewTag(tag, node, nil, dynaml.TAG_SCOPE_GLOBAL)
 	return s.Reset()
 }
@@ -245,13 +393,71 @@ func (s *spiff) CleanupTags() Spiff {
 	return s
 }
 
+// Clone creates an independent copy of the context with a reset
+// binding state. The function registry and other configuration
+// objects provided by the caller are shared (see Spiff.Clone).
+func (s *spiff) Clone() Spiff {
+	n := *s
+	n.binding = nil
+	n.warnings = nil
+	if s.values != nil {
+		n.values = map[string]yaml.Node{}
+		for k, v := range s.values {
+			n.values[k] = v
+		}
+	}
+	n.tags = map[string]*dynaml.Tag{}
+	for k, v := range s.tags {
+		n.tags[k] = v
+	}
+	n.features = features.FeatureFlags{}
+	for k, v := range s.features {
+		n.features[k] = v
+	}
+	if s.opts.Provenance != nil {
+		n.opts.Provenance = map[string]string{}
+		for k, v := range s.opts.Provenance {
+			n.opts.Provenance[k] = v
+		}
+	}
+	n.includes = append([]string{}, s.includes...)
+	n.post = append([]PostProcessor{}, s.post...)
+	return &n
+}
+
 // Cascade processes a template with a list of given subs and state
 // documents
 func (s *spiff) Cascade(template Node, stubs []Node, states ...Node) (Node, error) {
 	s.Reset()
 	s.assureBinding()
 	defer s.Reset()
-	return flow.Cascade(s.binding, template, s.opts, append(stubs, states...)...)
+	result, err := flow.Cascade(s.binding, template, s.opts, append(stubs, states...)...)
+	s.collectWarnings()
+	return s.postProcess(result, err)
+}
+
+// MustCascade processes a template like Cascade, but
+// panics if the processing fails
+func (s *spiff) MustCascade(template Node, stubs []Node, states ...Node) Node {
+	result, err := s.Cascade(template, stubs, states...)
+	if err != nil {
+		panic(err)
+	}
+	return result
+}
+
+// ApplyWithState processes a template with a list of given stubs and
+// an optional in-memory state and returns the result and the new state.
+func (s *spiff) ApplyWithState(template Node, stubs []Node, state Node) (Node, Node, error) {
+	var states []Node
+	if state != nil {
+		states = append(states, state)
+	}
+	result, err := s.Cascade(template, stubs, states...)
+	if err != nil {
+		return result, nil, err
+	}
+	return result, flow.Cleanup(result, flow.DiscardNonState), nil
 }
 
 // PrepareStubs processes a list a stubs and returns a prepared
@@ -261,7 +467,9 @@ func (s *spiff) Cascade(template Node, stubs []Node, states ...Node) (Node, erro
 func (s *spiff) PrepareStubs(stubs ...Node) ([]Node, error) {
 	s.Reset()
 	s.assureBinding()
-	return flow.PrepareStubs(s.binding, s.opts.Partial, stubs...)
+	prepared, err := flow.PrepareStubs(s.binding, s.opts.Partial, stubs...)
+	s.collectWarnings()
+	return prepared, err
 }
 
 // ApplyStubs uses already prepared subs to process a template.
@@ -271,13 +479,47 @@ func (s *spiff) ApplyStubs(template Node, preparedstubs []Node, stream ...bool)
 	if len(stream) == 0 || !stream[0] {
 		s.ResetStream()
 	}
-	return flow.Apply(s.binding, template, preparedstubs, s.opts)
+	if state, ok := s.binding.GetState().(*flow.State); ok {
+		state.ResetRandom()
+	}
+	result, err := flow.Apply(s.binding, template, preparedstubs, s.opts)
+	s.collectWarnings()
+	return s.postProcess(result, err)
+}
+
+// Warnings returns the non-fatal issues detected by the last
+// processing.
+func (s *spiff) Warnings() []Warning {
+	return append([]Warning{}, s.warnings...)
+}
+
+// collectWarnings takes over the warnings recorded by the state
+// of the actual binding.
+func (s *spiff) collectWarnings() {
+	s.warnings = nil
+	if state, ok := s.binding.GetState().(*flow.State); ok {
+		s.warnings = state.Warnings()
+		state.ResetWarnings()
+	}
+}
+
+func (s *spiff) postProcess(node Node, err error) (Node, error) {
+	if err != nil {
+		return node, err
+	}
+	for _, p := range s.post {
+		node, err = p(node)
+		if err != nil {
+			return nil, err
+		}
+	}
+	return node, nil
 }
 
 // Unmarshal parses a single document yaml representation and
 // returns the internal representation
 func (s *spiff) Unmarshal(name string, source []byte) (Node, error) {
-	return yaml.Unmarshal(name, source)
+	return yaml.ParseWithPositions(name, source)
 }
 
 // Unmarshal parses a single source and
@@ -287,13 +529,13 @@ func (s *spiff) UnmarshalSource(source Source) (Node, error) {
 	if err != nil {
 		return nil, err
 	}
-	return yaml.Unmarshal(source.Name(), data)
+	return yaml.ParseWithPositions(source.Name(), data)
 }
 
 // UnmarshalMulti parses a multi document yaml representation and
 // returns the list of documents in the internal representation
 func (s *spiff) UnmarshalMulti(name string, source []byte) ([]Node, error) {
-	return yaml.UnmarshalMulti(name, source)
+	return yaml.ParseMultiWithPositions(name, source)
 }
 
 // UnmarshalMulti parses a multi document source and
@@ -303,7 +545,7 @@ func (s *spiff) UnmarshalMultiSource(source Source) ([]Node, error) {
 	if err != nil {
 		return nil, err
 	}
-	return yaml.UnmarshalMulti(source.Name(), data)
+	return yaml.ParseMultiWithPositions(source.Name(), data)
 }
 
 // DetermineState extracts the intended new state representation from
@@ -1,10 +1,24 @@
 package spiffing
 
 import (
+	"bytes"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"sync"
+	"time"
+
 	. "github.com/onsi/ginkgo"
 	. "github.com/onsi/gomega"
 
+	"github.com/mandelsoft/vfs/pkg/memoryfs"
+	"github.com/mandelsoft/vfs/pkg/vfs"
+
 	"github.com/mandelsoft/spiff/dynaml"
+	"github.com/mandelsoft/spiff/yaml"
 )
 
 var _ = Describe("Spiffing", func() {
@@ -37,6 +51,656 @@ var _ = Describe("Spiffing", func() {
 		})
 	})
 
+	Context("with cached expressions", func() {
+		It("does not reuse cached values for further documents", func() {
+			count := 0
+			funcs := NewFunctions()
+			funcs.RegisterFunction("counter", func(arguments []interface{}, binding dynaml.Binding) (interface{}, dynaml.EvaluationInfo, bool) {
+				count++
+				return int64(count), dynaml.DefaultInfo(), true
+			})
+			ctx := New().WithFunctions(funcs)
+			docs, err := ctx.UnmarshalMulti("test", []byte(`
+spec: a
+v: (( &cached(spec "-x") ))
+c: (( &cached(counter()) ))
+---
+spec: b
+v: (( &cached(spec "-x") ))
+c: (( &cached(counter()) ))
+`))
+			Expect(err).To(Succeed())
+			Expect(len(docs)).To(Equal(2))
+			expected := []string{"c: 1\nspec: a\nv: a-x\n", "c: 2\nspec: b\nv: b-x\n"}
+			for i, doc := range docs {
+				result, err := ctx.Cascade(doc, nil)
+				Expect(err).To(Succeed())
+				data, err := ctx.Marshal(result)
+				Expect(err).To(Succeed())
+				Expect(string(data)).To(Equal(expected[i]))
+			}
+			Expect(count).To(Equal(2))
+		})
+
+		It("reuses cached values for the same inputs", func() {
+			count := 0
+			funcs := NewFunctions()
+			funcs.RegisterFunction("counter", func(arguments []interface{}, binding dynaml.Binding) (interface{}, dynaml.EvaluationInfo, bool) {
+				count++
+				return arguments[0], dynaml.DefaultInfo(), true
+			})
+			ctx := New().WithFunctions(funcs)
+			templ, err := ctx.Unmarshal("test", []byte(`
+tmpl:
+  <<: (( &template &temporary ))
+  v: (( &cached(counter(x)) ))
+list: (( map[[1,1,2]|x|->*tmpl] ))
+`))
+			Expect(err).To(Succeed())
+			result, err := ctx.Cascade(templ, nil)
+			Expect(err).To(Succeed())
+			data, err := ctx.Marshal(result)
+			Expect(err).To(Succeed())
+			Expect(string(data)).To(Equal("list:\n- v: 1\n- v: 1\n- v: 2\n"))
+			Expect(count).To(Equal(2))
+		})
+	})
+
+	Context("with once expressions", func() {
+		var count int
+		var ctx Spiff
+
+		BeforeEach(func() {
+			count = 0
+			funcs := NewFunctions()
+			funcs.RegisterFunction("counter", func(arguments []interface{}, binding dynaml.Binding) (interface{}, dynaml.EvaluationInfo, bool) {
+				count++
+				return int64(count), dynaml.DefaultInfo(), true
+			})
+			ctx = New().WithFunctions(funcs)
+		})
+
+		It("does not evaluate the expression for provided values", func() {
+			templ, err := ctx.Unmarshal("template", []byte(`
+a: (( &once(counter()) ))
+b: (( &state(counter()) ))
+`))
+			Expect(err).To(Succeed())
+			stub, err := ctx.Unmarshal("state", []byte(`
+a: 10
+b: 20
+`))
+			Expect(err).To(Succeed())
+			result, err := ctx.Cascade(templ, []Node{stub})
+			Expect(err).To(Succeed())
+			data, err := ctx.Marshal(result)
+			Expect(err).To(Succeed())
+			Expect(string(data)).To(Equal("a: 10\nb: 20\n"))
+			Expect(count).To(Equal(1))
+		})
+
+		It("evaluates the expression without provided value", func() {
+			templ, err := ctx.Unmarshal("template", []byte(`
+a: (( &once(counter()) ))
+`))
+			Expect(err).To(Succeed())
+			result, err := ctx.Cascade(templ, nil)
+			Expect(err).To(Succeed())
+			data, err := ctx.Marshal(result)
+			Expect(err).To(Succeed())
+			Expect(string(data)).To(Equal("a: 1\n"))
+			Expect(count).To(Equal(1))
+		})
+	})
+
+	Context("with state", func() {
+		It("keeps the state in memory", func() {
+			ctx := New()
+			templ, err := ctx.Unmarshal("template", []byte(`
+counter: (( &state(merge || 0) ))
+next: (( counter + 1 ))
+`))
+			Expect(err).To(Succeed())
+
+			result, state, err := ctx.ApplyWithState(templ, nil, nil)
+			Expect(err).To(Succeed())
+			data, err := ctx.Marshal(state)
+			Expect(err).To(Succeed())
+			Expect(string(data)).To(Equal("counter: 0\n"))
+
+			state, err = ctx.Unmarshal("state", []byte("counter: 5\n"))
+			Expect(err).To(Succeed())
+			result, state, err = ctx.ApplyWithState(templ, nil, state)
+			Expect(err).To(Succeed())
+			data, err = ctx.Marshal(result)
+			Expect(err).To(Succeed())
+			Expect(string(data)).To(Equal("counter: 5\nnext: 6\n"))
+			data, err = ctx.Marshal(state)
+			Expect(err).To(Succeed())
+			Expect(string(data)).To(Equal("counter: 5\n"))
+		})
+	})
+
+	Context("with once expressions", func() {
+		It("generates state values only if absent", func() {
+			count := 0
+			funcs := NewFunctions()
+			funcs.RegisterFunction("counter", func(arguments []interface{}, binding dynaml.Binding) (interface{}, dynaml.EvaluationInfo, bool) {
+				count++
+				return int64(count), dynaml.DefaultInfo(), true
+			})
+			ctx := New().WithFunctions(funcs)
+			templ, err := ctx.Unmarshal("template", []byte("value: (( &once(counter()) ))\n"))
+			Expect(err).To(Succeed())
+
+			_, state, err := ctx.ApplyWithState(templ, nil, nil)
+			Expect(err).To(Succeed())
+			data, err := ctx.Marshal(state)
+			Expect(err).To(Succeed())
+			Expect(string(data)).To(Equal("value: 1\n"))
+
+			result, state, err := ctx.ApplyWithState(templ, nil, state)
+			Expect(err).To(Succeed())
+			data, err = ctx.Marshal(result)
+			Expect(err).To(Succeed())
+			Expect(string(data)).To(Equal("value: 1\n"))
+			data, err = ctx.Marshal(state)
+			Expect(err).To(Succeed())
+			Expect(string(data)).To(Equal("value: 1\n"))
+			Expect(count).To(Equal(1))
+		})
+	})
+
+	Context("with network access", func() {
+		var server *httptest.Server
+
+		BeforeEach(func() {
+			server = httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				switch r.URL.Path {
+				case "/text":
+					w.Write([]byte("some text"))
+				case "/json":
+					w.Write([]byte(`{"alice": 25, "list": [1, 2]}`))
+				case "/slow":
+					time.Sleep(time.Second)
+					w.Write([]byte("slow"))
+				default:
+					http.NotFound(w, r)
+				}
+			}))
+		})
+
+		AfterEach(func() {
+			server.Close()
+		})
+
+		process := func(ctx Spiff, expr string) (string, error) {
+			templ, err := ctx.Unmarshal("test", []byte(fmt.Sprintf("value: (( %s ))\n", expr)))
+			Expect(err).To(Succeed())
+			result, err := ctx.Cascade(templ, nil)
+			if err != nil {
+				return "", err
+			}
+			data, err := ctx.Marshal(result)
+			Expect(err).To(Succeed())
+			return string(data), nil
+		}
+
+		It("denies access by default", func() {
+			_, err := process(New(), fmt.Sprintf("http_get(%q)", server.URL+"/text"))
+			Expect(err).To(HaveOccurred())
+			Expect(err.Error()).To(ContainSubstring("http_get: no network operations supported in this execution environment"))
+		})
+
+		It("gets text", func() {
+			ctx := New().WithMode(MODE_DEFAULT | MODE_NETWORK)
+			Expect(process(ctx, fmt.Sprintf("http_get(%q)", server.URL+"/text"))).To(Equal("value: some text\n"))
+		})
+
+		It("gets json", func() {
+			ctx := New().WithMode(MODE_DEFAULT | MODE_NETWORK)
+			Expect(process(ctx, fmt.Sprintf("http_get_json(%q).list.[1]", server.URL+"/json"))).To(Equal("value: 2\n"))
+		})
+
+		It("reports failed requests", func() {
+			ctx := New().WithMode(MODE_DEFAULT | MODE_NETWORK)
+			_, err := process(ctx, fmt.Sprintf("http_get(%q)", server.URL+"/missing"))
+			Expect(err).To(HaveOccurred())
+			Expect(err.Error()).To(ContainSubstring("failed with status 404 Not Found"))
+		})
+
+		It("limits the response size", func() {
+			ctx := New().WithMode(MODE_DEFAULT|MODE_NETWORK).WithHTTPLimits(0, 4)
+			_, err := process(ctx, fmt.Sprintf("http_get(%q)", server.URL+"/text"))
+			Expect(err).To(HaveOccurred())
+			Expect(err.Error()).To(ContainSubstring("exceeds 4 bytes"))
+		})
+
+		It("reports timeouts", func() {
+			ctx := New().WithMode(MODE_DEFAULT|MODE_NETWORK).WithHTTPLimits(100*time.Millisecond, 0)
+			_, err := process(ctx, fmt.Sprintf("http_get(%q)", server.URL+"/slow"))
+			Expect(err).To(HaveOccurred())
+			Expect(err.Error()).To(ContainSubstring("Timeout exceeded"))
+		})
+
+		It("denies reading urls by default", func() {
+			_, err := process(New(), fmt.Sprintf("read(%q, \"text\")", server.URL+"/text"))
+			Expect(err).To(HaveOccurred())
+			Expect(err.Error()).To(ContainSubstring("no network operations supported in this execution environment"))
+		})
+
+		It("reads urls respecting the limits", func() {
+			ctx := New().WithMode(MODE_DEFAULT | MODE_NETWORK)
+			Expect(process(ctx, fmt.Sprintf("read(%q, \"text\")", server.URL+"/text"))).To(Equal("value: some text\n"))
+
+			ctx = New().WithMode(MODE_DEFAULT|MODE_NETWORK).WithHTTPLimits(0, 4)
+			_, err := process(ctx, fmt.Sprintf("read(%q, \"text\")", server.URL+"/text"))
+			Expect(err).To(HaveOccurred())
+			Expect(err.Error()).To(ContainSubstring("exceeds 4 bytes"))
+		})
+	})
+
+	Context("with warnings", func() {
+		It("reports non-fatal issues", func() {
+			ctx := New().SetTag("empty", yaml.NewNode(map[string]yaml.Node{}, "test"))
+			templ, err := ctx.Unmarshal("test", []byte(`
+mult: (( |x,y|-> x * y ))
+double: (( .mult(2) ))
+value: (( .double(3) ))
+caught: (( catch(value) ))
+empty: (( empty::. ))
+`))
+			Expect(err).To(Succeed())
+			_, err = ctx.Cascade(templ, nil)
+			Expect(err).To(Succeed())
+			messages := map[string]string{}
+			for _, w := range ctx.Warnings() {
+				messages[w.Path] = w.Message
+			}
+			Expect(messages).To(Equal(map[string]string{
+				"double": "implicit currying of lambda with 1 of 2 arguments is deprecated, use the currying operator",
+				"caught": "catch without lambda is deprecated, use catch(expr, lambda)",
+				"empty":  "tag 'empty' is empty",
+			}))
+		})
+
+		It("keeps only the warnings of the last processing", func() {
+			ctx := New()
+			templ, err := ctx.Unmarshal("test", []byte("hash: (( md5(\"alice\") ))\n"))
+			Expect(err).To(Succeed())
+			_, err = ctx.Cascade(templ, nil)
+			Expect(err).To(Succeed())
+			Expect(ctx.Warnings()).To(HaveLen(1))
+			Expect(ctx.Warnings()[0].String()).To(Equal(`test:1: hash: function 'md5' is deprecated; use 'hash'`))
+
+			templ, err = ctx.Unmarshal("test", []byte("hash: (( hash(\"alice\", \"md5\") ))\n"))
+			Expect(err).To(Succeed())
+			_, err = ctx.Cascade(templ, nil)
+			Expect(err).To(Succeed())
+			Expect(ctx.Warnings()).To(BeEmpty())
+		})
+	})
+
+	Context("with interpolation delimiters", func() {
+		It("evaluates embedded expressions", func() {
+			ctx, err := New().WithInterpolation(true).WithInterpolationDelimiters("${", "}")
+			Expect(err).To(Succeed())
+			templ, err := ctx.Unmarshal("test", []byte("name: alice\ngreeting: hello ${name}, (( name ))\n"))
+			Expect(err).To(Succeed())
+			result, err := ctx.Cascade(templ, nil)
+			Expect(err).To(Succeed())
+			Expect(ctx.Marshal(result)).To(Equal([]byte("greeting: hello alice, (( name ))\nname: alice\n")))
+		})
+
+		It("rejects invalid delimiters", func() {
+			_, err := New().WithInterpolationDelimiters("${", "${")
+			Expect(err).To(HaveOccurred())
+		})
+	})
+
+	Context("with clones", func() {
+		It("does not share modifications", func() {
+			base, err := New().WithValues(map[string]interface{}{"values": map[string]interface{}{"alice": 25}})
+			Expect(err).To(Succeed())
+			clone := base.Clone()
+			clone, err = clone.WithValues(map[string]interface{}{"values": map[string]interface{}{"alice": 26}})
+			Expect(err).To(Succeed())
+			clone.WithInterpolation(true)
+
+			templ, err := base.Unmarshal("test", []byte("age: (( values.alice ))\n"))
+			Expect(err).To(Succeed())
+			result, err := base.Cascade(templ, nil)
+			Expect(err).To(Succeed())
+			Expect(base.Marshal(result)).To(Equal([]byte("age: 25\n")))
+			result, err = clone.Cascade(templ, nil)
+			Expect(err).To(Succeed())
+			Expect(clone.Marshal(result)).To(Equal([]byte("age: 26\n")))
+			Expect(base.(*spiff).features.InterpolationEnabled()).To(BeFalse())
+		})
+
+		It("shares the functions", func() {
+			funcs := NewFunctions()
+			base := New().WithFunctions(funcs)
+			clone := base.Clone()
+			funcs.RegisterFunction("late", func(arguments []interface{}, binding dynaml.Binding) (interface{}, dynaml.EvaluationInfo, bool) {
+				return "late", dynaml.DefaultInfo(), true
+			})
+			templ, err := clone.Unmarshal("test", []byte("value: (( late() ))\n"))
+			Expect(err).To(Succeed())
+			result, err := clone.Cascade(templ, nil)
+			Expect(err).To(Succeed())
+			Expect(clone.Marshal(result)).To(Equal([]byte("value: late\n")))
+		})
+
+		It("can be used concurrently", func() {
+			base := New().SetTag("base", yaml.NewNode(map[string]yaml.Node{"value": yaml.NewNode("tagged", "test")}, "test"))
+			templ, err := base.Unmarshal("test", []byte("value: (( base::value ))\nother: (( &tag:local(1) ))\n"))
+			Expect(err).To(Succeed())
+
+			var wg sync.WaitGroup
+			errs := make(chan error, 20)
+			for i := 0; i < 20; i++ {
+				wg.Add(1)
+				go func() {
+					defer wg.Done()
+					defer GinkgoRecover()
+					clone := base.Clone()
+					result, err := clone.Cascade(templ, nil)
+					if err != nil {
+						errs <- err
+						return
+					}
+					data, err := clone.Marshal(result)
+					Expect(err).To(Succeed())
+					Expect(string(data)).To(Equal("other: 1\nvalue: tagged\n"))
+				}()
+			}
+			wg.Wait()
+			close(errs)
+			Expect(errs).To(BeEmpty())
+		})
+	})
+
+	Context("with encryption method", func() {
+		It("uses the default method", func() {
+			ctx := New().WithEncryptionKey("secret").WithEncryptionMethod("AES-GCM")
+			templ, err := ctx.Unmarshal("test", []byte(`
+encrypted: (( &temporary(encrypt("value")) ))
+method: (( split(":", encrypted)[0] ))
+decrypted: (( decrypt(encrypted) ))
+`))
+			Expect(err).To(Succeed())
+			result, err := ctx.Cascade(templ, nil)
+			Expect(err).To(Succeed())
+			data, err := ctx.Marshal(result)
+			Expect(err).To(Succeed())
+			Expect(string(data)).To(Equal("decrypted: value\nmethod: AES-GCM\n"))
+		})
+	})
+
+	Context("with random seed", func() {
+		process := func(ctx Spiff) string {
+			templ, err := ctx.Unmarshal("test", []byte("(( random_string(16) \"-\" random_int(1, 1000) ))"))
+			Expect(err).To(Succeed())
+			result, err := ctx.Cascade(templ, nil)
+			Expect(err).To(Succeed())
+			data, err := ctx.Marshal(result)
+			Expect(err).To(Succeed())
+			return string(data)
+		}
+
+		It("reproduces random values", func() {
+			Expect(process(New().WithRandomSeed(42))).To(Equal(process(New().WithRandomSeed(42))))
+		})
+		It("varies random values", func() {
+			Expect(process(New().WithRandomSeed(42))).NotTo(Equal(process(New().WithRandomSeed(43))))
+		})
+		It("reproduces random values for a reused context", func() {
+			ctx := New().WithRandomSeed(42)
+			Expect(process(ctx)).To(Equal(process(ctx)))
+		})
+		It("reproduces random values for applied stubs", func() {
+			ctx := New().WithRandomSeed(42)
+			templ, err := ctx.Unmarshal("test", []byte("(( random_string(16) ))"))
+			Expect(err).To(Succeed())
+			prepared, err := ctx.PrepareStubs()
+			Expect(err).To(Succeed())
+			first, err := ctx.ApplyStubs(templ, prepared)
+			Expect(err).To(Succeed())
+			second, err := ctx.ApplyStubs(templ, prepared, true)
+			Expect(err).To(Succeed())
+			Expect(first.Value()).To(Equal(second.Value()))
+		})
+		It("reads the seed from a file", func() {
+			fs := memoryfs.New()
+			Expect(vfs.WriteFile(fs, "seed", []byte("42\n"), 0o644)).To(Succeed())
+			Expect(vfs.WriteFile(fs, "phrase", []byte("some seed phrase"), 0o644)).To(Succeed())
+			Expect(vfs.WriteFile(fs, "empty", []byte(" \n"), 0o644)).To(Succeed())
+
+			ctx, err := New().WithFileSystem(fs).WithRandomSeedFromFile("seed")
+			Expect(err).To(Succeed())
+			Expect(process(ctx)).To(Equal(process(New().WithRandomSeed(42))))
+
+			ctx, err = New().WithFileSystem(fs).WithRandomSeedFromFile("phrase")
+			Expect(err).To(Succeed())
+			other, err := New().WithFileSystem(fs).WithRandomSeedFromFile("phrase")
+			Expect(err).To(Succeed())
+			Expect(process(ctx)).To(Equal(process(other)))
+
+			_, err = New().WithFileSystem(fs).WithRandomSeedFromFile("empty")
+			Expect(err).To(MatchError("empty: empty random seed"))
+		})
+	})
+
+	Context("with tracer", func() {
+		process := func(prefixes ...string) string {
+			buf := &bytes.Buffer{}
+			ctx := New().WithTracer(NewTracer(buf, prefixes...))
+			templ, err := ctx.Unmarshal("test", []byte(`
+values:
+  a: 1
+  b: (( values.a + 1 ))
+tmpl:
+  <<: (( &template ))
+  x: (( values.b * 2 ))
+inst: (( *tmpl ))
+`))
+			Expect(err).To(Succeed())
+			_, err = ctx.Cascade(templ, nil)
+			Expect(err).To(Succeed())
+			return buf.String()
+		}
+
+		It("traces nested evaluations", func() {
+			trace := process()
+			Expect(trace).To(ContainSubstring("values.b\t(( values.a + 1 ))\t= 2\n"))
+			Expect(trace).To(ContainSubstring("  inst.x\t(( values.b * 2 ))\t= 4\ninst\t(( *(tmpl) ))\t= <map>\n"))
+		})
+		It("filters by path prefix", func() {
+			Expect(process("values")).To(Equal("values.b\t(( values.a + 1 ))\t= 2\n"))
+		})
+	})
+
+	Context("with profiler", func() {
+		It("records functions and top-level paths", func() {
+			profiler := NewProfiler()
+			ctx := New().WithProfiler(profiler)
+			templ, err := ctx.Unmarshal("test", []byte(`
+list: (( [1, 2, 3] ))
+squares:
+  values: (( map[list|x|->x * x] ))
+joined: (( join(",", squares.values) ))
+`))
+			Expect(err).To(Succeed())
+			_, err = ctx.Cascade(templ, nil)
+			Expect(err).To(Succeed())
+
+			names := func(entries []ProfileEntry) []string {
+				var result []string
+				for _, e := range entries {
+					Expect(e.Count).To(BeNumerically(">", 0))
+					result = append(result, e.Name)
+				}
+				return result
+			}
+			Expect(names(profiler.Functions())).To(ConsistOf("map[]", "join"))
+			Expect(names(profiler.Paths())).To(ConsistOf("list", "squares", "joined"))
+		})
+	})
+
+	Context("with file resolver", func() {
+		resolver := func(file string) ([]byte, error) {
+			if file == "stub.yml" {
+				return []byte("value: resolved\n"), nil
+			}
+			return nil, fmt.Errorf("unknown file %q", file)
+		}
+
+		It("reads files via the resolver", func() {
+			ctx := New().WithFileResolver(resolver)
+			templ, err := ctx.Unmarshal("test", []byte(`
+data: (( read("stub.yml").value ))
+`))
+			Expect(err).To(Succeed())
+			result, err := ctx.Cascade(templ, nil)
+			Expect(err).To(Succeed())
+			data, err := ctx.Marshal(result)
+			Expect(err).To(Succeed())
+			Expect(string(data)).To(Equal("data: resolved\n"))
+		})
+
+		It("finds resolver files in search paths", func() {
+			resolver := func(file string) ([]byte, error) {
+				if file == "lib/stub.yml" {
+					return []byte("value: resolved\n"), nil
+				}
+				return nil, fmt.Errorf("unknown file %q", file)
+			}
+			ctx := New().WithFileResolver(resolver).WithIncludeDirs("other", "lib")
+			templ, err := ctx.Unmarshal("test", []byte(`
+include: (( include("stub.yml").value ))
+lookup: (( lookup_file("stub.yml", ["other", "lib"]) ))
+read: (( lookup_read("stub.yml", ["other", "lib"]).value ))
+`))
+			Expect(err).To(Succeed())
+			result, err := ctx.Cascade(templ, nil)
+			Expect(err).To(Succeed())
+			data, err := ctx.Marshal(result)
+			Expect(err).To(Succeed())
+			Expect(string(data)).To(Equal("include: resolved\nlookup:\n- lib/stub.yml\nread: resolved\n"))
+		})
+
+		It("respects the processing mode", func() {
+			ctx := New().WithFileResolver(resolver).WithMode(MODE_PRIVATE)
+			templ, err := ctx.Unmarshal("test", []byte(`
+data: (( read("stub.yml").value ))
+`))
+			Expect(err).To(Succeed())
+			_, err = ctx.Cascade(templ, nil)
+			Expect(err).To(HaveOccurred())
+		})
+	})
+
+	Context("with post processors", func() {
+		It("applies post processors in order", func() {
+			add := func(key, value string) PostProcessor {
+				return func(node Node) (Node, error) {
+					m := map[string]Node{}
+					for k, v := range node.Value().(map[string]Node) {
+						m[k] = v
+					}
+					m[key] = yaml.NewNode(value+fmt.Sprintf("%d", len(m)), "post")
+					return yaml.NewNode(m, "post"), nil
+				}
+			}
+			ctx := New().WithPostProcessor(add("first", "a")).WithPostProcessor(add("second", "b"))
+			templ, err := ctx.Unmarshal("test", []byte("value: (( 1 + 1 ))\n"))
+			Expect(err).To(Succeed())
+			result, err := ctx.Cascade(templ, nil)
+			Expect(err).To(Succeed())
+			data, err := ctx.Marshal(result)
+			Expect(err).To(Succeed())
+			Expect(string(data)).To(Equal("first: a1\nsecond: b2\nvalue: 2\n"))
+		})
+		It("propagates errors", func() {
+			ctx := New().WithPostProcessor(func(node Node) (Node, error) {
+				return nil, fmt.Errorf("rejected")
+			})
+			templ, err := ctx.Unmarshal("test", []byte("value: 1\n"))
+			Expect(err).To(Succeed())
+			_, err = ctx.Cascade(templ, nil)
+			Expect(err).To(MatchError("rejected"))
+		})
+	})
+
+	Context("with typed errors", func() {
+		ctx := New()
+
+		cascade := func(src string) Errors {
+			templ, err := ctx.Unmarshal("test", []byte(src))
+			Expect(err).To(Succeed())
+			_, err = ctx.Cascade(templ, nil)
+			Expect(err).To(HaveOccurred())
+			errs, ok := err.(Errors)
+			Expect(ok).To(BeTrue())
+			return errs
+		}
+
+		It("provides the unresolved nodes", func() {
+			errs := cascade("\nvalue: (( 1 + ))\n")
+			var unresolved dynaml.UnresolvedNodes
+			Expect(errors.As(errs, &unresolved)).To(BeTrue())
+			Expect(len(unresolved.Nodes)).To(Equal(1))
+		})
+
+		It("reports parse errors", func() {
+			errs := cascade("\nvalue: (( 1 + ))\n")
+			Expect(len(errs.Errors)).To(Equal(1))
+			perr, ok := errs.Errors[0].(*ParseError)
+			Expect(ok).To(BeTrue())
+			Expect(perr.Path()).To(Equal("value"))
+			Expect(perr.Source()).To(Equal("test"))
+			Expect(perr.Line()).To(Equal(2))
+			Expect(perr.Expression).To(Equal("1 +"))
+		})
+		It("reports evaluation errors", func() {
+			errs := cascade("list:\n  - (( 1 / 0 ))\n")
+			Expect(len(errs.Errors)).To(Equal(1))
+			eerr, ok := errs.Errors[0].(*EvaluationError)
+			Expect(ok).To(BeTrue())
+			Expect(eerr.Path()).To(Equal("list[0]"))
+			Expect(eerr.Line()).To(Equal(2))
+			Expect(eerr.Message).To(Equal("division by zero"))
+			Expect(eerr.Error()).To(Equal("test:2: list[0]: division by zero"))
+		})
+		It("reports reference cycles", func() {
+			errs := cascade("a: (( b ))\nb: (( a ))\n")
+			Expect(len(errs.Errors)).To(Equal(2))
+			for _, e := range errs.Errors {
+				cerr, ok := e.(*CycleError)
+				Expect(ok).To(BeTrue())
+				if cerr.Path() == "a" {
+					Expect(cerr.Chain).To(Equal([]string{"a", "b", "a"}))
+				}
+			}
+		})
+		It("keeps the unresolved nodes", func() {
+			errs := cascade("value: (( 1 / 0 ))\n")
+			Expect(len(errs.Nodes)).To(Equal(1))
+			Expect(errs.Error()).To(ContainSubstring("division by zero"))
+		})
+		It("panics for MustCascade", func() {
+			templ, err := ctx.Unmarshal("test", []byte("value: (( 1 / 0 ))\n"))
+			Expect(err).To(Succeed())
+			Expect(func() { ctx.MustCascade(templ, nil) }).To(Panic())
+			templ, err = ctx.Unmarshal("test", []byte("value: (( 1 + 1 ))\n"))
+			Expect(err).To(Succeed())
+			data, err := ctx.Marshal(ctx.MustCascade(templ, nil))
+			Expect(err).To(Succeed())
+			Expect(string(data)).To(Equal("value: 2\n"))
+		})
+	})
+
 	Context("Simple processing", func() {
 		ctx, err := New().WithValues(map[string]interface{}{
 			"values": map[string]interface{}{
@@ -91,6 +755,19 @@ data: (( values.alice ))
 `))
 		})
 
+		It("Handles additional values", func() {
+			ctx := ctx.SetValue("carol", yaml.NewNode(int64(27), "test"))
+			templ, err := ctx.Unmarshal("test", []byte(`
+data: (( [values.alice, carol] ))
+`))
+			Expect(err).To(Succeed())
+			result, err := ctx.Cascade(templ, nil)
+			Expect(err).To(Succeed())
+			data, err := ctx.Marshal(result)
+			Expect(err).To(Succeed())
+			Expect(string(data)).To(Equal("data:\n- 25\n- 27\n"))
+		})
+
 		It("Handles override bindings", func() {
 			templ, err := ctx.Unmarshal("test", []byte(`
 values: other
@@ -109,4 +786,121 @@ values: other
 `))
 		})
 	})
+
+	Context("File lookup", func() {
+		var dir string
+
+		BeforeEach(func() {
+			var err error
+			dir, err = os.MkdirTemp("", "spiff-lookup")
+			Expect(err).To(Succeed())
+			Expect(os.MkdirAll(filepath.Join(dir, "local"), 0755)).To(Succeed())
+			Expect(os.MkdirAll(filepath.Join(dir, "shared"), 0755)).To(Succeed())
+			Expect(os.WriteFile(filepath.Join(dir, "shared", "common.yaml"), []byte("name: shared\n"), 0644)).To(Succeed())
+			Expect(os.WriteFile(filepath.Join(dir, "shared", "other.txt"), []byte("shared text"), 0644)).To(Succeed())
+			Expect(os.WriteFile(filepath.Join(dir, "local", "other.txt"), []byte("local text"), 0644)).To(Succeed())
+		})
+		AfterEach(func() {
+			os.RemoveAll(dir)
+		})
+
+		process := func(template string) (string, error) {
+			ctx, err := New().WithValues(map[string]interface{}{
+				"paths": []interface{}{filepath.Join(dir, "local"), filepath.Join(dir, "shared")},
+			})
+			Expect(err).To(Succeed())
+			templ, err := ctx.Unmarshal("test", []byte(template))
+			Expect(err).To(Succeed())
+			result, err := ctx.Cascade(templ, nil)
+			if err != nil {
+				return "", err
+			}
+			data, err := ctx.Marshal(result)
+			Expect(err).To(Succeed())
+			return string(data), nil
+		}
+
+		It("reads the first match in the search path", func() {
+			data, err := process(`
+common: (( lookup_read("common.yaml", paths).name ))
+other: (( lookup_read("other.txt", paths) ))
+missing: (( lookup_read("missing.txt", paths) ))
+`)
+			Expect(err).To(Succeed())
+			Expect(data).To(Equal(
+				`common: shared
+missing: null
+other: local text
+`))
+		})
+
+		It("fails for required missing files", func() {
+			_, err := process(`
+missing: (( lookup_read("missing.txt", paths, true) ))
+`)
+			Expect(err).To(HaveOccurred())
+			Expect(err.Error()).To(ContainSubstring(`lookup_read: "missing.txt" not found in`))
+		})
+	})
+
+	Context("Includes", func() {
+		var dir string
+
+		BeforeEach(func() {
+			var err error
+			dir, err = os.MkdirTemp("", "spiff-include")
+			Expect(err).To(Succeed())
+			Expect(os.WriteFile(filepath.Join(dir, "a.yaml"), []byte("name: (( \"a-\" suffix ))\nnested: (( include(\"b.yaml\") ))\n"), 0644)).To(Succeed())
+			Expect(os.WriteFile(filepath.Join(dir, "b.yaml"), []byte("value: b\n"), 0644)).To(Succeed())
+			Expect(os.WriteFile(filepath.Join(dir, "c.yaml"), []byte("c: (( include(\"d.yaml\") ))\n"), 0644)).To(Succeed())
+			Expect(os.WriteFile(filepath.Join(dir, "d.yaml"), []byte("d: (( include(\"c.yaml\") ))\n"), 0644)).To(Succeed())
+		})
+		AfterEach(func() {
+			os.RemoveAll(dir)
+		})
+
+		It("substitutes the evaluated file content", func() {
+			ctx := New().WithIncludeDirs(dir)
+			templ, err := ctx.Unmarshal("test", []byte(`
+suffix: x
+data: (( include("a.yaml") ))
+`))
+			Expect(err).To(Succeed())
+			result, err := ctx.Cascade(templ, nil)
+			Expect(err).To(Succeed())
+			data, err := ctx.Marshal(result)
+			Expect(err).To(Succeed())
+			Expect(string(data)).To(Equal(
+				`data:
+  name: a-x
+  nested:
+    value: b
+suffix: x
+`))
+		})
+
+		It("detects include cycles", func() {
+			ctx := New().WithIncludeDirs(dir)
+			templ, err := ctx.Unmarshal("test", []byte(`
+data: (( include("c.yaml") ))
+`))
+			Expect(err).To(Succeed())
+			_, err = ctx.Cascade(templ, nil)
+			Expect(err).To(HaveOccurred())
+			c := filepath.Join(dir, "c.yaml")
+			d := filepath.Join(dir, "d.yaml")
+			Expect(err.Error()).To(ContainSubstring("include cycle detected: " + c + " -> " + d + " -> " + c))
+		})
+
+		It("fails for missing files", func() {
+			ctx := New().WithIncludeDirs(dir)
+			templ, err := ctx.Unmarshal("test", []byte(`
+data: (( include("missing.yaml") ))
+`))
+			Expect(err).To(Succeed())
+			_, err = ctx.Cascade(templ, nil)
+			Expect(err).To(HaveOccurred())
+			Expect(err.Error()).To(ContainSubstring(`include: "missing.yaml" not found in include path [` + dir + `]`))
+		})
+	})
 })
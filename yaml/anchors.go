@@ -0,0 +1,144 @@
+package yaml
+
+import (
+	"crypto/sha256"
+	"encoding/json"
+	"fmt"
+
+	"github.com/mandelsoft/spiff/legacy/candiedyaml"
+)
+
+// MarshalWithAnchors marshals a document like Marshal, but emits repeated
+// identical maps and lists only once, marked with an anchor. All further
+// occurrences are emitted as aliases. Anchor names are assigned in the
+// order of their occurrence in the output.
+func MarshalWithAnchors(node Node) ([]byte, error) {
+	return MarshalWithOptions(node, MarshalOptions{Anchors: true})
+}
+
+func withAnchors(node Node) (interface{}, error) {
+	t, err := newAnchorTree(node)
+	if err != nil {
+		return nil, err
+	}
+	counts := map[string]int{}
+	t.count(counts)
+
+	a := &anchors{counts: counts}
+	v := a.convert(t)
+	if len(a.aliased) < len(a.names) {
+		// drop anchors of subtrees only repeated inside aliased subtrees
+		a = &anchors{counts: counts, used: a.aliased}
+		v = a.convert(t)
+	}
+	return v, nil
+}
+
+type anchorTree struct {
+	hash     string
+	node     Node
+	keys     []string
+	children []*anchorTree
+	isList   bool
+}
+
+func newAnchorTree(node Node) (*anchorTree, error) {
+	t := &anchorTree{node: node}
+	h := sha256.New()
+	var value interface{}
+	if node != nil {
+		value = node.Value()
+	}
+	switch v := value.(type) {
+	case map[string]Node:
+		h.Write([]byte("m"))
+		for _, k := range GetSortedKeys(v) {
+			c, err := newAnchorTree(v[k])
+			if err != nil {
+				return nil, err
+			}
+			t.keys = append(t.keys, k)
+			t.children = append(t.children, c)
+			fmt.Fprintf(h, "%d:%s%s", len(k), k, c.hash)
+		}
+	case []Node:
+		t.isList = true
+		h.Write([]byte("l"))
+		for _, e := range v {
+			c, err := newAnchorTree(e)
+			if err != nil {
+				return nil, err
+			}
+			t.children = append(t.children, c)
+			h.Write([]byte(c.hash))
+		}
+	default:
+		n, err := Normalize(node)
+		if err != nil {
+			return nil, err
+		}
+		data, err := json.Marshal(n)
+		if err != nil {
+			return nil, err
+		}
+		h.Write([]byte("s"))
+		h.Write(data)
+	}
+	t.hash = string(h.Sum(nil))
+	return t, nil
+}
+
+func (t *anchorTree) isContainer() bool {
+	return len(t.children) > 0
+}
+
+func (t *anchorTree) count(counts map[string]int) {
+	if t.isContainer() {
+		counts[t.hash]++
+	}
+	for _, c := range t.children {
+		c.count(counts)
+	}
+}
+
+type anchors struct {
+	counts  map[string]int
+	used    map[string]bool
+	names   map[string]string
+	aliased map[string]bool
+}
+
+func (a *anchors) convert(t *anchorTree) interface{} {
+	if a.names == nil {
+		a.names = map[string]string{}
+		a.aliased = map[string]bool{}
+	}
+	if t.isContainer() && a.counts[t.hash] > 1 && (a.used == nil || a.used[t.hash]) {
+		if name, ok := a.names[t.hash]; ok {
+			a.aliased[t.hash] = true
+			return candiedyaml.Alias(name)
+		}
+		name := fmt.Sprintf("a%d", len(a.names)+1)
+		a.names[t.hash] = name
+		return candiedyaml.Anchor{Name: name, Value: a.value(t)}
+	}
+	return a.value(t)
+}
+
+func (a *anchors) value(t *anchorTree) interface{} {
+	if !t.isContainer() {
+		return t.node
+	}
+	if t.isList {
+		list := make([]interface{}, len(t.children))
+		for i, c := range t.children {
+			list[i] = a.convert(c)
+		}
+		return list
+	}
+	m := make(map[string]interface{}, len(t.children))
+	for i, c := range t.children {
+		m[t.keys[i]] = a.convert(c)
+	}
+	return m
+}
@@ -0,0 +1,75 @@
+package yaml
+
+import (
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("Marshalling with anchors", func() {
+	It("emits repeated subtrees as aliases", func() {
+		doc, err := Parse("test", []byte(`
+a:
+  resources:
+    cpu: 1
+    memory: 2
+  list: [1, 2]
+b:
+  resources:
+    cpu: 1
+    memory: 2
+  list: [1, 2]
+c:
+  list: [1, 2]
+  other: 1
+`))
+		Expect(err).NotTo(HaveOccurred())
+		data, err := MarshalWithAnchors(doc)
+		Expect(err).NotTo(HaveOccurred())
+		Expect(string(data)).To(Equal(`a: &a1
+  list: &a2
+  - 1
+  - 2
+  resources:
+    cpu: 1
+    memory: 2
+b: *a1
+c:
+  list: *a2
+  other: 1
+`))
+		parsed, err := Parse("test", data)
+		Expect(err).NotTo(HaveOccurred())
+		expected, err := Normalize(doc)
+		Expect(err).NotTo(HaveOccurred())
+		Expect(Normalize(parsed)).To(Equal(expected))
+	})
+
+	It("omits anchors only repeated inside aliased subtrees", func() {
+		doc, err := Parse("test", []byte(`
+a:
+  b:
+    c: 1
+b:
+  b:
+    c: 1
+`))
+		Expect(err).NotTo(HaveOccurred())
+		data, err := MarshalWithAnchors(doc)
+		Expect(err).NotTo(HaveOccurred())
+		Expect(string(data)).To(Equal(`a: &a1
+  b:
+    c: 1
+b: *a1
+`))
+	})
+
+	It("keeps documents without repetitions", func() {
+		doc, err := Parse("test", []byte("a: 1\nb:\n  c: [1]\n"))
+		Expect(err).NotTo(HaveOccurred())
+		data, err := MarshalWithAnchors(doc)
+		Expect(err).NotTo(HaveOccurred())
+		expected, err := Marshal(doc)
+		Expect(err).NotTo(HaveOccurred())
+		Expect(string(data)).To(Equal(string(expected)))
+	})
+})
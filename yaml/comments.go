@@ -0,0 +1,413 @@
+package yaml
+
+import (
+	"bufio"
+	"bytes"
+	"fmt"
+	"io"
+	"regexp"
+	"strconv"
+	"strings"
+)
+
+// Comment describes the comments attached to a map key or list entry in a
+// YAML source. Head holds the comment lines preceding the key or entry,
+// Line the comment following it on the same line.
+type Comment struct {
+	Head []string
+	Line string
+}
+
+// Comments maps the dot separated paths of map keys and list entries
+// (`[<index>]`) to their comments.
+type Comments map[string]Comment
+
+// CommentSource describes the comments of a document read from the
+// source with the given name.
+type CommentSource struct {
+	Name     string
+	Comments Comments
+}
+
+// SelectComments determines the comments for the map keys and list
+// entries of a processed document. For every path the comments are taken
+// from the source the node of the path originates from. If this source
+// provides no comments for the path, the first source of the given list
+// providing some is used.
+func SelectComments(node Node, sources []CommentSource) Comments {
+	result := Comments{}
+	selectComments(result, node, nil, sources)
+	return result
+}
+
+func selectComments(result Comments, node Node, path []string, sources []CommentSource) {
+	if node == nil {
+		return
+	}
+	if len(path) > 0 {
+		key := strings.Join(path, ".")
+		found := false
+		for _, src := range sources {
+			if c, ok := src.Comments[key]; ok && src.Name == node.SourceName() {
+				result[key] = c
+				found = true
+				break
+			}
+		}
+		if !found {
+			for _, src := range sources {
+				if c, ok := src.Comments[key]; ok {
+					result[key] = c
+					break
+				}
+			}
+		}
+	}
+	switch v := node.Value().(type) {
+	case map[string]Node:
+		for k, e := range v {
+			selectComments(result, e, append(path[:len(path):len(path)], k), sources)
+		}
+	case []Node:
+		for i, e := range v {
+			selectComments(result, e, append(path[:len(path):len(path)], fmt.Sprintf("[%d]", i)), sources)
+		}
+	}
+}
+
+// ExtractComments determines the comments of a (multi document) YAML source
+// on a textual basis. It returns the comments separately for every
+// document. Only comments of map keys and list entries are considered,
+// all other comments are dropped.
+func ExtractComments(source []byte) ([]Comments, error) {
+	result := []Comments{}
+	s := newCommentScanner()
+	comments := Comments{}
+	var head []string
+
+	err := forEachLine(source, func(text string) {
+		l := s.scan(text)
+		switch l.kind {
+		case lineDocument:
+			if s.content {
+				result = append(result, comments)
+				comments = Comments{}
+				head = nil
+				s = newCommentScanner()
+			}
+		case lineComment:
+			head = append(head, l.comment)
+		case lineKey:
+			if head != nil {
+				key := strings.Join(l.headPath(), ".")
+				c := comments[key]
+				c.Head = head
+				comments[key] = c
+			}
+			if l.comment != "" {
+				key := strings.Join(l.path, ".")
+				c := comments[key]
+				c.Line = l.comment
+				comments[key] = c
+			}
+			head = nil
+		case lineOther:
+			head = nil
+		}
+	})
+	if err != nil {
+		return nil, err
+	}
+	return append(result, comments), nil
+}
+
+// InsertComments adds the given comments to the map keys of a single
+// YAML document with matching paths.
+func InsertComments(source []byte, comments Comments) ([]byte, error) {
+	if len(comments) == 0 {
+		return source, nil
+	}
+	buf := &bytes.Buffer{}
+	s := newCommentScanner()
+	err := forEachLine(source, func(text string) {
+		l := s.scan(text)
+		if l.kind == lineKey {
+			indent := strings.Repeat(" ", l.indent)
+			key := strings.Join(l.path, ".")
+			if l.head != nil {
+				if entry := strings.Join(l.head, "."); entry != key {
+					for _, h := range comments[entry].Head {
+						buf.WriteString(indent + h + "\n")
+					}
+				}
+			}
+			if c, ok := comments[key]; ok {
+				for _, h := range c.Head {
+					buf.WriteString(indent + h + "\n")
+				}
+				if c.Line != "" && l.comment == "" {
+					text += " " + c.Line
+				}
+			}
+		}
+		buf.WriteString(text + "\n")
+	})
+	if err != nil {
+		return nil, err
+	}
+	return buf.Bytes(), nil
+}
+
+// CountComments determines the number of all comments of a (multi
+// document) YAML source, including those dropped by ExtractComments.
+func CountComments(source []byte) (int, error) {
+	count := 0
+	s := newCommentScanner()
+	err := forEachLine(source, func(text string) {
+		l := s.scan(text)
+		if l.kind == lineDocument {
+			s = newCommentScanner()
+		}
+		if l.comment != "" {
+			count++
+		}
+	})
+	return count, err
+}
+
+// Count returns the number of comment lines of the comment set.
+func (c Comments) Count() int {
+	count := 0
+	for _, v := range c {
+		count += len(v.Head)
+		if v.Line != "" {
+			count++
+		}
+	}
+	return count
+}
+
+// forEachLine calls f for every line of the source without the line
+// terminator. In contrast to a bufio.Scanner lines are not limited in size.
+func forEachLine(source []byte, f func(string)) error {
+	reader := bufio.NewReader(bytes.NewReader(source))
+	for {
+		line, err := reader.ReadString('\n')
+		if len(line) > 0 {
+			line = strings.TrimSuffix(line, "\n")
+			f(strings.TrimSuffix(line, "\r"))
+		}
+		if err == io.EOF {
+			return nil
+		}
+		if err != nil {
+			return err
+		}
+	}
+}
+
+const (
+	lineBlank = iota
+	lineComment
+	lineDocument
+	lineKey
+	lineOther
+)
+
+type commentLine struct {
+	kind    int
+	indent  int
+	path    []string
+	head    []string // path of the list entry started by the line, if any
+	comment string
+}
+
+// headPath returns the path the comment lines preceding the line are
+// attached to.
+func (l commentLine) headPath() []string {
+	if l.head != nil {
+		return l.head
+	}
+	return l.path
+}
+
+type commentLevel struct {
+	indent int
+	key    string
+	index  int // index of a list entry, -1 for map keys
+}
+
+// commentScanner keeps track of the path of map keys and list entries
+// of the lines of a YAML document.
+type commentScanner struct {
+	stack   []commentLevel
+	block   int
+	content bool
+}
+
+var blockIndicator = regexp.MustCompile(`^[|>][-+0-9]*$`)
+
+func newCommentScanner() *commentScanner {
+	return &commentScanner{block: -1}
+}
+
+func (s *commentScanner) scan(text string) commentLine {
+	content := strings.TrimLeft(text, " ")
+	indent := len(text) - len(content)
+	content = strings.TrimRight(content, " \t\r")
+
+	if s.block >= 0 {
+		if content == "" || indent > s.block {
+			return commentLine{kind: lineOther, indent: indent}
+		}
+		s.block = -1
+	}
+	switch {
+	case content == "":
+		return commentLine{kind: lineBlank}
+	case strings.HasPrefix(content, "#"):
+		return commentLine{kind: lineComment, indent: indent, comment: content}
+	case indent == 0 && (content == "---" || strings.HasPrefix(content, "--- ") || content == "..."):
+		return commentLine{kind: lineDocument}
+	}
+	s.content = true
+
+	var head []string
+	lineIndent := indent
+	for content == "-" || strings.HasPrefix(content, "- ") {
+		s.push(indent)
+		if head == nil {
+			head = s.path()
+		}
+		rest := strings.TrimLeft(content[1:], " ")
+		indent += len(content) - len(rest)
+		content = rest
+	}
+	if head != nil {
+		if content == "" || strings.HasPrefix(content, "#") {
+			return commentLine{kind: lineKey, indent: lineIndent, path: s.path(), head: head, comment: content}
+		}
+	} else {
+		s.pop(indent)
+	}
+
+	key, rest, ok := splitKey(content)
+	if !ok {
+		value, comment := splitComment(content)
+		if head != nil {
+			if blockIndicator.MatchString(value) {
+				s.block = lineIndent
+			}
+			return commentLine{kind: lineKey, indent: lineIndent, path: s.path(), head: head, comment: comment}
+		}
+		return commentLine{kind: lineOther, indent: indent, comment: comment}
+	}
+	value, comment := splitComment(rest)
+	if blockIndicator.MatchString(value) {
+		s.block = indent
+	}
+	s.stack = append(s.stack, commentLevel{indent, key, -1})
+	return commentLine{kind: lineKey, indent: lineIndent, path: s.path(), head: head, comment: comment}
+}
+
+// push starts the next list entry at the given indentation.
+func (s *commentScanner) push(indent int) {
+	for len(s.stack) > 0 && s.stack[len(s.stack)-1].indent > indent {
+		s.stack = s.stack[:len(s.stack)-1]
+	}
+	index := 0
+	if n := len(s.stack); n > 0 && s.stack[n-1].indent == indent && s.stack[n-1].index >= 0 {
+		index = s.stack[n-1].index + 1
+		s.stack = s.stack[:n-1]
+	}
+	s.stack = append(s.stack, commentLevel{indent, fmt.Sprintf("[%d]", index), index})
+}
+
+func (s *commentScanner) pop(indent int) {
+	for len(s.stack) > 0 && s.stack[len(s.stack)-1].indent >= indent {
+		s.stack = s.stack[:len(s.stack)-1]
+	}
+}
+
+// path returns the path of the innermost map key or list entry.
+func (s *commentScanner) path() []string {
+	path := make([]string, len(s.stack))
+	for i, l := range s.stack {
+		path[i] = l.key
+	}
+	return path
+}
+
+// splitKey splits a line into a map key and the remaining value part.
+func splitKey(content string) (string, string, bool) {
+	switch content[0] {
+	case '"':
+		for i := 1; i < len(content); i++ {
+			switch content[i] {
+			case '\\':
+				i++
+			case '"':
+				if key, err := strconv.Unquote(content[:i+1]); err == nil {
+					return afterQuotedKey(key, content[i+1:])
+				}
+				return "", "", false
+			}
+		}
+		return "", "", false
+	case '\'':
+		for i := 1; i < len(content); i++ {
+			if content[i] == '\'' {
+				if i+1 < len(content) && content[i+1] == '\'' {
+					i++
+					continue
+				}
+				key := strings.ReplaceAll(content[1:i], "''", "'")
+				return afterQuotedKey(key, content[i+1:])
+			}
+		}
+		return "", "", false
+	case '?', '{', '[', '&', '*', '!', '|', '>', '%', '@', '`':
+		return "", "", false
+	}
+	if strings.HasSuffix(content, ":") && !strings.Contains(content, ": ") {
+		return strings.TrimRight(content[:len(content)-1], " "), "", true
+	}
+	if i := strings.Index(content, ": "); i > 0 {
+		return strings.TrimRight(content[:i], " "), content[i+2:], true
+	}
+	return "", "", false
+}
+
+func afterQuotedKey(key, rest string) (string, string, bool) {
+	rest = strings.TrimLeft(rest, " ")
+	if !strings.HasPrefix(rest, ":") {
+		return "", "", false
+	}
+	rest = rest[1:]
+	if rest != "" && rest[0] != ' ' {
+		return "", "", false
+	}
+	return key, rest, true
+}
+
+// splitComment splits the value part of a line into the value and a
+// trailing comment.
+func splitComment(rest string) (string, string) {
+	quote := byte(0)
+	for i := 0; i < len(rest); i++ {
+		c := rest[i]
+		switch {
+		case quote != 0:
+			if c == '\\' && quote == '"' {
+				i++
+			} else if c == quote {
+				quote = 0
+			}
+		case (c == '"' || c == '\'') && strings.TrimSpace(rest[:i]) == "":
+			quote = c
+		case c == '#' && (i == 0 || rest[i-1] == ' ' || rest[i-1] == '\t'):
+			return strings.TrimSpace(rest[:i]), strings.TrimSpace(rest[i:])
+		}
+	}
+	return strings.TrimSpace(rest), ""
+}
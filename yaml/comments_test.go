@@ -0,0 +1,144 @@
+package yaml
+
+import (
+	"strings"
+
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("Comments", func() {
+	source := `
+# the alice section
+alice:
+  # the name
+  name: bob # not carol
+  text: |+
+    # no comment
+    some text
+  list:
+    # the entry
+    - a: 1 # the value
+"quoted key": 'x # y' # quoted
+---
+# second document
+peter: 1
+`
+
+	It("extracts comments of map keys and list entries", func() {
+		comments, err := ExtractComments([]byte(source))
+		Expect(err).NotTo(HaveOccurred())
+		Expect(comments).To(Equal([]Comments{
+			{
+				"alice":            {Head: []string{"# the alice section"}},
+				"alice.name":       {Head: []string{"# the name"}, Line: "# not carol"},
+				"alice.list.[0]":   {Head: []string{"# the entry"}},
+				"alice.list.[0].a": {Line: "# the value"},
+				"quoted key":       {Line: "# quoted"},
+			},
+			{
+				"peter": {Head: []string{"# second document"}},
+			},
+		}))
+	})
+
+	It("inserts comments", func() {
+		comments, err := ExtractComments([]byte(source))
+		Expect(err).NotTo(HaveOccurred())
+		doc, err := Parse("test", []byte(`
+alice:
+  list:
+  - a: 1
+  name: bob
+  text: |
+    # no comment
+    some text
+other: 2
+quoted key: 'x # y'
+`))
+		Expect(err).NotTo(HaveOccurred())
+		data, err := Marshal(doc)
+		Expect(err).NotTo(HaveOccurred())
+		data, err = InsertComments(data, comments[0])
+		Expect(err).NotTo(HaveOccurred())
+		Expect(string(data)).To(Equal(`# the alice section
+alice:
+  list:
+  # the entry
+  - a: 1 # the value
+  # the name
+  name: bob # not carol
+  text: |
+    # no comment
+    some text
+other: 2
+quoted key: 'x # y' # quoted
+`))
+	})
+
+	It("handles long lines", func() {
+		long := strings.Repeat("x", 100000)
+		source := "# big\nbig: " + long + "\n# small\nsmall: 1\n"
+		comments, err := ExtractComments([]byte(source))
+		Expect(err).NotTo(HaveOccurred())
+		Expect(comments).To(Equal([]Comments{
+			{
+				"big":   {Head: []string{"# big"}},
+				"small": {Head: []string{"# small"}},
+			},
+		}))
+		data, err := InsertComments([]byte("big: "+long+"\nsmall: 1\n"), comments[0])
+		Expect(err).NotTo(HaveOccurred())
+		Expect(string(data)).To(Equal(source))
+	})
+
+	It("handles nested list entries", func() {
+		source := `list:
+# first
+- a # scalar
+- - x # nested
+  # second nested
+  - y
+- name: n
+  # the value
+  value: 1
+`
+		comments, err := ExtractComments([]byte(source))
+		Expect(err).NotTo(HaveOccurred())
+		Expect(comments).To(Equal([]Comments{
+			{
+				"list.[0]":       {Head: []string{"# first"}, Line: "# scalar"},
+				"list.[1].[0]":   {Line: "# nested"},
+				"list.[1].[1]":   {Head: []string{"# second nested"}},
+				"list.[2].value": {Head: []string{"# the value"}},
+			},
+		}))
+		data, err := InsertComments([]byte(`list:
+- a
+- - x
+  - y
+- name: n
+  value: 1
+`), comments[0])
+		Expect(err).NotTo(HaveOccurred())
+		Expect(string(data)).To(Equal(source))
+	})
+
+	It("selects the comments of the source providing the node", func() {
+		template, err := Parse("template", []byte("a: 1\nb: 2\nc: 3\n"))
+		Expect(err).NotTo(HaveOccurred())
+		stub, err := Parse("stub", []byte("a: 4\n"))
+		Expect(err).NotTo(HaveOccurred())
+		template.Value().(map[string]Node)["a"] = stub.Value().(map[string]Node)["a"]
+
+		comments := SelectComments(template, []CommentSource{
+			{"template", Comments{"a": {Line: "# template a"}, "b": {Line: "# template b"}}},
+			{"stub", Comments{"a": {Line: "# stub a"}, "b": {Line: "# stub b"}, "c": {Line: "# stub c"}, "d": {Line: "# stub d"}}},
+		})
+		Expect(comments).To(Equal(Comments{
+			"a": {Line: "# stub a"},
+			"b": {Line: "# template b"},
+			"c": {Line: "# stub c"},
+		}))
+	})
+})
@@ -0,0 +1,57 @@
+package yaml
+
+import (
+	"fmt"
+	"regexp"
+	"strings"
+)
+
+var envKey = regexp.MustCompile(`^[A-Za-z_][A-Za-z0-9_]*$`)
+var envPlain = regexp.MustCompile(`^[A-Za-z0-9_@%+=:,./-]*$`)
+
+// ToEnv renders a map document as environment file with `KEY=value`
+// lines. Values are quoted for the shell, if required. Without a separator
+// all map values must be scalars, otherwise nested maps are flattened
+// by joining the keys with the separator. Keys must be valid shell
+// identifiers.
+func ToEnv(node Node, separator string) ([]byte, error) {
+	if node == nil {
+		return nil, fmt.Errorf("env output requires a map")
+	}
+	m, ok := node.Value().(map[string]Node)
+	if !ok {
+		return nil, fmt.Errorf("env output requires a map")
+	}
+	if separator == "" {
+		for _, k := range GetSortedKeys(m) {
+			if m[k] == nil {
+				continue
+			}
+			switch m[k].Value().(type) {
+			case map[string]Node, []Node:
+				return nil, fmt.Errorf("non-scalar value for env key %q (use a separator to flatten nested maps)", k)
+			}
+		}
+	}
+	entries, err := Flatten(node, separator, false)
+	if err != nil {
+		return nil, err
+	}
+	buf := &strings.Builder{}
+	for _, e := range entries {
+		if !envKey.MatchString(e.Key) {
+			return nil, fmt.Errorf("invalid env key %q", e.Key)
+		}
+		fmt.Fprintf(buf, "%s=%s\n", e.Key, ShellQuote(e.Value))
+	}
+	return []byte(buf.String()), nil
+}
+
+// ShellQuote quotes a string for the shell, if it contains characters
+// with a special meaning.
+func ShellQuote(s string) string {
+	if envPlain.MatchString(s) {
+		return s
+	}
+	return "'" + strings.ReplaceAll(s, "'", `'\''`) + "'"
+}
@@ -0,0 +1,47 @@
+package yaml
+
+import (
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("Env output", func() {
+	It("renders scalar values", func() {
+		doc, err := Parse("test", []byte(`
+HOST: example.com
+PORT: 8080
+MESSAGE: it's a test
+EMPTY: ""
+`))
+		Expect(err).NotTo(HaveOccurred())
+		data, err := ToEnv(doc, "")
+		Expect(err).NotTo(HaveOccurred())
+		Expect(string(data)).To(Equal(`EMPTY=
+HOST=example.com
+MESSAGE='it'\''s a test'
+PORT=8080
+`))
+	})
+
+	It("rejects nested maps without separator", func() {
+		doc, err := Parse("test", []byte("DB:\n  HOST: db\n"))
+		Expect(err).NotTo(HaveOccurred())
+		_, err = ToEnv(doc, "")
+		Expect(err).To(MatchError(`non-scalar value for env key "DB" (use a separator to flatten nested maps)`))
+	})
+
+	It("flattens nested maps with separator", func() {
+		doc, err := Parse("test", []byte("DB:\n  HOST: db\n  PORT: 5432\n"))
+		Expect(err).NotTo(HaveOccurred())
+		data, err := ToEnv(doc, "_")
+		Expect(err).NotTo(HaveOccurred())
+		Expect(string(data)).To(Equal("DB_HOST=db\nDB_PORT=5432\n"))
+	})
+
+	It("rejects invalid keys", func() {
+		doc, err := Parse("test", []byte("my-key: 1\n"))
+		Expect(err).NotTo(HaveOccurred())
+		_, err = ToEnv(doc, "")
+		Expect(err).To(MatchError(`invalid env key "my-key"`))
+	})
+})
@@ -83,18 +83,23 @@ func nextStep(raw bool, step string, here Node, features features.FeatureFlags)
 	return here, found
 }
 
-func stepThroughList(raw bool, here []Node, step string, key string, features features.FeatureFlags) (Node, bool) {
+// ListIndex returns the index of a list index path step ([n]).
+// Indices exceeding the int range are clamped to the int limits.
+func ListIndex(step string) (int, bool) {
 	match := listIndex.FindStringSubmatch(step)
-	if match != nil {
-		index, err := strconv.Atoi(match[1])
-		if err != nil {
-			panic(err)
-		}
+	if match == nil {
+		return 0, false
+	}
+	index, _ := strconv.Atoi(match[1])
+	return index, true
+}
 
+func stepThroughList(raw bool, here []Node, step string, key string, features features.FeatureFlags) (Node, bool) {
+	if index, ok := ListIndex(step); ok {
 		if index < 0 {
 			index = len(here) + index
 		}
-		if len(here) <= index {
+		if index < 0 || len(here) <= index {
 			return nil, false
 		}
 
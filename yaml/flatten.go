@@ -0,0 +1,96 @@
+package yaml
+
+import (
+	"encoding/json"
+	"fmt"
+	"strings"
+)
+
+// FlatEntry is a single entry of a flattened document.
+type FlatEntry struct {
+	Key   string
+	Value string
+}
+
+func (e FlatEntry) String() string {
+	return e.Key + "=" + e.Value
+}
+
+// Flatten converts a document into a list of entries mapping the paths of
+// all scalar values to their string representation. The path elements
+// of map keys are joined by the given separator, list indices are appended
+// in the form `[<index>]`. The entries are ordered like the nodes visited
+// by Walk. Empty maps and lists are non-scalar leaves. If encode is set,
+// they are represented by their JSON encoding, otherwise they are
+// rejected with an error.
+func Flatten(node Node, separator string, encode bool) ([]FlatEntry, error) {
+	if node == nil || node.Value() == nil {
+		return nil, fmt.Errorf("flat output requires a map or list")
+	}
+	switch node.Value().(type) {
+	case map[string]Node, []Node:
+	default:
+		return nil, fmt.Errorf("flat output requires a map or list")
+	}
+	result := []FlatEntry{}
+	err := Walk(node, func(path []string, n Node) error {
+		if len(path) == 0 {
+			return nil
+		}
+		var value interface{}
+		if n != nil {
+			value = n.Value()
+		}
+		switch v := value.(type) {
+		case map[string]Node:
+			if len(v) > 0 {
+				return nil
+			}
+		case []Node:
+			if len(v) > 0 {
+				return nil
+			}
+		}
+		key := FlatKey(path, separator)
+		normalized, err := Normalize(n)
+		if err != nil {
+			return err
+		}
+		switch v := normalized.(type) {
+		case map[string]interface{}, []interface{}:
+			if !encode {
+				return fmt.Errorf("non-scalar value for %q", key)
+			}
+			data, err := json.Marshal(v)
+			if err != nil {
+				return err
+			}
+			result = append(result, FlatEntry{key, string(data)})
+		case nil:
+			result = append(result, FlatEntry{key, ""})
+		case []byte:
+			result = append(result, FlatEntry{key, string(v)})
+		default:
+			result = append(result, FlatEntry{key, fmt.Sprintf("%v", v)})
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+	return result, nil
+}
+
+// FlatKey joins the elements of a path as returned by Walk. Map keys are
+// separated by the given separator, list indices are appended in the
+// form `[<index>]`.
+func FlatKey(path []string, separator string) string {
+	key := ""
+	for _, p := range path {
+		if key != "" && !strings.HasPrefix(p, "[") {
+			key += separator
+		}
+		key += p
+	}
+	return key
+}
@@ -0,0 +1,72 @@
+package yaml
+
+import (
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("Flattening", func() {
+	source := `
+spec:
+  replicas: 3
+  ports:
+    - 80
+    - name: https
+      port: 443
+  enabled: true
+  empty: []
+  none: ~
+`
+
+	It("flattens documents", func() {
+		doc, err := Parse("test", []byte(`
+spec:
+  replicas: 3
+  ports:
+    - 80
+    - name: https
+      port: 443
+  enabled: true
+  none: ~
+`))
+		Expect(err).NotTo(HaveOccurred())
+		Expect(Flatten(doc, ".", false)).To(Equal([]FlatEntry{
+			{"spec.enabled", "true"},
+			{"spec.none", ""},
+			{"spec.ports[0]", "80"},
+			{"spec.ports[1].name", "https"},
+			{"spec.ports[1].port", "443"},
+			{"spec.replicas", "3"},
+		}))
+	})
+
+	It("uses the given separator", func() {
+		doc, err := Parse("test", []byte("a:\n  b:\n  - c: 1\n"))
+		Expect(err).NotTo(HaveOccurred())
+		Expect(Flatten(doc, "_", false)).To(Equal([]FlatEntry{
+			{"a_b[0]_c", "1"},
+		}))
+	})
+
+	It("rejects non-scalar leaves", func() {
+		doc, err := Parse("test", []byte(source))
+		Expect(err).NotTo(HaveOccurred())
+		_, err = Flatten(doc, ".", false)
+		Expect(err).To(MatchError(`non-scalar value for "spec.empty"`))
+	})
+
+	It("encodes non-scalar leaves", func() {
+		doc, err := Parse("test", []byte(source))
+		Expect(err).NotTo(HaveOccurred())
+		entries, err := Flatten(doc, ".", true)
+		Expect(err).NotTo(HaveOccurred())
+		Expect(entries).To(ContainElement(FlatEntry{"spec.empty", "[]"}))
+	})
+
+	It("rejects scalar documents", func() {
+		doc, err := Parse("test", []byte("1"))
+		Expect(err).NotTo(HaveOccurred())
+		_, err = Flatten(doc, ".", false)
+		Expect(err).To(HaveOccurred())
+	})
+})
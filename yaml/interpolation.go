@@ -1,8 +1,11 @@
 package yaml
 
 import (
+	"bytes"
 	"encoding/json"
+	"fmt"
 	"strings"
+	"unicode"
 )
 
 func StringToExpression(s string) string {
@@ -13,119 +16,199 @@ func StringToExpression(s string) string {
 	return "(( " + *expr + " ))"
 }
 
+// Delimiters describe the delimiters of dynaml expressions embedded
+// in strings by the interpolation feature.
+type Delimiters struct {
+	Open  string
+	Close string
+}
+
+// DefaultDelimiters are the standard delimiters of dynaml expressions.
+var DefaultDelimiters = Delimiters{"((", "))"}
+
+// ParseDelimiters parses a delimiter specification consisting of the
+// opening and the closing delimiter separated by white space, for
+// example "<% %>".
+func ParseDelimiters(spec string) (Delimiters, error) {
+	fields := strings.Fields(spec)
+	if len(fields) != 2 {
+		return Delimiters{}, fmt.Errorf("invalid delimiters %q: opening and closing delimiter separated by a space expected", spec)
+	}
+	d := Delimiters{fields[0], fields[1]}
+	return d, d.Validate()
+}
+
+// Validate checks whether embedded expressions can unambiguously be
+// parsed with the delimiters. They must not be empty, must not contain
+// white space, quotes, backslashes or the escape character (!) and
+// must not contain each other.
+func (d Delimiters) Validate() error {
+	for _, s := range []string{d.Open, d.Close} {
+		if s == "" {
+			return fmt.Errorf("empty expression delimiter")
+		}
+		if strings.ContainsAny(s, "\"\\!") || strings.IndexFunc(s, unicode.IsSpace) >= 0 {
+			return fmt.Errorf("invalid expression delimiter %q: white space, quotes, backslashes and '!' are not allowed", s)
+		}
+	}
+	if strings.Contains(d.Open, d.Close) || strings.Contains(d.Close, d.Open) {
+		return fmt.Errorf("expression delimiters %q and %q must not contain each other", d.Open, d.Close)
+	}
+	return nil
+}
+
+func (d Delimiters) String() string {
+	return d.Open + " " + d.Close
+}
+
+// delimiters provides the optionally given delimiters
+// or the default delimiters.
+func delimiters(delims []Delimiters) Delimiters {
+	if len(delims) == 0 || delims[0].Open == "" {
+		return DefaultDelimiters
+	}
+	return delims[0]
+}
+
 func convertToExpression(s string, unescape bool) (*string, *string) {
-	mask := false
-	quote := false
-	start := false
-	ob := 0
-	lvl := 0
-	expr := ""
+	return DefaultDelimiters.convert(s, unescape)
+}
+
+// convert converts a string with embedded expressions into a flat
+// dynaml expression concatenating the string parts and the expressions.
+// For a string without embedded expressions the (optionally unescaped)
+// string is returned.
+func (d Delimiters) convert(s string, unescape bool) (*string, *string) {
 	str := ""
 	result := ""
 	found := false
 
-	for _, c := range s {
-		if start {
-			if quote {
-				// in quotes in expr
-				switch c {
-				case '"':
-					if !mask {
-						quote = false
-					}
-					mask = false
-				case '\\':
-					mask = !mask
-				default:
-					mask = false
-				}
-				expr = expr + string(c)
-			} else {
-				// in expr outside quotes
-				switch c {
-				case '(':
-					lvl++
-				case ')':
-					if lvl > 0 {
-						lvl--
-					} else {
-						ob++
-						if ob == 2 {
-							start = false
-							ob = 0
-							found = addExpr(&result, &str, &expr, false, unescape) || found
-						}
-					}
-				case '"':
-					quote = true
-				}
-				if start {
-					expr = expr + string(c)
-				}
-				if c != ')' {
-					ob = 0
-				}
-			}
-		} else {
-			// regular string
-			switch c {
-			case '(':
-				ob++
-			default:
-				if ob >= 2 {
-					start = true
-					str = str[:len(str)-2]
-					expr = string(c)
-					ob = 0
-				}
-			}
-			if !start {
-				str = str + string(c)
-				if c != '(' {
-					ob = 0
-				}
-			}
+	for {
+		i := strings.Index(s, d.Open)
+		if i < 0 {
+			str += s
+			break
+		}
+		// the last of overlapping opening delimiters starts the expression, e.g. "((("
+		for strings.HasPrefix(s[i+1:], d.Open) {
+			i++
 		}
+		str += s[:i]
+		rest := s[i+len(d.Open):]
+		n := d.length(rest)
+		if n < 0 {
+			// incomplete expression
+			str += s[i:]
+			break
+		}
+		found = d.addExpr(&result, &str, rest[:n], unescape) || found
+		s = rest[n+len(d.Close):]
 	}
 
-	if start {
-		str = str + "((" + expr
-		expr = ""
-	}
-	found = addExpr(&result, &str, &expr, true, unescape) || found
 	if found {
+		addString(&result, &str)
 		return nil, &result
 	}
 	return &str, nil
 }
 
-func addExpr(result, str, expr *string, final, unescape bool) bool {
-	if unescape && strings.HasPrefix(*expr, "!") {
-		*str += "((" + (*expr)[1:] + ")"
-		*expr = ""
+// escape escapes all embedded expressions of a string
+// detected by convert.
+func (d Delimiters) escape(s string) string {
+	result := ""
+	for {
+		i := strings.Index(s, d.Open)
+		if i < 0 {
+			break
+		}
+		for strings.HasPrefix(s[i+1:], d.Open) {
+			i++
+		}
+		rest := s[i+len(d.Open):]
+		n := d.length(rest)
+		if n < 0 {
+			break
+		}
+		result += s[:i] + d.Open + "!" + rest[:n] + d.Close
+		s = rest[n+len(d.Close):]
 	}
-	if strings.HasPrefix(*expr, "!") {
-		*str += "((" + *expr + ")"
-		*expr = ""
+	return result + s
+}
+
+// length determines the length of an embedded expression up to the
+// closing delimiter or -1 if it is not closed. Closing delimiters in
+// quotes or in brackets of the kind used by the closing delimiter are
+// ignored.
+func (d Delimiters) length(s string) int {
+	lvl := 0
+	quote := false
+	mask := false
+	for i, c := range s {
+		if quote {
+			switch c {
+			case '"':
+				if !mask {
+					quote = false
+				}
+				mask = false
+			case '\\':
+				mask = !mask
+			default:
+				mask = false
+			}
+			continue
+		}
+		if lvl == 0 && strings.HasPrefix(s[i:], d.Close) {
+			return i
+		}
+		switch c {
+		case '"':
+			quote = true
+		case '(', '[', '{':
+			if strings.ContainsRune(d.Close, closing[c]) {
+				lvl++
+			}
+		case ')', ']', '}':
+			if lvl > 0 && strings.ContainsRune(d.Close, c) {
+				lvl--
+			}
+		}
 	}
-	if *expr == "" && (*result == "" || !final) {
+	return -1
+}
+
+var closing = map[rune]rune{'(': ')', '[': ']', '{': '}'}
+
+// addExpr adds an embedded expression to the result. Escaped expressions
+// are kept (or unescaped) as part of the actual string.
+func (d Delimiters) addExpr(result, str *string, expr string, unescape bool) bool {
+	if strings.HasPrefix(expr, "!") {
+		if unescape {
+			expr = expr[1:]
+		}
+		*str += d.Open + expr + d.Close
 		return false
 	}
+	addString(result, str)
+	expr = strings.TrimSpace(expr)
+	if *result != "" && expr != "" {
+		*result += " "
+	}
+	*result += expr
+	return true
+}
+
+// addString adds the actual string as string literal to the result.
+func addString(result, str *string) {
 	if *str != "" {
-		r, _ := json.Marshal(*str)
+		var buf bytes.Buffer
+		enc := json.NewEncoder(&buf)
+		enc.SetEscapeHTML(false)
+		enc.Encode(*str)
 		if *result != "" {
 			*result += " "
 		}
-		*result = *result + string(r)
+		*result += strings.TrimSuffix(buf.String(), "\n")
+		*str = ""
 	}
-	if *expr != "" {
-		*expr = strings.TrimSpace((*expr)[:len(*expr)-1])
-	}
-	if *result != "" && *expr != "" {
-		*result += " "
-	}
-	*result += *expr
-	*expr = ""
-	*str = ""
-	return true
 }
@@ -10,6 +10,15 @@ func checkConvert(o, r string) {
 	Expect(c).To(Equal(r))
 }
 
+func checkConvertWith(d Delimiters, o, r string) {
+	str, expr := d.convert(o, false)
+	if expr != nil {
+		Expect("(( " + *expr + " ))").To(Equal(r))
+	} else {
+		Expect(*str).To(Equal(r))
+	}
+}
+
 func checkUnescape(o, r string) {
 	str, expr := convertToExpression(o, true)
 	Expect(expr).To(BeNil())
@@ -98,4 +107,84 @@ var _ = Describe("Convert String", func() {
 			checkUnescape("b start ((!! a ) end", "b start ((!! a ) end")
 		})
 	})
+
+	Context("custom delimiters", func() {
+		d := Delimiters{"<%", "%>"}
+
+		It("handles substitution", func() {
+			checkConvertWith(d, "start <% a + b %> end", "(( \"start \" a + b \" end\" ))")
+			checkConvertWith(d, "<% a %><% b %>", "(( a b ))")
+			checkConvertWith(d, "<<% a %>", "(( \"<\" a ))")
+		})
+		It("keeps standard expressions", func() {
+			checkConvertWith(d, "start (( a )) <% b %>", "(( \"start (( a )) \" b ))")
+			checkConvertWith(d, "start (( a ))", "start (( a ))")
+		})
+		It("ignores closing delimiters in strings", func() {
+			checkConvertWith(d, "a <% \"%>\" %>", "(( \"a \" \"%>\" ))")
+		})
+		It("handles incomplete expr", func() {
+			checkConvertWith(d, "a <% b", "a <% b")
+			checkConvertWith(d, "a <% \"%>", "a <% \"%>")
+		})
+		It("handles nested brackets of the closing delimiter", func() {
+			b := Delimiters{"{{", "}}"}
+			checkConvertWith(b, "a {{ { \"b\" = 1 }.b }}", "(( \"a \" { \"b\" = 1 }.b ))")
+			checkConvertWith(b, "a {{ ({ \"b\" = 1 }) }} c", "(( \"a \" ({ \"b\" = 1 }) \" c\" ))")
+		})
+		It("unescapes expr", func() {
+			str, expr := d.convert("a <%! b %> (( c ))", true)
+			Expect(expr).To(BeNil())
+			Expect(*str).To(Equal("a <% b %> (( c ))"))
+		})
+	})
+
+	Context("delimiter validation", func() {
+		It("parses delimiters", func() {
+			Expect(ParseDelimiters("<% %>")).To(Equal(Delimiters{"<%", "%>"}))
+			Expect(ParseDelimiters(" ${ } ")).To(Equal(Delimiters{"${", "}"}))
+		})
+		It("rejects invalid specifications", func() {
+			_, err := ParseDelimiters("<%%>")
+			Expect(err).To(HaveOccurred())
+			_, err = ParseDelimiters("<% %> x")
+			Expect(err).To(HaveOccurred())
+		})
+		It("rejects ambiguous delimiters", func() {
+			Expect(Delimiters{"", "}"}.Validate()).To(HaveOccurred())
+			Expect(Delimiters{"{", "{"}.Validate()).To(HaveOccurred())
+			Expect(Delimiters{"<<", "<"}.Validate()).To(HaveOccurred())
+			Expect(Delimiters{"<\"", ">"}.Validate()).To(HaveOccurred())
+			Expect(Delimiters{"<!", ">"}.Validate()).To(HaveOccurred())
+			Expect(Delimiters{"<", "\\>"}.Validate()).To(HaveOccurred())
+			Expect(DefaultDelimiters.Validate()).To(Succeed())
+		})
+	})
+
+	Context("escaping", func() {
+		check := func(d Delimiters, o, r string) {
+			e := EscapeDynaml(o, true, d)
+			Expect(e).To(Equal(r))
+			Expect(UnescapeDynaml(NewNode(e, "test"), true, d).Value()).To(Equal(o))
+		}
+
+		It("escapes complete expressions", func() {
+			check(DefaultDelimiters, "(( a ))", "((! a ))")
+			check(DefaultDelimiters, "((! a ))", "((!! a ))")
+			Expect(EscapeDynaml("a (( b ))", false)).To(Equal("a (( b ))"))
+		})
+		It("escapes embedded expressions", func() {
+			check(DefaultDelimiters, "a (( b )) c (( d ))", "a ((! b )) c ((! d ))")
+			check(DefaultDelimiters, "a ((( b ))) c", "a (((! b ))) c")
+			check(DefaultDelimiters, "a ((! b )) c", "a ((!! b )) c")
+		})
+		It("keeps incomplete expressions", func() {
+			check(DefaultDelimiters, "a (( b ) c", "a (( b ) c")
+			check(DefaultDelimiters, "a (( \"b )) c", "a (( \"b )) c")
+		})
+		It("escapes custom delimiters", func() {
+			d := Delimiters{"<%", "%>"}
+			check(d, "a <% b %> (( c ))", "a <%! b %> (( c ))")
+		})
+	})
 })
@@ -1,10 +1,12 @@
 package yaml
 
 import (
+	"bytes"
 	"encoding/json"
 	"errors"
 	"fmt"
 	"reflect"
+	"strings"
 
 	"github.com/mandelsoft/spiff/legacy/candiedyaml"
 )
@@ -13,6 +15,35 @@ func Marshal(node Node) ([]byte, error) {
 	return candiedyaml.Marshal(node)
 }
 
+// MarshalOptions controls the yaml output of MarshalWithOptions.
+type MarshalOptions struct {
+	// Anchors enables the output of repeated maps and lists as aliases.
+	Anchors bool
+	// Multiline is the style used for multi-line strings without a style
+	// taken from the source document.
+	Multiline candiedyaml.ScalarStyle
+	// Quote is the quoting used for single-line string values.
+	Quote candiedyaml.QuoteStyle
+}
+
+// MarshalWithOptions marshals a document according to the given options.
+func MarshalWithOptions(node Node, opts MarshalOptions) ([]byte, error) {
+	var v interface{} = node
+	if opts.Anchors {
+		var err error
+		v, err = withAnchors(node)
+		if err != nil {
+			return nil, err
+		}
+	}
+	b := bytes.Buffer{}
+	e := candiedyaml.NewEncoder(&b)
+	e.SetMultilineStyle(opts.Multiline)
+	e.SetQuoteStyle(opts.Quote)
+	err := e.Encode(v)
+	return b.Bytes(), err
+}
+
 func ToJSON(root Node) ([]byte, error) {
 	if root == nil {
 		return ValueToJSON(nil)
@@ -20,6 +51,77 @@ func ToJSON(root Node) ([]byte, error) {
 	return ValueToJSON(root.Value())
 }
 
+// MarshalMulti marshals a list of documents into a single yaml stream.
+// Like the output of the merge command multiple documents are preceded
+// by a document separator (---).
+func MarshalMulti(nodes []Node) ([]byte, error) {
+	return MarshalMultiWith(nodes, func(i int, n Node) ([]byte, error) {
+		return Marshal(n)
+	})
+}
+
+// MarshalMultiWith works like MarshalMulti, but uses the given function
+// to marshal the document with the given index.
+func MarshalMultiWith(nodes []Node, marshal func(int, Node) ([]byte, error)) ([]byte, error) {
+	b := bytes.Buffer{}
+	for i, n := range nodes {
+		data, err := marshal(i, n)
+		if err != nil {
+			return nil, err
+		}
+		if len(nodes) > 1 || len(data) == 0 {
+			b.WriteString("---\n")
+		}
+		b.Write(data)
+		if len(data) > 0 && data[len(data)-1] != '\n' {
+			b.WriteString("\n")
+		}
+	}
+	return b.Bytes(), nil
+}
+
+// ToJSONMulti marshals a list of documents into a JSON array, or, if lines
+// is set, into JSON lines, one compact JSON document per line.
+func ToJSONMulti(nodes []Node, lines bool) ([]byte, error) {
+	if !lines {
+		return ToJSON(NewNode(nodes, ""))
+	}
+	return ToJSONStream(nodes, 0)
+}
+
+// ToJSONStream marshals a list of documents into a sequence of JSON
+// documents, each followed by a newline. The documents are indented
+// like for ToJSONIndent, an indent of 0 yields JSON lines.
+func ToJSONStream(nodes []Node, indent int) ([]byte, error) {
+	b := bytes.Buffer{}
+	for _, n := range nodes {
+		data, err := ToJSONIndent(n, indent)
+		if err != nil {
+			return nil, err
+		}
+		b.Write(data)
+		b.WriteString("\n")
+	}
+	return b.Bytes(), nil
+}
+
+// ToJSONIndent marshals a document to JSON indenting nested elements by
+// the given number of spaces. An indent of 0 yields a compact single line.
+func ToJSONIndent(root Node, indent int) ([]byte, error) {
+	if indent <= 0 {
+		return ToJSON(root)
+	}
+	var v interface{}
+	if root != nil {
+		v = root.Value()
+	}
+	n, err := normalizeValue(v)
+	if err != nil {
+		return nil, err
+	}
+	return json.MarshalIndent(n, "", strings.Repeat(" ", indent))
+}
+
 func ValueToJSON(root interface{}) ([]byte, error) {
 	n, err := normalizeValue(root)
 	if err != nil {
@@ -0,0 +1,155 @@
+package yaml
+
+import (
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+
+	"github.com/mandelsoft/spiff/legacy/candiedyaml"
+)
+
+var _ = Describe("Marshalling", func() {
+	source := `
+zeta:
+  - b: 1
+    a: 2
+  - second
+alpha:
+  gamma: 1
+  beta: 2
+`
+
+	It("sorts map keys and keeps list order in yaml output", func() {
+		doc, err := Parse("test", []byte(source))
+		Expect(err).NotTo(HaveOccurred())
+		data, err := Marshal(doc)
+		Expect(err).NotTo(HaveOccurred())
+		Expect(string(data)).To(Equal(`alpha:
+  beta: 2
+  gamma: 1
+zeta:
+- a: 2
+  b: 1
+- second
+`))
+	})
+
+	It("sorts map keys and keeps list order in json output", func() {
+		doc, err := Parse("test", []byte(source))
+		Expect(err).NotTo(HaveOccurred())
+		data, err := ToJSON(doc)
+		Expect(err).NotTo(HaveOccurred())
+		Expect(string(data)).To(Equal(`{"alpha":{"beta":2,"gamma":1},"zeta":[{"a":2,"b":1},"second"]}`))
+	})
+
+	It("indents json output", func() {
+		doc, err := Parse("test", []byte(source))
+		Expect(err).NotTo(HaveOccurred())
+		data, err := ToJSONIndent(doc, 2)
+		Expect(err).NotTo(HaveOccurred())
+		Expect(string(data)).To(Equal(`{
+  "alpha": {
+    "beta": 2,
+    "gamma": 1
+  },
+  "zeta": [
+    {
+      "a": 2,
+      "b": 1
+    },
+    "second"
+  ]
+}`))
+		data, err = ToJSONIndent(doc, 0)
+		Expect(err).NotTo(HaveOccurred())
+		Expect(string(data)).To(Equal(`{"alpha":{"beta":2,"gamma":1},"zeta":[{"a":2,"b":1},"second"]}`))
+	})
+
+	Context("multiple documents", func() {
+		var docs []Node
+
+		BeforeEach(func() {
+			docs = nil
+			for _, src := range []string{"a: 1", "- b", "c: 2"} {
+				doc, err := Parse("test", []byte(src))
+				Expect(err).NotTo(HaveOccurred())
+				docs = append(docs, doc)
+			}
+		})
+
+		It("separates yaml documents", func() {
+			data, err := MarshalMulti(docs)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(string(data)).To(Equal("---\na: 1\n---\n- b\n---\nc: 2\n"))
+			parsed, err := ParseMulti("test", data)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(len(parsed)).To(Equal(3))
+		})
+
+		It("omits the separator for a single document", func() {
+			data, err := MarshalMulti(docs[:1])
+			Expect(err).NotTo(HaveOccurred())
+			Expect(string(data)).To(Equal("a: 1\n"))
+		})
+
+		It("marshals a json array", func() {
+			data, err := ToJSONMulti(docs, false)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(string(data)).To(Equal(`[{"a":1},["b"],{"c":2}]`))
+		})
+
+		It("uses the given function to marshal the documents", func() {
+			data, err := MarshalMultiWith(docs, func(i int, n Node) ([]byte, error) {
+				if i == 1 {
+					return nil, nil
+				}
+				return ToJSON(n)
+			})
+			Expect(err).NotTo(HaveOccurred())
+			Expect(string(data)).To(Equal("---\n{\"a\":1}\n---\n---\n{\"c\":2}\n"))
+		})
+
+		It("marshals a stream of indented json documents", func() {
+			data, err := ToJSONStream(docs[:2], 1)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(string(data)).To(Equal("{\n \"a\": 1\n}\n[\n \"b\"\n]\n"))
+		})
+
+		It("marshals json lines", func() {
+			data, err := ToJSONMulti(docs, true)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(string(data)).To(Equal("{\"a\":1}\n[\"b\"]\n{\"c\":2}\n"))
+		})
+	})
+
+	Context("multi-line strings", func() {
+		source := "literal: |\n  a\n  b\nfolded: >\n  c\n  d\n\n  e\n"
+
+		It("preserves the style of block scalars", func() {
+			doc, err := ParseWithPositions("test", []byte(source))
+			Expect(err).NotTo(HaveOccurred())
+			data, err := Marshal(doc)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(string(data)).To(Equal("folded: >\n  c d\n\n  e\nliteral: |\n  a\n  b\n"))
+		})
+
+		It("preserves the chomping indicator", func() {
+			source := "keep: |+\n  a\n\nstrip: |-\n  b\n  c\n"
+			doc, err := ParseWithPositions("test", []byte(source))
+			Expect(err).NotTo(HaveOccurred())
+			data, err := Marshal(doc)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(string(data)).To(Equal(source))
+		})
+
+		It("uses the default style for other strings", func() {
+			doc, err := Parse("test", []byte(source))
+			Expect(err).NotTo(HaveOccurred())
+			data, err := MarshalWithOptions(doc, MarshalOptions{Multiline: candiedyaml.FoldedStyle})
+			Expect(err).NotTo(HaveOccurred())
+			Expect(string(data)).To(Equal("folded: >\n  c d\n\n  e\nliteral: >\n  a\n\n  b\n"))
+			parsed, err := Parse("test", data)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(Normalize(parsed)).To(Equal(map[string]interface{}{"literal": "a\nb\n", "folded": "c d\ne\n"}))
+		})
+	})
+})
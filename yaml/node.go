@@ -37,6 +37,7 @@ type Node interface {
 	Failed() bool
 	Undefined() bool
 	Issue() Issue
+	Position() Position
 
 	Resolver() RefResolver
 
@@ -57,14 +58,17 @@ type Issue struct {
 	OrigPath []string
 	Nested   []Issue
 	Sequence bool
+	// Reference is the path of the unresolved reference
+	// the issue is caused by, if any.
+	Reference []string
 }
 
 func NewIssue(msg string, args ...interface{}) Issue {
-	return Issue{fmt.Sprintf(msg, args...), nil, []Issue{}, false}
+	return Issue{fmt.Sprintf(msg, args...), nil, []Issue{}, false, nil}
 }
 
 func NewPathIssue(path []string, msg string, args ...interface{}) Issue {
-	return Issue{fmt.Sprintf(msg, args...), path, []Issue{}, false}
+	return Issue{fmt.Sprintf(msg, args...), path, []Issue{}, false, nil}
 }
 
 const (
@@ -171,6 +175,8 @@ type Annotation struct {
 	undefined    bool
 	issue        Issue
 	tag          string
+	position     Position
+	style        candiedyaml.ScalarStyle
 	NodeFlags
 }
 
@@ -270,6 +276,22 @@ func StateNode(node Node) Node {
 	return copyNodeAnnotated(node, node.GetAnnotation().SetState())
 }
 
+func PositionedNode(node Node, pos Position) Node {
+	if !pos.IsValid() || node.Position() == pos {
+		return node
+	}
+	return copyNodeAnnotated(node, node.GetAnnotation().SetPosition(pos))
+}
+
+// StyledNode sets the presentation style used to marshal a multi-line
+// string value.
+func StyledNode(node Node, style candiedyaml.ScalarStyle) Node {
+	if node.GetAnnotation().Style() == style {
+		return node
+	}
+	return copyNodeAnnotated(node, node.GetAnnotation().SetStyle(style))
+}
+
 func MassageType(value interface{}) interface{} {
 	switch value.(type) {
 	case int, int8, int16, int32:
@@ -279,11 +301,11 @@ func MassageType(value interface{}) interface{} {
 }
 
 func EmptyAnnotation() Annotation {
-	return Annotation{nil, false, false, false, "", false, false, false, Issue{}, "", 0}
+	return Annotation{nil, false, false, false, "", false, false, false, Issue{}, "", Position{}, candiedyaml.DefaultStyle, 0}
 }
 
 func NewReferencedAnnotation(node Node) Annotation {
-	return Annotation{nil, false, false, false, node.KeyName(), node.HasError(), node.Failed(), node.Undefined(), node.Issue(), "", 0}
+	return Annotation{nil, false, false, false, node.KeyName(), node.HasError(), node.Failed(), node.Undefined(), node.Issue(), "", node.Position(), candiedyaml.DefaultStyle, 0}
 }
 
 func (n Annotation) Flags() NodeFlags {
@@ -334,6 +356,15 @@ func (n Annotation) Issue() Issue {
 	return n.issue
 }
 
+func (n Annotation) Position() Position {
+	return n.position
+}
+
+// Style returns the presentation style of a multi-line string value.
+func (n Annotation) Style() candiedyaml.ScalarStyle {
+	return n.style
+}
+
 func (n Annotation) AddFlags(flags NodeFlags) Annotation {
 	n.NodeFlags |= flags
 	return n
@@ -399,6 +430,16 @@ func (n Annotation) SetTag(tag string) Annotation {
 	return n
 }
 
+func (n Annotation) SetPosition(pos Position) Annotation {
+	n.position = pos
+	return n
+}
+
+func (n Annotation) SetStyle(style candiedyaml.ScalarStyle) Annotation {
+	n.style = style
+	return n
+}
+
 func (n Annotation) SetUndefined() Annotation {
 	n.undefined = true
 	return n
@@ -448,6 +489,9 @@ func (n AnnotatedNode) MarshalYAML() (string, interface{}, error) {
 		_, v, _ = m.MarshalYAML()
 		m, ok = v.(candiedyaml.Marshaler)
 	}
+	if s, ok := v.(string); ok && n.style != candiedyaml.DefaultStyle {
+		return "", candiedyaml.Styled{Value: s, Style: n.style}, nil
+	}
 	return "", v, nil
 }
 
@@ -509,7 +553,10 @@ func (n AnnotatedNode) EquivalentToNode(o Node) bool {
 	return b
 }
 
-func EmbeddedDynaml(root Node, interpol bool) *string {
+// EmbeddedDynaml returns the dynaml expression of a node value. If
+// interpolation is enabled, expressions embedded in strings are
+// detected by the optionally given delimiters (default "((" and "))").
+func EmbeddedDynaml(root Node, interpol bool, delims ...Delimiters) *string {
 	rootString, ok := root.Value().(string)
 	if !ok {
 		return nil
@@ -525,11 +572,29 @@ func EmbeddedDynaml(root Node, interpol bool) *string {
 	if !interpol {
 		return nil
 	}
-	_, expr := convertToExpression(rootString, false)
+	_, expr := delimiters(delims).convert(rootString, false)
 	return expr
 }
 
-func UnescapeDynaml(root Node, interpol bool) Node {
+// EscapeDynaml escapes the dynaml expressions of a string value, such that
+// the unescaping done by processing the escaped string (see UnescapeDynaml)
+// yields the original string. If interpolation is enabled, expressions
+// embedded in strings are detected by the optionally given delimiters.
+func EscapeDynaml(value string, interpol bool, delims ...Delimiters) string {
+	if strings.HasPrefix(value, "((") &&
+		strings.HasSuffix(value, "))") {
+		return "((!" + value[2:]
+	}
+	if !interpol {
+		return value
+	}
+	return delimiters(delims).escape(value)
+}
+
+// UnescapeDynaml unescapes escaped dynaml expressions and merge keys.
+// If interpolation is enabled, escaped expressions embedded in strings
+// are detected by the optionally given delimiters.
+func UnescapeDynaml(root Node, interpol bool, delims ...Delimiters) Node {
 	if root.Value() == nil {
 		return root
 	}
@@ -544,7 +609,7 @@ func UnescapeDynaml(root Node, interpol bool) Node {
 			return root
 		}
 		if interpol {
-			str, _ := convertToExpression(value, true)
+			str, _ := delimiters(delims).convert(value, true)
 			if str != nil && *str != value {
 				return NewNode(*str, root.SourceName())
 			}
@@ -39,7 +39,30 @@ func UnmarshalMulti(sourceName string, source []byte) ([]Node, error) {
 	return ParseMulti(sourceName, source)
 }
 
+// ParseWithPositions parses a single document like Parse, but additionally
+// annotates the nodes with their position in the source.
+func ParseWithPositions(sourceName string, source []byte) (Node, error) {
+	docs, err := ParseMultiWithPositions(sourceName, source)
+	if err != nil {
+		return nil, err
+	}
+	if len(docs) > 1 {
+		return nil, fmt.Errorf("multi document not possible")
+	}
+	return docs[0], err
+}
+
 func ParseMulti(sourceName string, source []byte) ([]Node, error) {
+	return parseMulti(sourceName, source, false)
+}
+
+// ParseMultiWithPositions parses a multi document stream like ParseMulti,
+// but additionally annotates the nodes with their position in the source.
+func ParseMultiWithPositions(sourceName string, source []byte) ([]Node, error) {
+	return parseMulti(sourceName, source, true)
+}
+
+func parseMulti(sourceName string, source []byte, positions bool) ([]Node, error) {
 	docs := []Node{}
 
 	if len(bytes.Trim(source, " \t\n\r")) == 0 {
@@ -47,6 +70,9 @@ func ParseMulti(sourceName string, source []byte) ([]Node, error) {
 	}
 	r := bytes.NewBuffer(source)
 	d := candiedyaml.NewDecoder(r)
+	if positions {
+		d.TrackPositions()
+	}
 
 	for d.HasNext() {
 		var parsed interface{}
@@ -68,6 +94,15 @@ var arrayType = reflect.TypeOf([]interface{}{})
 
 func Sanitize(sourceName string, root interface{}) (Node, error) {
 	switch rootVal := root.(type) {
+	case candiedyaml.Positioned:
+		n, err := Sanitize(sourceName, rootVal.Value)
+		if err != nil {
+			return nil, err
+		}
+		if rootVal.Style != candiedyaml.DefaultStyle {
+			n = StyledNode(n, rootVal.Style)
+		}
+		return PositionedNode(n, NewPosition(rootVal.Line, rootVal.Column)), nil
 	case time.Time:
 		return NewNode(rootVal.Format("2019-01-08T10:06:26Z"), sourceName), nil
 	case map[interface{}]interface{}:
@@ -79,6 +79,32 @@ doc2:
 			Expect(len(docs)).To(Equal(2))
 		})
 	})
+
+	Context("parsing with positions", func() {
+		It("annotates nodes with their source position", func() {
+			parsed, err := ParseWithPositions("test", []byte(`
+foo:
+  bar: alice
+list:
+  - bob
+`))
+			Expect(err).NotTo(HaveOccurred())
+			foo := parsed.Value().(map[string]Node)["foo"]
+			Expect(foo.Position()).To(Equal(NewPosition(3, 3)))
+			Expect(foo.Value().(map[string]Node)["bar"].Position()).To(Equal(NewPosition(3, 8)))
+			bob := parsed.Value().(map[string]Node)["list"].Value().([]Node)[0]
+			Expect(bob.Position()).To(Equal(NewPosition(5, 5)))
+			Expect(Location(bob)).To(Equal("test:5"))
+		})
+
+		It("omits positions for regular parsing", func() {
+			parsed, err := Parse("test", []byte("foo: bar\n"))
+			Expect(err).NotTo(HaveOccurred())
+			foo := parsed.Value().(map[string]Node)["foo"]
+			Expect(foo.Position().IsValid()).To(BeFalse())
+			Expect(Location(foo)).To(Equal("test"))
+		})
+	})
 })
 
 func parsesAs(source string, expr interface{}) {
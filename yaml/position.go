@@ -0,0 +1,37 @@
+package yaml
+
+import (
+	"fmt"
+)
+
+// Position describes the location of a node in its source document.
+// Lines and columns are 1-based, the zero value means unknown.
+type Position struct {
+	Line   int
+	Column int
+}
+
+func NewPosition(line, column int) Position {
+	return Position{line, column}
+}
+
+func (p Position) IsValid() bool {
+	return p.Line > 0
+}
+
+func (p Position) String() string {
+	if !p.IsValid() {
+		return ""
+	}
+	return fmt.Sprintf("%d:%d", p.Line, p.Column)
+}
+
+// Location returns the source name of a node, followed by the line
+// of its definition, if known (for example stub.yml:14).
+func Location(node Node) string {
+	pos := node.Position()
+	if !pos.IsValid() {
+		return node.SourceName()
+	}
+	return fmt.Sprintf("%s:%d", node.SourceName(), pos.Line)
+}
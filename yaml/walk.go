@@ -0,0 +1,90 @@
+package yaml
+
+import (
+	"errors"
+	"fmt"
+)
+
+// SkipNode can be returned by a visitor to skip the children
+// of the visited node.
+var SkipNode = errors.New("skip node")
+
+// Walk traverses the given document in pre-order and calls the visitor
+// for every node with its path. Map entries are visited ordered by their
+// keys, list entries are denoted by path elements of the form `[<index>]`
+// (as understood by Find). The traversal stops at the first error
+// returned by the visitor, except SkipNode, which just skips the children
+// of the actual node.
+func Walk(node Node, visit func(path []string, n Node) error) error {
+	return walk([]string{}, node, visit)
+}
+
+func walk(path []string, node Node, visit func(path []string, n Node) error) error {
+	err := visit(path, node)
+	if err != nil {
+		if err == SkipNode {
+			return nil
+		}
+		return err
+	}
+	if node == nil {
+		return nil
+	}
+	switch v := node.Value().(type) {
+	case map[string]Node:
+		for _, k := range GetSortedKeys(v) {
+			if err := walk(append(path[:len(path):len(path)], k), v[k], visit); err != nil {
+				return err
+			}
+		}
+	case []Node:
+		for i, e := range v {
+			if err := walk(append(path[:len(path):len(path)], fmt.Sprintf("[%d]", i)), e, visit); err != nil {
+				return err
+			}
+		}
+	}
+	return nil
+}
+
+// WalkReplace traverses the given document like Walk, but uses the node
+// returned by the visitor instead of the visited one. The children of
+// the returned node are traversed afterwards. Maps and lists are always
+// copied, the given document is never modified.
+func WalkReplace(node Node, visit func(path []string, n Node) (Node, error)) (Node, error) {
+	return walkReplace([]string{}, node, visit)
+}
+
+func walkReplace(path []string, node Node, visit func(path []string, n Node) (Node, error)) (Node, error) {
+	node, err := visit(path, node)
+	if err != nil {
+		if err == SkipNode {
+			return node, nil
+		}
+		return nil, err
+	}
+	if node == nil {
+		return nil, nil
+	}
+	switch v := node.Value().(type) {
+	case map[string]Node:
+		result := make(map[string]Node, len(v))
+		for _, k := range GetSortedKeys(v) {
+			result[k], err = walkReplace(append(path[:len(path):len(path)], k), v[k], visit)
+			if err != nil {
+				return nil, err
+			}
+		}
+		return SubstituteNode(result, node), nil
+	case []Node:
+		result := make([]Node, len(v))
+		for i, e := range v {
+			result[i], err = walkReplace(append(path[:len(path):len(path)], fmt.Sprintf("[%d]", i)), e, visit)
+			if err != nil {
+				return nil, err
+			}
+		}
+		return SubstituteNode(result, node), nil
+	}
+	return node, nil
+}
@@ -0,0 +1,72 @@
+package yaml
+
+import (
+	"errors"
+	"strings"
+
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("Walking documents", func() {
+	source := `
+b:
+  - x
+  - e: 1
+a:
+  d: 2
+  c: 3
+`
+
+	It("visits nodes in pre-order with sorted keys", func() {
+		doc, err := Parse("test", []byte(source))
+		Expect(err).NotTo(HaveOccurred())
+
+		var paths []string
+		err = Walk(doc, func(path []string, n Node) error {
+			paths = append(paths, strings.Join(path, "."))
+			return nil
+		})
+		Expect(err).NotTo(HaveOccurred())
+		Expect(paths).To(Equal([]string{"", "a", "a.c", "a.d", "b", "b.[0]", "b.[1]", "b.[1].e"}))
+	})
+
+	It("skips children and stops on errors", func() {
+		doc, err := Parse("test", []byte(source))
+		Expect(err).NotTo(HaveOccurred())
+
+		var paths []string
+		err = Walk(doc, func(path []string, n Node) error {
+			paths = append(paths, strings.Join(path, "."))
+			if len(path) == 1 && path[0] == "a" {
+				return SkipNode
+			}
+			if len(path) == 2 && path[1] == "[1]" {
+				return errors.New("stop")
+			}
+			return nil
+		})
+		Expect(err).To(MatchError("stop"))
+		Expect(paths).To(Equal([]string{"", "a", "b", "b.[0]", "b.[1]"}))
+	})
+
+	It("replaces nodes without modifying the original document", func() {
+		doc, err := Parse("test", []byte(source))
+		Expect(err).NotTo(HaveOccurred())
+
+		result, err := WalkReplace(doc, func(path []string, n Node) (Node, error) {
+			if i, ok := n.Value().(int64); ok {
+				return NewNode(i*10, "test"), nil
+			}
+			return n, nil
+		})
+		Expect(err).NotTo(HaveOccurred())
+		Expect(Normalize(result)).To(Equal(map[string]interface{}{
+			"a": map[string]interface{}{"c": int64(30), "d": int64(20)},
+			"b": []interface{}{"x", map[string]interface{}{"e": int64(10)}},
+		}))
+		value, ok := FindInt(doc, nil, "a", "c")
+		Expect(ok).To(BeTrue())
+		Expect(value).To(Equal(int64(3)))
+	})
+})